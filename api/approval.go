@@ -0,0 +1,254 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codepipeline"
+	"github.com/olebedev/config"
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api/objects"
+)
+
+const stagesPath = "Resources.HerogatePipeline.Properties.Stages"
+
+const (
+	approvalStageName  = "Approval"
+	approvalActionName = "Approve"
+)
+
+// ErrNoPendingApproval is returned when resolving an approval, but no approvals are pending.
+var ErrNoPendingApproval = errors.New("No approvals are pending")
+
+// GetApproval returns whether or not the application pipeline has the manual approval stage.
+func (c *Client) GetApproval(appName string) (bool, error) {
+	if _, err := c.GetApp(appName); err != nil {
+		return false, err
+	}
+
+	return templateApproval(c.GetTemplate(appName)), nil
+}
+
+// SetApproval adds or removes the manual approval stage before the Deployer stage.
+// When the approval did not change, it does not perform updates.
+// When the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) SetApproval(appName string, enabled bool) error {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return err
+	}
+	if err = checkStackIdle(app); err != nil {
+		return err
+	}
+
+	base := c.GetTemplate(appName)
+	if templateApproval(base) == enabled {
+		return nil
+	}
+
+	return c.updateStack(appName, generateApprovalTemplate(base, enabled))
+}
+
+// DescribeApprovals returns pending approvals of the application pipeline.
+// CodePipeline allows only one execution in a stage, so it returns at most one approval.
+func (c *Client) DescribeApprovals(appName string) ([]*objects.Approval, error) {
+	approvals := []*objects.Approval{}
+
+	resp, err := c.codePipeline.GetPipelineState(&codepipeline.GetPipelineStateInput{
+		Name: aws.String(appName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stage := range resp.StageStates {
+		if aws.StringValue(stage.StageName) != approvalStageName || stage.LatestExecution == nil {
+			continue
+		}
+		for _, action := range stage.ActionStates {
+			execution := action.LatestExecution
+			if execution == nil || aws.StringValue(execution.Status) != codepipeline.ActionExecutionStatusInProgress {
+				continue
+			}
+
+			approval := &objects.Approval{
+				ExecutionID: aws.StringValue(stage.LatestExecution.PipelineExecutionId),
+				Token:       aws.StringValue(execution.Token),
+				RequestedAt: aws.TimeValue(execution.LastStatusChange),
+			}
+			if err = c.setApprovalRevision(appName, approval); err != nil {
+				return nil, err
+			}
+			approvals = append(approvals, approval)
+		}
+	}
+
+	return approvals, nil
+}
+
+// setApprovalRevision sets the source revision of the pipeline execution to the approval.
+func (c *Client) setApprovalRevision(appName string, approval *objects.Approval) error {
+	revision, summary, err := c.describeExecutionRevision(appName, approval.ExecutionID)
+	if err != nil {
+		return err
+	}
+	approval.Revision = revision
+	approval.Summary = summary
+	approval.ImageTag = imageTag(revision)
+
+	return nil
+}
+
+// describeExecutionRevision returns the source revision and its summary of the pipeline execution.
+func (c *Client) describeExecutionRevision(appName string, executionID string) (string, string, error) {
+	resp, err := c.codePipeline.GetPipelineExecution(&codepipeline.GetPipelineExecutionInput{
+		PipelineName:        aws.String(appName),
+		PipelineExecutionId: aws.String(executionID),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, revision := range resp.PipelineExecution.ArtifactRevisions {
+		if aws.StringValue(revision.Name) == "HerogateSource" {
+			return aws.StringValue(revision.RevisionId), aws.StringValue(revision.RevisionSummary), nil
+		}
+	}
+	return "", "", nil
+}
+
+// imageTag returns the first 8 characters of the revision as the Builder tags the image.
+func imageTag(revision string) string {
+	if len(revision) > 8 {
+		return revision[:8]
+	}
+	return revision
+}
+
+// ResolveApproval approves or rejects the pending approval, and returns it.
+// If no approvals are pending, returns ErrNoPendingApproval.
+func (c *Client) ResolveApproval(appName string, approved bool, summary string) (*objects.Approval, error) {
+	approvals, err := c.DescribeApprovals(appName)
+	if err != nil {
+		return nil, err
+	}
+	if len(approvals) == 0 {
+		return nil, ErrNoPendingApproval
+	}
+	approval := approvals[0]
+
+	status := codepipeline.ApprovalStatusApproved
+	if !approved {
+		status = codepipeline.ApprovalStatusRejected
+	}
+	_, err = c.codePipeline.PutApprovalResult(&codepipeline.PutApprovalResultInput{
+		PipelineName: aws.String(appName),
+		StageName:    aws.String(approvalStageName),
+		ActionName:   aws.String(approvalActionName),
+		Token:        aws.String(approval.Token),
+		Result: &codepipeline.ApprovalResult{
+			Status:  aws.String(status),
+			Summary: aws.String(summary),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+func templateApproval(template string) bool {
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	return stageIndex(cfg, approvalStageName) >= 0
+}
+
+func generateApprovalTemplate(base string, enabled bool) string {
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	setApprovalStage(cfg, enabled)
+
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template
+}
+
+// setApprovalStage inserts the approval stage before the Deployer stage, or removes it.
+func setApprovalStage(cfg *config.Config, enabled bool) {
+	stages, err := cfg.List(stagesPath)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg,
+		}).Fatal("Failed to get pipeline stages" + err.Error())
+	}
+
+	index := stageIndex(cfg, approvalStageName)
+	if enabled == (index >= 0) {
+		return
+	}
+
+	newStages := []interface{}{}
+	if enabled {
+		deployer := stageIndex(cfg, "Deployer")
+		if deployer < 0 {
+			deployer = len(stages)
+		}
+		newStages = append(newStages, stages[:deployer]...)
+		newStages = append(newStages, map[string]interface{}{
+			"Name": approvalStageName,
+			"Actions": []interface{}{
+				map[string]interface{}{
+					"Name": approvalActionName,
+					"ActionTypeId": map[string]interface{}{
+						"Category": "Approval",
+						"Owner":    "AWS",
+						"Provider": "Manual",
+						"Version":  1,
+					},
+				},
+			},
+		})
+		newStages = append(newStages, stages[deployer:]...)
+	} else {
+		newStages = append(newStages, stages[:index]...)
+		newStages = append(newStages, stages[index+1:]...)
+	}
+
+	if err = cfg.Set(stagesPath, newStages); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"stages": newStages,
+			"config": cfg,
+		}).Fatal("Failed to set pipeline stages to template" + err.Error())
+	}
+}
+
+// stageIndex returns the index of the pipeline stage. If the stage is not found, returns -1.
+func stageIndex(cfg *config.Config, name string) int {
+	stages, err := cfg.List(stagesPath)
+	if err != nil {
+		return -1
+	}
+	for i := range stages {
+		if stageName, err := cfg.String(fmt.Sprintf("%s.%d.Name", stagesPath, i)); err == nil && stageName == name {
+			return i
+		}
+	}
+	return -1
+}
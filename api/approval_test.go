@@ -0,0 +1,164 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codepipeline"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestGenerateApprovalTemplate(t *testing.T) {
+	base := `Resources:
+  HerogatePipeline:
+    Properties:
+      Stages:
+      - Name: Repository
+      - Name: Builder
+      - Name: Tester
+      - Name: Deployer
+`
+	if templateApproval(base) {
+		t.Fatal("Expected approval is disabled, but enabled")
+	}
+
+	template := generateApprovalTemplate(base, true)
+	expected := `Resources:
+  HerogatePipeline:
+    Properties:
+      Stages:
+      - Name: Repository
+      - Name: Builder
+      - Name: Tester
+      - Actions:
+        - ActionTypeId:
+            Category: Approval
+            Owner: AWS
+            Provider: Manual
+            Version: 1
+          Name: Approve
+        Name: Approval
+      - Name: Deployer
+`
+	if template != expected {
+		t.Fatalf("\nExpected: %s\nActual: %s", expected, template)
+	}
+	if !templateApproval(template) {
+		t.Fatal("Expected approval is enabled, but disabled")
+	}
+
+	if generateApprovalTemplate(template, false) != base {
+		t.Fatalf("\nExpected: %s\nActual: %s", base, generateApprovalTemplate(template, false))
+	}
+}
+
+func TestDescribeApprovals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	requestedAt := time.Date(2018, time.February, 3, 1, 30, 0, 0, time.UTC)
+	codePipelineMock := mock.NewMockCodePipelineAPI(ctrl)
+	// Expect to get pipeline state
+	codePipelineMock.EXPECT().GetPipelineState(&codepipeline.GetPipelineStateInput{
+		Name: aws.String("young-eyrie-24091"),
+	}).Return(&codepipeline.GetPipelineStateOutput{
+		StageStates: []*codepipeline.StageState{
+			{
+				StageName: aws.String("Deployer"),
+				LatestExecution: &codepipeline.StageExecution{
+					PipelineExecutionId: aws.String("8a7b6c5d-0000-0000-0000-000000000000"),
+					Status:              aws.String("Succeeded"),
+				},
+				ActionStates: []*codepipeline.ActionState{
+					{
+						ActionName: aws.String("DeployAction"),
+						LatestExecution: &codepipeline.ActionExecution{
+							Status: aws.String("Succeeded"),
+						},
+					},
+				},
+			},
+			{
+				StageName: aws.String("Approval"),
+				LatestExecution: &codepipeline.StageExecution{
+					PipelineExecutionId: aws.String("1a2b3c4d-0000-0000-0000-000000000000"),
+					Status:              aws.String("InProgress"),
+				},
+				ActionStates: []*codepipeline.ActionState{
+					{
+						ActionName: aws.String("Approve"),
+						LatestExecution: &codepipeline.ActionExecution{
+							Status:           aws.String("InProgress"),
+							Token:            aws.String("0f1e2d3c-token"),
+							LastStatusChange: aws.Time(requestedAt),
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	// Expect to get the source revision of the execution
+	codePipelineMock.EXPECT().GetPipelineExecution(&codepipeline.GetPipelineExecutionInput{
+		PipelineName:        aws.String("young-eyrie-24091"),
+		PipelineExecutionId: aws.String("1a2b3c4d-0000-0000-0000-000000000000"),
+	}).Return(&codepipeline.GetPipelineExecutionOutput{
+		PipelineExecution: &codepipeline.PipelineExecution{
+			ArtifactRevisions: []*codepipeline.ArtifactRevision{
+				{
+					Name:            aws.String("HerogateSource"),
+					RevisionId:      aws.String("c9f1a2b3d4e5f60718293a4b5c6d7e8f90a1b2c3"),
+					RevisionSummary: aws.String("Fix typo"),
+				},
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.codePipeline = codePipelineMock
+
+	approvals, err := client.DescribeApprovals("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := []*objects.Approval{
+		{
+			ExecutionID: "1a2b3c4d-0000-0000-0000-000000000000",
+			Revision:    "c9f1a2b3d4e5f60718293a4b5c6d7e8f90a1b2c3",
+			Summary:     "Fix typo",
+			ImageTag:    "c9f1a2b3",
+			Token:       "0f1e2d3c-token",
+			RequestedAt: requestedAt,
+		},
+	}
+	if !cmp.Equal(expected, approvals) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, approvals))
+	}
+}
+
+func TestResolveApproval__noPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	codePipelineMock := mock.NewMockCodePipelineAPI(ctrl)
+	// Expect to get pipeline state
+	codePipelineMock.EXPECT().GetPipelineState(&codepipeline.GetPipelineStateInput{
+		Name: aws.String("young-eyrie-24091"),
+	}).Return(&codepipeline.GetPipelineStateOutput{
+		StageStates: []*codepipeline.StageState{
+			{StageName: aws.String("Deployer")},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.codePipeline = codePipelineMock
+
+	_, err := client.ResolveApproval("young-eyrie-24091", true, "")
+	if err != ErrNoPendingApproval {
+		t.Fatalf("Expected error is ErrNoPendingApproval, but get `%v`", err)
+	}
+}
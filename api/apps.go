@@ -2,26 +2,31 @@ package api
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/olebedev/config"
 	"github.com/sirupsen/logrus"
 	"github.com/wata727/herogate/api/assets"
 	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/api/options"
 )
 
-// XXX: Count of resources of `assets/platform.yaml`
-var totalResources = 27.0
-
 //go:generate go-bindata -o assets/assets.go -pkg assets assets/platform.yaml
 
 // CreateApp creates a new CloudFormation stack and wait until stack create complete.
 // When the stack is created, returns ALB endpoint URL and CodeCommit URL.
-// If the stack creation is failed, delete this stack.
-func (c *Client) CreateApp(appName string) *objects.App {
+// If the stack creation is failed, delete this stack and returns StackFailureError.
+// When `KeepOnFailure` option is true, the failed stack is kept for debugging.
+// When `Branch` option is specified, the pipeline deploys the branch instead of master.
+// When `Parent` option is specified, it creates a review app which tracks the parent repository.
+// When `EnvVars`, `DesiredCount`, `CPU` or `Memory` options are specified, the app is created with them.
+func (c *Client) CreateApp(appName string, options *options.CreateApp) (*objects.App, error) {
 	yaml, err := assets.Asset("assets/platform.yaml")
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -29,57 +34,81 @@ func (c *Client) CreateApp(appName string) *objects.App {
 		}).Fatal("Failed to load the template: " + err.Error())
 	}
 
-	_, err = c.cloudFormation.CreateStack(&cloudformation.CreateStackInput{
+	template := string(yaml)
+	if options.Branch != "" && options.Branch != defaultBranch {
+		template = generateUpdatedBranchTemplate(template, options.Branch)
+	}
+	if len(options.EnvVars) > 0 {
+		template = generateUpdatedEnvVarsTemplate(template, options.EnvVars)
+	}
+	template = generateFormationTemplate(template, options)
+	tags := []*cloudformation.Tag{
+		{
+			Key:   aws.String("herogate-platform-version"),
+			Value: aws.String(PlatformVersion),
+		},
+	}
+	if options.Parent != "" {
+		template, err = c.generateReviewTemplate(template, options.Parent)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, &cloudformation.Tag{
+			Key:   aws.String("herogate-parent-app"),
+			Value: aws.String(options.Parent),
+		})
+	}
+
+	input := &cloudformation.CreateStackInput{
 		StackName:        aws.String(appName),
-		TemplateBody:     aws.String((string(yaml))),
+		TemplateBody:     aws.String(template),
 		TimeoutInMinutes: aws.Int64(10),
 		Capabilities:     []*string{aws.String("CAPABILITY_NAMED_IAM")},
-		Tags: []*cloudformation.Tag{
-			{
-				Key:   aws.String("herogate-platform-version"),
-				Value: aws.String("1.0"),
-			},
-		},
-	})
+		Tags:             tags,
+	}
+	if options.KeepOnFailure {
+		// Keep resources created successfully for debugging
+		input.OnFailure = aws.String(cloudformation.OnFailureDoNothing)
+	}
+	_, err = c.cloudFormation.CreateStack(input)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"appName": appName,
 		}).Fatal("Failed to request for creating stack: " + err.Error())
 	}
 
-	err = c.cloudFormation.WaitUntilStackCreateComplete(&cloudformation.DescribeStacksInput{
+	waitErr := c.cloudFormation.WaitUntilStackCreateComplete(&cloudformation.DescribeStacksInput{
 		StackName: aws.String(appName),
 	})
-	if err != nil {
-		logrus.WithFields(logrus.Fields{
-			"appName": appName,
-		}).Fatal("Failed to wait stack creation: " + err.Error())
-	}
 
 	app, err := c.GetApp(appName)
 	if err != nil {
+		if waitErr != nil {
+			err = waitErr
+		}
 		logrus.WithFields(logrus.Fields{
 			"appName": appName,
-		}).Fatal("Failed to get app: " + err.Error())
+		}).Fatal("Failed to wait stack creation: " + err.Error())
 	}
 
-	c.validateAppStatus(app)
+	if app.Status != "CREATE_COMPLETE" {
+		return nil, c.handleCreationFailure(app, options)
+	}
 
-	return app
+	return app, nil
 }
 
-func (c *Client) validateAppStatus(app *objects.App) {
-	if app.Status != "CREATE_COMPLETE" {
-		resourcesResp, err := c.cloudFormation.ListStackResources(&cloudformation.ListStackResourcesInput{
-			StackName: aws.String(app.Name),
-		})
-		if err != nil {
-			logrus.WithFields(logrus.Fields{
-				"appName": app.Name,
-			}).Fatal("Failed to get failed stack resources: " + err.Error())
-		}
+// handleCreationFailure returns the failure report of the stack.
+// If `KeepOnFailure` option is false, it deletes the stack after reporting.
+func (c *Client) handleCreationFailure(app *objects.App, options *options.CreateApp) error {
+	failures, err := c.describeFailures(app.Name)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": app.Name,
+		}).Fatal("Failed to get failed stack events: " + err.Error())
+	}
 
-		// If status is not `CREATE_COMPLETE`, delete the stack.
+	if !options.KeepOnFailure {
 		_, err = c.cloudFormation.DeleteStack(&cloudformation.DeleteStackInput{
 			StackName: aws.String(app.Name),
 		})
@@ -88,20 +117,24 @@ func (c *Client) validateAppStatus(app *objects.App) {
 				"appName": app.Name,
 			}).Fatal("Failed to request for deleting stack: " + err.Error())
 		}
+	}
 
-		logrus.WithFields(logrus.Fields{
-			"appName":   app.Name,
-			"summaries": resourcesResp.StackResourceSummaries,
-		}).Fatal("Failed to stack creation.")
+	return &StackFailureError{
+		AppName:  app.Name,
+		Status:   app.Status,
+		Failures: failures,
+		Kept:     options.KeepOnFailure,
 	}
 }
 
 // GetAppCreationProgress returns the creation progress of the application.
-// This function calculates the proportion of resources that are "CREATE_COMPLETE".
+// This function calculates the proportion of resources that are "CREATE_COMPLETE"
+// in all resources declared in the stack template.
 func (c *Client) GetAppCreationProgress(appName string) int {
-	resp, err := c.cloudFormation.ListStackResources(&cloudformation.ListStackResourcesInput{
-		StackName: aws.String(appName),
-	})
+	// The resource types in the template summary are distinct, so resources are counted from the template.
+	total := templateResourceCount(c.GetTemplate(appName))
+
+	resources, err := c.listStackResources(appName)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"appName": appName,
@@ -109,13 +142,30 @@ func (c *Client) GetAppCreationProgress(appName string) int {
 	}
 
 	var created int
-	for _, s := range resp.StackResourceSummaries {
+	for _, s := range resources {
 		if aws.StringValue(s.ResourceStatus) == "CREATE_COMPLETE" {
 			created++
 		}
 	}
 
-	return int((float64(created) / totalResources) * 100)
+	return progressRate(created, total)
+}
+
+// templateResourceCount returns the number of resources declared in the template.
+func templateResourceCount(template string) int {
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	resources, err := cfg.Map("Resources")
+	if err != nil {
+		logrus.Debug("Failed to get resources: " + err.Error())
+		return 0
+	}
+	return len(resources)
 }
 
 // GetApp returns the application object.
@@ -132,10 +182,17 @@ func (c *Client) GetApp(appName string) (*objects.App, error) {
 	}
 	stack := resp.Stacks[0]
 
-	var repository, endpoint, platformVersion string
+	var repository, endpoint, platformVersion, parent, pipeline, stage string
 	for _, tag := range stack.Tags {
-		if aws.StringValue(tag.Key) == "herogate-platform-version" {
+		switch aws.StringValue(tag.Key) {
+		case "herogate-platform-version":
 			platformVersion = aws.StringValue(tag.Value)
+		case "herogate-parent-app":
+			parent = aws.StringValue(tag.Value)
+		case "herogate-pipeline":
+			pipeline = aws.StringValue(tag.Value)
+		case "herogate-pipeline-stage":
+			stage = aws.StringValue(tag.Value)
 		}
 	}
 	if platformVersion == "" {
@@ -157,9 +214,34 @@ func (c *Client) GetApp(appName string) (*objects.App, error) {
 		Repository:      repository,
 		Endpoint:        endpoint,
 		PlatformVersion: platformVersion,
+		Parent:          parent,
+		Pipeline:        pipeline,
+		Stage:           stage,
 	}, nil
 }
 
+// ReviewAppsExistError is an error when review apps of the application still exist.
+// Review apps track the repository of the parent, so they must be destroyed before the parent.
+type ReviewAppsExistError struct {
+	AppName    string
+	ReviewApps []string
+}
+
+func (e *ReviewAppsExistError) Error() string {
+	return fmt.Sprintf("%s has review apps: %s", e.AppName, strings.Join(e.ReviewApps, ", "))
+}
+
+// ReviewApps returns names of review apps of the parent app in the apps.
+func ReviewApps(apps []*objects.App, parentName string) []string {
+	names := []string{}
+	for _, app := range apps {
+		if app.Parent == parentName {
+			names = append(names, app.Name)
+		}
+	}
+	return names
+}
+
 // DestroyApp destroys resources in the following order:
 //
 // - S3 Bucket
@@ -167,12 +249,79 @@ func (c *Client) GetApp(appName string) (*objects.App, error) {
 // - CloudFormation Stack
 //
 // This function waits until stack deletion complete.
+// When the stack is being changed by another operation, returns StackBusyError.
+// When review apps of the application exist, returns ReviewAppsExistError without deleting anything.
+// If the stack deletion is failed, returns StackFailureError.
 func (c *Client) DestroyApp(appName string) error {
-	if _, err := c.GetApp(appName); err != nil {
+	app, err := c.GetApp(appName)
+	if err != nil {
 		return err
 	}
+	if err = checkStackIdle(app); err != nil {
+		return err
+	}
+	if reviewApps := ReviewApps(c.ListApps(), appName); len(reviewApps) > 0 {
+		return &ReviewAppsExistError{AppName: appName, ReviewApps: reviewApps}
+	}
 
 	// At first, delete S3 bucket because if the bucket is not empty, DeleteStack is failed.
+	c.deleteArtifactStore(appName)
+	// After that, delete ECR because if images exist, DeleteStack is failed.
+	c.deleteRegistry(appName)
+
+	// At last, delete CloudFormation stack.
+	_, err = c.cloudFormation.DeleteStack(&cloudformation.DeleteStackInput{
+		StackName: aws.String(appName),
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to request for deleting stack: " + err.Error())
+	}
+	return c.waitStackDeletion(appName)
+}
+
+// waitStackDeletion waits until stack deletion complete.
+// If the stack deletion is failed, returns StackFailureError.
+func (c *Client) waitStackDeletion(appName string) error {
+	waitErr := c.cloudFormation.WaitUntilStackDeleteComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(appName),
+	})
+
+	app, err := c.GetApp(appName)
+	if err != nil {
+		// Deletion success!
+		return nil
+	}
+
+	// If it can get the application, check status
+	if app.Status != "DELETE_COMPLETE" {
+		failures, err := c.describeFailures(appName)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"appName": appName,
+			}).Fatal("Failed to get failed stack events: " + err.Error())
+		}
+
+		return &StackFailureError{
+			AppName:  appName,
+			Status:   app.Status,
+			Failures: failures,
+			Kept:     true,
+		}
+	}
+	if waitErr != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to wait stack deletion: " + waitErr.Error())
+	}
+
+	return nil
+}
+
+// deleteArtifactStore empties and deletes the S3 bucket for pipeline artifacts.
+// It returns whether or not the bucket is deleted.
+func (c *Client) deleteArtifactStore(appName string) bool {
 	s3Resource, err := c.cloudFormation.DescribeStackResource(&cloudformation.DescribeStackResourceInput{
 		StackName:         aws.String(appName),
 		LogicalResourceId: aws.String("HerogatePipelineArtifactStore"),
@@ -181,48 +330,58 @@ func (c *Client) DestroyApp(appName string) error {
 		logrus.WithFields(logrus.Fields{
 			"appName": appName,
 		}).Debugf("Failed to get S3 bucket: " + err.Error())
-	} else {
-		s3Objects, err := c.s3.ListObjects(&s3.ListObjectsInput{
+		return false
+	}
+
+	// The bucket is versioned since the platform version 1.1, so all versions and delete markers must be deleted.
+	s3Versions, err := c.s3.ListObjectVersions(&s3.ListObjectVersionsInput{
+		Bucket: s3Resource.StackResourceDetail.PhysicalResourceId,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+			"bucket":  aws.StringValue(s3Resource.StackResourceDetail.PhysicalResourceId),
+		}).Debugf("Failed to list S3 object versions: " + err.Error())
+		return false
+	}
+
+	objectsToDelete := []*s3.ObjectIdentifier{}
+	for _, v := range s3Versions.Versions {
+		objectsToDelete = append(objectsToDelete, &s3.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+	}
+	for _, v := range s3Versions.DeleteMarkers {
+		objectsToDelete = append(objectsToDelete, &s3.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+	}
+	if len(objectsToDelete) > 0 {
+		_, err = c.s3.DeleteObjects(&s3.DeleteObjectsInput{
 			Bucket: s3Resource.StackResourceDetail.PhysicalResourceId,
+			Delete: &s3.Delete{Objects: objectsToDelete},
 		})
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"appName": appName,
 				"bucket":  aws.StringValue(s3Resource.StackResourceDetail.PhysicalResourceId),
-			}).Debugf("Failed to list S3 objects: " + err.Error())
-		} else {
-			if len(s3Objects.Contents) > 0 {
-				objectsToDelete := make([]*s3.ObjectIdentifier, len(s3Objects.Contents))
-				for i, v := range s3Objects.Contents {
-					objectsToDelete[i] = &s3.ObjectIdentifier{Key: v.Key}
-				}
-
-				_, err = c.s3.DeleteObjects(&s3.DeleteObjectsInput{
-					Bucket: s3Resource.StackResourceDetail.PhysicalResourceId,
-					Delete: &s3.Delete{Objects: objectsToDelete},
-				})
-				if err != nil {
-					logrus.WithFields(logrus.Fields{
-						"appName": appName,
-						"bucket":  aws.StringValue(s3Resource.StackResourceDetail.PhysicalResourceId),
-						"objects": objectsToDelete,
-					}).Fatalf("Failed to delete S3 objects: " + err.Error())
-				}
-			}
-
-			_, err = c.s3.DeleteBucket(&s3.DeleteBucketInput{
-				Bucket: s3Resource.StackResourceDetail.PhysicalResourceId,
-			})
-			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"appName": appName,
-					"bucket":  aws.StringValue(s3Resource.StackResourceDetail.PhysicalResourceId),
-				}).Fatalf("Failed to delete S3 bucket: " + err.Error())
-			}
+				"objects": objectsToDelete,
+			}).Fatalf("Failed to delete S3 objects: " + err.Error())
 		}
 	}
 
-	// After that, delete ECR because if images exist, DeleteStack is failed.
+	_, err = c.s3.DeleteBucket(&s3.DeleteBucketInput{
+		Bucket: s3Resource.StackResourceDetail.PhysicalResourceId,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+			"bucket":  aws.StringValue(s3Resource.StackResourceDetail.PhysicalResourceId),
+		}).Fatalf("Failed to delete S3 bucket: " + err.Error())
+	}
+
+	return true
+}
+
+// deleteRegistry deletes the ECR repository with images.
+// It returns whether or not the repository is deleted.
+func (c *Client) deleteRegistry(appName string) bool {
 	ecrResource, err := c.cloudFormation.DescribeStackResource(&cloudformation.DescribeStackResourceInput{
 		StackName:         aws.String(appName),
 		LogicalResourceId: aws.String("HerogateRegistry"),
@@ -231,81 +390,207 @@ func (c *Client) DestroyApp(appName string) error {
 		logrus.WithFields(logrus.Fields{
 			"appName": appName,
 		}).Debugf("Failed to get ECR repository: " + err.Error())
-	} else {
-		_, err = c.ecr.DeleteRepository(&ecr.DeleteRepositoryInput{
-			Force:          aws.Bool(true),
-			RepositoryName: ecrResource.StackResourceDetail.PhysicalResourceId,
-		})
-		if err != nil {
-			logrus.WithFields(logrus.Fields{
-				"appName": appName,
-			}).Debugf("Failed to delete ECR repository: " + err.Error())
-		}
+		return false
 	}
 
-	// At last, delete CloudFormation stack.
-	_, err = c.cloudFormation.DeleteStack(&cloudformation.DeleteStackInput{
-		StackName: aws.String(appName),
+	_, err = c.ecr.DeleteRepository(&ecr.DeleteRepositoryInput{
+		Force:          aws.Bool(true),
+		RepositoryName: ecrResource.StackResourceDetail.PhysicalResourceId,
 	})
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"appName": appName,
-		}).Fatal("Failed to request for deleting stack: " + err.Error())
+		}).Debugf("Failed to delete ECR repository: " + err.Error())
+		return false
+	}
+
+	return true
+}
+
+// RepairApp recovers the application stack stuck in a failed state, and returns what it did.
+//
+// - UPDATE_ROLLBACK_FAILED: Continue the update rollback with skipping resources which failed to roll back
+// - DELETE_FAILED: Empty S3 bucket and ECR repository, and retry the deletion with keeping blocking resources
+//
+// If the stack is not stuck, it does nothing and returns no actions.
+// When the stack is being changed by another operation, returns StackBusyError.
+// If the repair is failed, returns StackFailureError.
+func (c *Client) RepairApp(appName string) (*objects.Repair, error) {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkStackIdle(app); err != nil {
+		return nil, err
+	}
+
+	repair := &objects.Repair{
+		Status:  app.Status,
+		Actions: []string{},
+	}
+	switch app.Status {
+	case "UPDATE_ROLLBACK_FAILED":
+		err = c.continueUpdateRollback(appName, repair)
+	case "DELETE_FAILED":
+		err = c.retryDeletion(appName, repair)
+	}
+
+	return repair, err
+}
+
+func (c *Client) continueUpdateRollback(appName string, repair *objects.Repair) error {
+	failures, err := c.describeStuckResources(appName, "UPDATE_FAILED")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to get failed stack events: " + err.Error())
+	}
+
+	var resourcesToSkip []*string
+	for _, failure := range failures {
+		resourcesToSkip = append(resourcesToSkip, aws.String(failure.LogicalResourceID))
+		repair.Actions = append(repair.Actions, fmt.Sprintf("Skipped %s (%s) which failed to roll back: %s", failure.LogicalResourceID, failure.ResourceType, failure.Reason))
 	}
-	err = c.cloudFormation.WaitUntilStackDeleteComplete(&cloudformation.DescribeStacksInput{
+
+	_, err = c.cloudFormation.ContinueUpdateRollback(&cloudformation.ContinueUpdateRollbackInput{
+		StackName:       aws.String(appName),
+		ResourcesToSkip: resourcesToSkip,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to request for continuing update rollback: " + err.Error())
+	}
+	repair.Actions = append(repair.Actions, "Continued the update rollback")
+
+	// The waiter regards UPDATE_ROLLBACK_COMPLETE as a failure, so check the status after that.
+	err = c.cloudFormation.WaitUntilStackUpdateComplete(&cloudformation.DescribeStacksInput{
 		StackName: aws.String(appName),
 	})
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"appName": appName,
-		}).Fatal("Failed to wait stack deletion: " + err.Error())
+		}).Debug("Waiter for update rollback is finished: " + err.Error())
 	}
 
 	app, err := c.GetApp(appName)
 	if err != nil {
-		// Deletion success!
-		return nil
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to get app: " + err.Error())
 	}
-
-	// If it can get the application, check status
-	if app.Status != "DELETE_COMPLETE" {
-		resourcesResp, err := c.cloudFormation.ListStackResources(&cloudformation.ListStackResourcesInput{
-			StackName: aws.String(app.Name),
-		})
+	if app.Status != "UPDATE_ROLLBACK_COMPLETE" {
+		failures, err := c.describeFailures(appName)
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
-				"appName": app.Name,
-			}).Fatal("Failed to get failed stack resources: " + err.Error())
+				"appName": appName,
+			}).Fatal("Failed to get failed stack events: " + err.Error())
 		}
 
-		logrus.WithFields(logrus.Fields{
-			"appName":   app.Name,
-			"summaries": resourcesResp.StackResourceSummaries,
-		}).Fatal("Failed to stack creation.")
+		return &StackFailureError{
+			AppName:  appName,
+			Status:   app.Status,
+			Failures: failures,
+			Kept:     true,
+		}
 	}
 
 	return nil
 }
 
-// GetAppDeletionProgress returns the deletion progress of the application.
-// This function calculates the proportion of resources that are "DELETE_COMPLETE".
-func (c *Client) GetAppDeletionProgress(appName string) int {
-	resp, err := c.cloudFormation.ListStackResources(&cloudformation.ListStackResourcesInput{
-		StackName: aws.String(appName),
+func (c *Client) retryDeletion(appName string, repair *objects.Repair) error {
+	deleted := map[string]bool{}
+	if c.deleteArtifactStore(appName) {
+		deleted["HerogatePipelineArtifactStore"] = true
+		repair.Actions = append(repair.Actions, "Emptied and deleted the artifact bucket")
+	}
+	if c.deleteRegistry(appName) {
+		deleted["HerogateRegistry"] = true
+		repair.Actions = append(repair.Actions, "Deleted the container registry with images")
+	}
+
+	failures, err := c.describeStuckResources(appName, "DELETE_FAILED")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to get failed stack events: " + err.Error())
+	}
+
+	var retainResources []*string
+	for _, failure := range failures {
+		if deleted[failure.LogicalResourceID] {
+			continue
+		}
+		retainResources = append(retainResources, aws.String(failure.LogicalResourceID))
+		repair.Actions = append(repair.Actions, fmt.Sprintf("Kept %s (%s) which blocks the deletion: %s", failure.LogicalResourceID, failure.ResourceType, failure.Reason))
+	}
+
+	_, err = c.cloudFormation.DeleteStack(&cloudformation.DeleteStackInput{
+		StackName:       aws.String(appName),
+		RetainResources: retainResources,
 	})
 	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to request for deleting stack: " + err.Error())
+	}
+	repair.Actions = append(repair.Actions, "Retried the deletion")
+
+	return c.waitStackDeletion(appName)
+}
+
+// GetAppDeletionProgress returns the deletion progress of the application.
+// This function calculates the proportion of resources that are "DELETE_COMPLETE" or "DELETE_SKIPPED".
+func (c *Client) GetAppDeletionProgress(appName string) int {
+	resources, err := c.listStackResources(appName)
+	if err != nil || len(resources) == 0 {
 		// When the stack deleted, returns 100%
 		return 100
 	}
 
 	var deleted int
-	for _, s := range resp.StackResourceSummaries {
-		if aws.StringValue(s.ResourceStatus) == "DELETE_COMPLETE" {
+	for _, s := range resources {
+		switch aws.StringValue(s.ResourceStatus) {
+		case "DELETE_COMPLETE", "DELETE_SKIPPED":
 			deleted++
 		}
 	}
 
-	return int((float64(deleted) / totalResources) * 100)
+	return progressRate(deleted, len(resources))
+}
+
+func (c *Client) listStackResources(appName string) ([]*cloudformation.StackResourceSummary, error) {
+	resources := []*cloudformation.StackResourceSummary{}
+	var nextToken *string
+	for {
+		resp, err := c.cloudFormation.ListStackResources(&cloudformation.ListStackResourcesInput{
+			StackName: aws.String(appName),
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resp.StackResourceSummaries...)
+
+		if resp.NextToken == nil {
+			break
+		}
+		nextToken = resp.NextToken
+	}
+
+	return resources, nil
+}
+
+// progressRate returns the percentage of completed in total. It never exceeds 100.
+func progressRate(completed int, total int) int {
+	if total == 0 {
+		return 0
+	}
+	rate := int((float64(completed) / float64(total)) * 100)
+	if rate > 100 {
+		return 100
+	}
+	return rate
 }
 
 // ListApps returns applications.
@@ -318,10 +603,17 @@ func (c *Client) ListApps() []*objects.App {
 	apps := []*objects.App{}
 
 	for _, stack := range resp.Stacks {
-		var repository, endpoint, platformVersion string
+		var repository, endpoint, platformVersion, parent, pipeline, stage string
 		for _, tag := range stack.Tags {
-			if aws.StringValue(tag.Key) == "herogate-platform-version" {
+			switch aws.StringValue(tag.Key) {
+			case "herogate-platform-version":
 				platformVersion = aws.StringValue(tag.Value)
+			case "herogate-parent-app":
+				parent = aws.StringValue(tag.Value)
+			case "herogate-pipeline":
+				pipeline = aws.StringValue(tag.Value)
+			case "herogate-pipeline-stage":
+				stage = aws.StringValue(tag.Value)
 			}
 		}
 		if platformVersion == "" {
@@ -343,6 +635,9 @@ func (c *Client) ListApps() []*objects.App {
 			Repository:      repository,
 			Endpoint:        endpoint,
 			PlatformVersion: platformVersion,
+			Parent:          parent,
+			Pipeline:        pipeline,
+			Stage:           stage,
 		})
 	}
 
@@ -407,9 +702,61 @@ func (c *Client) GetAppInfo(appName string) (*objects.AppInfo, error) {
 		})
 	}
 
+	template := c.GetTemplate(appName)
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
 	return &objects.AppInfo{
-		App:        app,
-		Containers: containers,
-		Region:     "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		App:         app,
+		Branch:      templateBranch(template),
+		Containers:  containers,
+		HealthCheck: templateHealthCheck(cfg),
+		Region:      "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 	}, nil
 }
+
+// generateFormationTemplate sets the number of tasks and the task size specified in the options.
+func generateFormationTemplate(base string, options *options.CreateApp) string {
+	if options.DesiredCount == 0 && options.CPU == "" && options.Memory == "" {
+		return base
+	}
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	settings := map[string]interface{}{}
+	if options.DesiredCount > 0 {
+		settings[desiredCountPath] = options.DesiredCount
+	}
+	if options.CPU != "" {
+		settings[taskCPUPath] = options.CPU
+	}
+	if options.Memory != "" {
+		settings[taskMemoryPath] = options.Memory
+	}
+	for path, value := range settings {
+		if err = cfg.Set(path, value); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"path":   path,
+				"value":  value,
+				"config": cfg,
+			}).Fatal("Failed to set formation to template" + err.Error())
+		}
+	}
+
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template
+}
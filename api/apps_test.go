@@ -13,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/wata727/herogate/api/assets"
 	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/api/options"
 	"github.com/wata727/herogate/mock"
 )
 
@@ -35,7 +36,7 @@ func TestCreateApp(t *testing.T) {
 		Tags: []*cloudformation.Tag{
 			{
 				Key:   aws.String("herogate-platform-version"),
-				Value: aws.String("1.0"),
+				Value: aws.String("1.5"),
 			},
 		},
 	}).Return(&cloudformation.CreateStackOutput{}, nil)
@@ -63,7 +64,7 @@ func TestCreateApp(t *testing.T) {
 				Tags: []*cloudformation.Tag{
 					{
 						Key:   aws.String("herogate-platform-version"),
-						Value: aws.String("1.0"),
+						Value: aws.String("1.5"),
 					},
 				},
 			},
@@ -73,24 +74,161 @@ func TestCreateApp(t *testing.T) {
 	client := NewClient(&ClientOption{})
 	client.cloudFormation = cfnMock
 
-	app := client.CreateApp("young-eyrie-24091")
+	app, err := client.CreateApp("young-eyrie-24091", &options.CreateApp{})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
 	expected := &objects.App{
 		Name:            "young-eyrie-24091",
 		Status:          "CREATE_COMPLETE",
 		Repository:      "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/young-eyrie-24091",
 		Endpoint:        "http://young-eyrie-24091-123456789.us-east-1.elb.amazonaws.com",
-		PlatformVersion: "1.0",
+		PlatformVersion: "1.5",
 	}
 	if !cmp.Equal(expected, app) {
 		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, app))
 	}
 }
 
+func TestCreateApp__failed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	yaml, err := assets.Asset("assets/platform.yaml")
+	if err != nil {
+		t.Fatal("Failed to load the template: " + err.Error())
+	}
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to create stack with keeping resources on failure
+	cfnMock.EXPECT().CreateStack(&cloudformation.CreateStackInput{
+		StackName:        aws.String("young-eyrie-24091"),
+		TemplateBody:     aws.String((string(yaml))),
+		TimeoutInMinutes: aws.Int64(10),
+		Capabilities:     []*string{aws.String("CAPABILITY_NAMED_IAM")},
+		OnFailure:        aws.String("DO_NOTHING"),
+		Tags: []*cloudformation.Tag{
+			{
+				Key:   aws.String("herogate-platform-version"),
+				Value: aws.String("1.5"),
+			},
+		},
+	}).Return(&cloudformation.CreateStackOutput{}, nil)
+	// Expect to fail to wait stack creation
+	cfnMock.EXPECT().WaitUntilStackCreateComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(errors.New("ResourceNotReady: failed waiting for successful resource state"))
+	// Expect to describe the failed stack
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackStatus: aws.String("CREATE_FAILED"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.5"),
+					},
+				},
+			},
+		},
+	}, nil)
+	// Expect to describe stack events
+	cfnMock.EXPECT().DescribeStackEvents(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStackEventsOutput{
+		StackEvents: []*cloudformation.StackEvent{
+			{
+				EventId:              aws.String("4"),
+				LogicalResourceId:    aws.String("young-eyrie-24091"),
+				ResourceType:         aws.String("AWS::CloudFormation::Stack"),
+				ResourceStatus:       aws.String("CREATE_FAILED"),
+				ResourceStatusReason: aws.String("The following resource(s) failed to create: [HerogateLoadBalancer, HerogateRegistry]."),
+			},
+			{
+				EventId:              aws.String("3"),
+				LogicalResourceId:    aws.String("HerogateRegistry"),
+				ResourceType:         aws.String("AWS::ECR::Repository"),
+				ResourceStatus:       aws.String("CREATE_FAILED"),
+				ResourceStatusReason: aws.String("Resource creation cancelled"),
+			},
+			{
+				EventId:              aws.String("2"),
+				LogicalResourceId:    aws.String("HerogateLoadBalancer"),
+				ResourceType:         aws.String("AWS::ElasticLoadBalancingV2::LoadBalancer"),
+				ResourceStatus:       aws.String("CREATE_FAILED"),
+				ResourceStatusReason: aws.String("You have reached the limit on the number of load balancers for your account"),
+			},
+			{
+				EventId:           aws.String("1"),
+				LogicalResourceId: aws.String("HerogateLoadBalancer"),
+				ResourceType:      aws.String("AWS::ElasticLoadBalancingV2::LoadBalancer"),
+				ResourceStatus:    aws.String("CREATE_IN_PROGRESS"),
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	_, err = client.CreateApp("young-eyrie-24091", &options.CreateApp{KeepOnFailure: true})
+	failureErr, ok := err.(*StackFailureError)
+	if !ok {
+		t.Fatalf("Expected error is StackFailureError, but get `%#v`", err)
+	}
+
+	expected := &StackFailureError{
+		AppName: "young-eyrie-24091",
+		Status:  "CREATE_FAILED",
+		Failures: []*objects.ResourceFailure{
+			{
+				LogicalResourceID: "HerogateLoadBalancer",
+				ResourceType:      "AWS::ElasticLoadBalancingV2::LoadBalancer",
+				ResourceStatus:    "CREATE_FAILED",
+				Reason:            "You have reached the limit on the number of load balancers for your account",
+				Hint:              "You may have reached a service limit. Delete unused resources or request a limit increase to AWS support.",
+			},
+		},
+		Kept: true,
+	}
+	if !cmp.Equal(expected, failureErr) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, failureErr))
+	}
+}
+
 func TestGetAppCreationProgress(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// The template declares 10 resources of 8 types
+	cfnMock.EXPECT().GetTemplate(&cloudformation.GetTemplateInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.GetTemplateOutput{
+		TemplateBody: aws.String(`Resources:
+  HerogateVPC:
+    Type: AWS::EC2::VPC
+  HerogateSubnetA:
+    Type: AWS::EC2::Subnet
+  HerogateSubnetB:
+    Type: AWS::EC2::Subnet
+  HerogateArtifactStore:
+    Type: AWS::S3::Bucket
+  HerogatePipelineRole:
+    Type: AWS::IAM::Role
+  HerogateBuilderRole:
+    Type: AWS::IAM::Role
+  HerogateRepository:
+    Type: AWS::CodeCommit::Repository
+  HerogateBuilder:
+    Type: AWS::CodeBuild::Project
+  HerogatePipeline:
+    Type: AWS::CodePipeline::Pipeline
+  HerogateApplicationService:
+    Type: AWS::ECS::Service
+`),
+	}, nil)
 	cfnMock.EXPECT().ListStackResources(&cloudformation.ListStackResourcesInput{
 		StackName: aws.String("young-eyrie-24091"),
 	}).Return(&cloudformation.ListStackResourcesOutput{
@@ -132,10 +270,10 @@ func TestGetAppCreationProgress(t *testing.T) {
 	client.cloudFormation = cfnMock
 
 	rate := client.GetAppCreationProgress("young-eyrie-24091")
-	// Total resources: 27, Created: 6
-	//   => (6 / 27) * 100 = 22.22...
-	if rate != 22 {
-		t.Fatalf("Expected progress rate is `22`, but get `%d`", rate)
+	// Total resources: 10, Created: 6
+	//   => (6 / 10) * 100 = 60
+	if rate != 60 {
+		t.Fatalf("Expected progress rate is `60`, but get `%d`", rate)
 	}
 }
 
@@ -284,6 +422,10 @@ func TestDestroyApp(t *testing.T) {
 			},
 		},
 	}, nil)
+	// Expect to list apps to find review apps
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{},
+	}, nil)
 	// Expect to describe S3 resource
 	cfnMock.EXPECT().DescribeStackResource(&cloudformation.DescribeStackResourceInput{
 		StackName:         aws.String("young-eyrie-24091"),
@@ -293,13 +435,16 @@ func TestDestroyApp(t *testing.T) {
 			PhysicalResourceId: aws.String("herogate-12345678-us-east-1-young-eyrie-24091"),
 		},
 	}, nil)
-	// Expect to list S3 objects
-	s3Mock.EXPECT().ListObjects(&s3.ListObjectsInput{
+	// Expect to list S3 object versions
+	s3Mock.EXPECT().ListObjectVersions(&s3.ListObjectVersionsInput{
 		Bucket: aws.String("herogate-12345678-us-east-1-young-eyrie-24091"),
-	}).Return(&s3.ListObjectsOutput{
-		Contents: []*s3.Object{
-			{Key: aws.String("bar")},
-			{Key: aws.String("baz")},
+	}).Return(&s3.ListObjectVersionsOutput{
+		Versions: []*s3.ObjectVersion{
+			{Key: aws.String("bar"), VersionId: aws.String("null")},
+			{Key: aws.String("baz"), VersionId: aws.String("v2")},
+		},
+		DeleteMarkers: []*s3.DeleteMarkerEntry{
+			{Key: aws.String("baz"), VersionId: aws.String("v3")},
 		},
 	}, nil)
 	// Expect to delete S3 objects
@@ -307,8 +452,9 @@ func TestDestroyApp(t *testing.T) {
 		Bucket: aws.String("herogate-12345678-us-east-1-young-eyrie-24091"),
 		Delete: &s3.Delete{
 			Objects: []*s3.ObjectIdentifier{
-				{Key: aws.String("bar")},
-				{Key: aws.String("baz")},
+				{Key: aws.String("bar"), VersionId: aws.String("null")},
+				{Key: aws.String("baz"), VersionId: aws.String("v2")},
+				{Key: aws.String("baz"), VersionId: aws.String("v3")},
 			},
 		},
 	})
@@ -389,6 +535,10 @@ func TestDestroyApp__emptyBucket(t *testing.T) {
 			},
 		},
 	}, nil)
+	// Expect to list apps to find review apps
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{},
+	}, nil)
 	// Expect to describe S3 resource
 	cfnMock.EXPECT().DescribeStackResource(&cloudformation.DescribeStackResourceInput{
 		StackName:         aws.String("young-eyrie-24091"),
@@ -398,12 +548,10 @@ func TestDestroyApp__emptyBucket(t *testing.T) {
 			PhysicalResourceId: aws.String("herogate-12345678-us-east-1-young-eyrie-24091"),
 		},
 	}, nil)
-	// Expect to list S3 objects
-	s3Mock.EXPECT().ListObjects(&s3.ListObjectsInput{
+	// Expect to list S3 object versions
+	s3Mock.EXPECT().ListObjectVersions(&s3.ListObjectVersionsInput{
 		Bucket: aws.String("herogate-12345678-us-east-1-young-eyrie-24091"),
-	}).Return(&s3.ListObjectsOutput{
-		Contents: []*s3.Object{},
-	}, nil)
+	}).Return(&s3.ListObjectVersionsOutput{}, nil)
 	// Expect to delete S3 bucket
 	s3Mock.EXPECT().DeleteBucket(&s3.DeleteBucketInput{
 		Bucket: aws.String("herogate-12345678-us-east-1-young-eyrie-24091"),
@@ -447,6 +595,70 @@ func TestDestroyApp__emptyBucket(t *testing.T) {
 	}
 }
 
+func TestDestroyApp__reviewApps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to call GetApp and return App
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackStatus: aws.String("CREATE_COMPLETE"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.0"),
+					},
+				},
+			},
+		},
+	}, nil)
+	// Expect to list apps, and the review app is found
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackName:   aws.String("young-eyrie-24091"),
+				StackStatus: aws.String("CREATE_COMPLETE"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.0"),
+					},
+				},
+			},
+			{
+				StackName:   aws.String("young-eyrie-24091-pr-feature"),
+				StackStatus: aws.String("CREATE_COMPLETE"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.0"),
+					},
+					{
+						Key:   aws.String("herogate-parent-app"),
+						Value: aws.String("young-eyrie-24091"),
+					},
+				},
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	err := client.DestroyApp("young-eyrie-24091")
+	expected := &ReviewAppsExistError{
+		AppName:    "young-eyrie-24091",
+		ReviewApps: []string{"young-eyrie-24091-pr-feature"},
+	}
+	if !cmp.Equal(err, expected) {
+		t.Fatalf("Expected error is `%#v`, but get `%#v`", expected, err)
+	}
+}
+
 func TestDestroyApp__notFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -512,10 +724,53 @@ func TestGetAppDeletionProgress(t *testing.T) {
 	client.cloudFormation = cfnMock
 
 	rate := client.GetAppDeletionProgress("young-eyrie-24091")
-	// Total resources: 27, deleted: 4
-	//   => (4 / 27) * 100 = 14.81...
-	if rate != 14 {
-		t.Fatalf("Expected progress rate is `14`, but get `%d`", rate)
+	// Total resources: 10, deleted: 4
+	//   => (4 / 10) * 100 = 40
+	if rate != 40 {
+		t.Fatalf("Expected progress rate is `40`, but get `%d`", rate)
+	}
+}
+
+func TestGetAppDeletionProgress__paging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	cfnMock.EXPECT().ListStackResources(&cloudformation.ListStackResourcesInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.ListStackResourcesOutput{
+		StackResourceSummaries: []*cloudformation.StackResourceSummary{
+			{
+				ResourceStatus: aws.String("DELETE_COMPLETE"),
+			},
+			{
+				ResourceStatus: aws.String("DELETE_SKIPPED"),
+			},
+			{
+				ResourceStatus: aws.String("DELETE_IN_PROGRESS"),
+			},
+		},
+		NextToken: aws.String("next"),
+	}, nil)
+	cfnMock.EXPECT().ListStackResources(&cloudformation.ListStackResourcesInput{
+		StackName: aws.String("young-eyrie-24091"),
+		NextToken: aws.String("next"),
+	}).Return(&cloudformation.ListStackResourcesOutput{
+		StackResourceSummaries: []*cloudformation.StackResourceSummary{
+			{
+				ResourceStatus: aws.String("DELETE_IN_PROGRESS"),
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	rate := client.GetAppDeletionProgress("young-eyrie-24091")
+	// Total resources: 4, deleted: 2
+	//   => (2 / 4) * 100 = 50
+	if rate != 50 {
+		t.Fatalf("Expected progress rate is `50`, but get `%d`", rate)
 	}
 }
 
@@ -673,6 +928,22 @@ func TestGetAppInfo(t *testing.T) {
 			},
 		},
 	}, nil)
+	// Expected to get template
+	cfnMock.EXPECT().GetTemplate(&cloudformation.GetTemplateInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.GetTemplateOutput{
+		TemplateBody: aws.String(`
+Resources:
+  HerogatePipeline:
+    Properties:
+      Stages:
+        - Name: Repository
+          Actions:
+            - Name: ChangeSource
+              Configuration:
+                BranchName: main
+`),
+	}, nil)
 	// Expected to describe services
 	ecsMock.EXPECT().DescribeServices(&ecs.DescribeServicesInput{
 		Cluster:  aws.String("young-eyrie-24091"),
@@ -716,6 +987,7 @@ func TestGetAppInfo(t *testing.T) {
 			Endpoint:        "http://young-eyrie-24091-123456789.us-east-1.elb.amazonaws.com",
 			PlatformVersion: "1.0",
 		},
+		Branch: "main",
 		Containers: []*objects.Container{
 			{
 				Name:    "web",
@@ -723,6 +995,11 @@ func TestGetAppInfo(t *testing.T) {
 				Command: []string{},
 			},
 		},
+		HealthCheck: &objects.HealthCheck{
+			Path:             "/",
+			Interval:         30,
+			HealthyThreshold: 5,
+		},
 		Region: "us-east-1",
 	}
 	if !cmp.Equal(expected, app) {
@@ -750,3 +1027,178 @@ func TestGetAppInfo__notFound(t *testing.T) {
 		t.Fatal("Expected app is nil, but get app")
 	}
 }
+
+func TestRepairApp__updateRollbackFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	gomock.InOrder(
+		// Expect to call GetApp and return App stuck in rollback
+		cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+			StackName: aws.String("young-eyrie-24091"),
+		}).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []*cloudformation.Stack{
+				{
+					StackStatus: aws.String("UPDATE_ROLLBACK_FAILED"),
+					Tags: []*cloudformation.Tag{
+						{
+							Key:   aws.String("herogate-platform-version"),
+							Value: aws.String("1.0"),
+						},
+					},
+				},
+			},
+		}, nil),
+		// Expect to call GetApp after continuing rollback
+		cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+			StackName: aws.String("young-eyrie-24091"),
+		}).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []*cloudformation.Stack{
+				{
+					StackStatus: aws.String("UPDATE_ROLLBACK_COMPLETE"),
+					Tags: []*cloudformation.Tag{
+						{
+							Key:   aws.String("herogate-platform-version"),
+							Value: aws.String("1.0"),
+						},
+					},
+				},
+			},
+		}, nil),
+	)
+	// Expect to describe stack events
+	cfnMock.EXPECT().DescribeStackEvents(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStackEventsOutput{
+		StackEvents: []*cloudformation.StackEvent{
+			{
+				EventId:              aws.String("4"),
+				LogicalResourceId:    aws.String("HerogateApplicationService"),
+				ResourceType:         aws.String("AWS::ECS::Service"),
+				ResourceStatus:       aws.String("UPDATE_FAILED"),
+				ResourceStatusReason: aws.String("Service was not ACTIVE"),
+			},
+			{
+				EventId:           aws.String("3"),
+				LogicalResourceId: aws.String("HerogateApplicationContainer"),
+				ResourceType:      aws.String("AWS::ECS::TaskDefinition"),
+				ResourceStatus:    aws.String("UPDATE_COMPLETE"),
+			},
+			{
+				EventId:              aws.String("2"),
+				LogicalResourceId:    aws.String("HerogateApplicationContainer"),
+				ResourceType:         aws.String("AWS::ECS::TaskDefinition"),
+				ResourceStatus:       aws.String("UPDATE_FAILED"),
+				ResourceStatusReason: aws.String("Invalid request"),
+			},
+			{
+				EventId:           aws.String("1"),
+				LogicalResourceId: aws.String("young-eyrie-24091"),
+				ResourceType:      aws.String("AWS::CloudFormation::Stack"),
+				ResourceStatus:    aws.String("UPDATE_IN_PROGRESS"),
+			},
+		},
+	}, nil)
+	// Expect to continue rollback with skipping only the resource still failed
+	cfnMock.EXPECT().ContinueUpdateRollback(&cloudformation.ContinueUpdateRollbackInput{
+		StackName:       aws.String("young-eyrie-24091"),
+		ResourcesToSkip: []*string{aws.String("HerogateApplicationService")},
+	}).Return(&cloudformation.ContinueUpdateRollbackOutput{}, nil)
+	// Expect to wait rollback. The waiter regards UPDATE_ROLLBACK_COMPLETE as a failure.
+	cfnMock.EXPECT().WaitUntilStackUpdateComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(errors.New("ResourceNotReady: failed waiting for successful resource state"))
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	repair, err := client.RepairApp("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := &objects.Repair{
+		Status: "UPDATE_ROLLBACK_FAILED",
+		Actions: []string{
+			"Skipped HerogateApplicationService (AWS::ECS::Service) which failed to roll back: Service was not ACTIVE",
+			"Continued the update rollback",
+		},
+	}
+	if !cmp.Equal(expected, repair) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, repair))
+	}
+}
+
+func TestRepairApp__healthy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to call GetApp and return healthy App
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackStatus: aws.String("UPDATE_COMPLETE"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.0"),
+					},
+				},
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	repair, err := client.RepairApp("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := &objects.Repair{
+		Status:  "UPDATE_COMPLETE",
+		Actions: []string{},
+	}
+	if !cmp.Equal(expected, repair) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, repair))
+	}
+}
+
+func TestGenerateFormationTemplate(t *testing.T) {
+	base := `Resources:
+  HerogateApplicationContainer:
+    Properties:
+      Cpu: "1024"
+      Memory: "2048"
+  HerogateApplicationService:
+    Properties:
+      DesiredCount: 1
+`
+
+	if template := generateFormationTemplate(base, &options.CreateApp{}); template != base {
+		t.Fatalf("Expected template is `%s`, but get `%s`", base, template)
+	}
+
+	template := generateFormationTemplate(base, &options.CreateApp{
+		DesiredCount: 2,
+		CPU:          "512",
+		Memory:       "1024",
+	})
+	expected := `Resources:
+  HerogateApplicationContainer:
+    Properties:
+      Cpu: "512"
+      Memory: "1024"
+  HerogateApplicationService:
+    Properties:
+      DesiredCount: 2
+`
+	if template != expected {
+		t.Fatalf("Expected template is `%s`, but get `%s`", expected, template)
+	}
+}
@@ -68,7 +68,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _assetsPlatformYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5b\xed\x6f\xe3\x36\xd2\xff\x9e\xbf\x82\xd0\x93\x0f\x6d\xf1\xc8\xb1\x93\x7d\x15\xd0\x07\x50\x6c\x6f\x6a\x3c\x4e\x6c\xd8\x4e\x7a\xed\xe1\x10\xd0\xd2\x58\xe6\x45\x16\x55\x92\x8a\xd7\xdd\xcd\xff\x7e\x20\x29\x59\xaf\xf4\x4b\xb2\x69\xaf\xb8\xd3\x7e\xd9\x90\x33\x9c\xe1\x6f\x86\xc3\x19\x92\x76\x7f\x9e\xce\x60\x15\x87\x58\xc0\x27\xca\x56\x58\xdc\x01\xe3\x84\x46\x0e\xb2\xce\xdb\x9d\xb6\xdd\xfe\x68\xb7\x3f\x5a\x27\x3d\xe0\x1e\x23\xb1\x50\x3d\x3f\x01\xa3\x01\x16\x80\xc6\x21\x16\x0b\xca\x56\x28\x1b\x02\x3d\x76\x5a\xed\x93\x93\x09\x70\x9a\x30\x0f\xb8\x73\x82\xd0\xff\xa0\x1b\x10\x6b\xca\x1e\x4e\xd0\x96\x33\x6d\x91\xdd\x08\xcd\x36\x31\x38\xc8\x72\x7f\x9e\x3a\x4e\xbf\x7b\xee\x38\x77\xe3\xae\xa5\x7a\xc6\x8c\xc6\xc0\x04\xd1\x03\xc9\xaf\x4b\x7c\x76\x19\x52\xef\xc1\x41\x56\xe7\xe3\x79\xab\xf3\xee\x43\xab\xdd\x6a\x9f\x75\xde\x59\x29\xc5\x0c\x07\x5b\x6a\x84\x6c\xf4\xff\xb0\x71\xd0\x0d\x5e\xc1\xb6\x0d\xa1\x3b\x1c\x26\xe0\x14\x1a\x10\x9a\xc0\xc2\x41\x4a\x85\xa9\xc0\xde\x43\xca\x50\xd1\x77\x9a\xcc\x23\x10\xae\x41\x6d\xdd\x6b\xd2\xfc\x2e\xf6\x06\x7e\x2e\x53\xc9\xab\x0c\x9f\x76\xba\x8f\x98\x84\x78\x4e\x42\x22\x36\xbf\xd2\xa8\xa0\xe8\xa7\xc8\x71\xa6\x10\x82\x27\x8a\xca\xdb\xa8\x5d\xfa\x4b\x52\x5d\x81\x70\x7f\xe5\x0e\xb2\xac\x3d\xc0\x9d\xb7\x33\x8a\x6b\x1c\x8f\x93\x79\x48\xbc\x41\x3c\x8a\x86\x38\x89\xbc\xa5\x83\x04\x4b\xe0\x68\x60\x51\x09\x59\xa5\x74\x32\x77\x90\x75\xfa\xa5\x0c\xf0\x13\xd2\x90\x21\xd7\x32\x61\x7d\xf9\x6f\x87\x75\xe7\x79\x58\x77\xde\xbd\x12\xd8\xc7\x62\x7d\xd9\x80\xf5\x84\x26\x02\x66\x78\x1e\x82\x01\xee\x9c\xe0\xe5\x90\xbf\xe6\x02\x1d\x25\x62\x4e\x93\xc8\x57\xfa\xee\x9a\x8b\x69\x1a\xf9\x44\xf7\xcd\x26\xa7\x4c\xc9\x7a\xc0\x05\x89\xb0\x0c\x91\x45\x07\x68\xb7\xd4\xbf\xb3\xad\xe9\xaf\xb0\x80\x35\xde\xec\x1b\x3f\x25\xab\xcf\x31\xed\x30\xcc\x6e\x10\x09\x60\x11\x88\x94\xca\x34\xcf\xd7\x34\x42\x2a\xda\x15\x02\x7b\xcb\x15\x44\xc2\x1c\xe6\x6b\xa4\x2f\x77\xaf\x0a\x00\xcf\x86\x39\x8d\xf5\xbb\x3c\x49\x93\xe4\x8e\xe0\x72\x4e\x3d\xa2\x3c\xc0\x34\x0f\xcd\xb2\x4f\xa9\x54\xf6\xcb\x7c\xb2\x39\x9e\xfe\x89\xf3\xb9\x7c\xc9\x7c\x54\x22\x31\x26\x31\x84\x24\x2a\xce\x2e\x6b\xaa\x4f\xa9\x4b\xfd\xbc\xd7\xc9\xfe\x67\x9a\x88\x74\xe5\x8a\x32\x35\x3f\x97\x9f\xcb\x04\x59\x60\x4f\x4c\x05\x65\x05\x06\x2d\x78\x7a\xb1\x6d\x18\x52\x4f\x01\x57\x5c\x3f\xa5\x29\x66\x0a\x95\x06\xcc\x10\x02\x2e\x30\x13\xfd\xcf\xe0\x25\x72\x90\x51\x74\x1b\xfb\x58\x80\x83\x16\x38\xe4\x5b\x2a\x1a\x82\xcb\xa2\xf2\x96\x25\x37\x23\x51\xd9\xb2\xaa\x22\x25\x63\x89\xc0\x65\x51\x66\x4f\x81\x03\x28\x45\x06\x05\x0c\x9a\x40\x4c\x39\x11\x94\x6d\x0a\x8c\xae\x27\x95\xe3\xe5\x10\x91\x71\x74\x97\x38\x0a\x60\xaa\xf2\xc0\x12\x41\xc6\x27\x21\x2b\x3a\x40\xf6\x75\xb1\x80\x80\xb2\x8d\x83\x1a\x99\x11\x1a\xad\x23\x60\xca\x3c\xb5\xae\x31\xa3\x8f\xc4\x97\xbd\xd2\xfa\x5d\xba\x5a\x11\x51\x23\xda\x66\xb8\x9d\x4a\x57\x97\x46\x0b\x12\x24\xac\x66\x38\xfd\xe5\x18\x94\x9d\x25\xff\x9a\x0d\x50\xc4\x26\x33\x45\x23\x9e\x45\xc2\x4a\x2c\xd6\xdf\x25\xc3\x91\xb7\xd4\xf8\xae\x30\x17\xc0\x2a\x24\xa3\x44\xc4\x89\xc8\x5c\x8a\xd7\xb5\xc8\xac\x93\xe9\x51\x81\x38\xeb\xbe\x4c\x48\xe8\x97\x46\xdf\x69\x6b\x45\xfe\x4c\x23\x37\xf1\x1e\x61\xe3\x66\xf6\xe7\x9a\x78\xcc\xe8\x3f\xc1\x13\x26\xfb\x96\x16\x70\x1d\x23\xa4\xb6\x9f\x17\x19\x20\x9d\xfd\xb1\x66\x2c\xa3\x90\xf5\xf6\x20\x0e\xe9\xe6\x70\x33\x6a\xfa\x67\xda\xb1\x91\xf9\x50\x43\x86\x34\xf1\x75\xf9\x49\x68\xf4\xcd\xac\xb9\x8d\xdd\x46\x5b\x36\x86\xf8\xea\xdc\xaf\xa9\x2f\xe3\xd9\xa4\xef\xce\xfa\xf7\xb7\xe3\x9e\x3b\xeb\x37\xe0\x10\xeb\x2a\x42\x6e\x29\xa8\xeb\x8e\xdd\xcb\xc1\x70\x30\xfb\xe5\xfe\xc6\xbd\xee\xf7\xee\x07\xee\x75\x8d\x25\xab\x99\xc7\x58\x2c\x2b\x96\x74\x9c\x38\xad\xac\x5b\x1b\xbc\x0a\xeb\x91\xa8\x1a\xf9\xf3\xef\xf0\x10\x94\x79\x47\x65\x37\x28\x92\xe6\xfb\x42\xf6\x1d\xeb\xde\x99\x63\xee\xdc\xf5\xea\x7b\xf7\xf4\xc2\x71\x2e\x13\xef\xc1\x5c\xe2\xe9\xde\xb2\x71\xf3\xea\x67\x99\x8a\xb3\xd3\x32\xc8\xf5\x3c\x9a\x44\x62\xe0\x3f\x65\x2d\x13\x08\x08\x8d\xb6\x7f\xe6\x75\x92\xd5\xa0\xad\x84\xa8\xae\xe4\xc0\xbd\x96\xd5\x84\xb9\x26\x92\x7d\x26\x0d\x9b\x44\x34\x2b\x23\x3f\x97\xf3\x64\xa5\x68\xc6\x34\x24\xde\xa6\x47\xbd\x24\xcf\xac\x51\x71\x8d\x58\xe7\xed\xce\xb9\xdd\x69\xdb\x9d\xf7\xd6\xb6\x77\x2a\xb0\x80\x32\x83\xb4\x55\x7f\xb1\x90\xf5\x2d\x72\xc3\x90\xae\x4b\xb6\x1c\x33\x12\x79\x24\xc6\x61\xd5\xc4\x53\x60\x8f\xc4\x03\x07\x79\xd4\x87\x38\x55\xbe\x85\x57\xf8\x77\x1a\xe1\x35\x6f\x79\x74\x55\xe2\xd0\x4b\xc8\x41\x5c\x70\x27\x9f\x45\x4a\xa2\x26\x43\xca\xf9\x86\x9e\x60\x7d\xd5\x9a\xa1\xd3\x1c\x66\xf0\xb6\x92\x1a\x60\xdb\x0f\x9d\x11\xbe\x7d\x10\x22\x9d\xc8\xa9\xf8\xee\x20\xeb\x07\xcb\x10\x5e\x9a\xd6\xa9\xad\xe0\xf5\x54\xfe\xe2\x74\x71\xe4\x41\x78\x1b\x87\x14\xfb\x2e\xf3\x96\xe4\xb1\x69\xbd\x96\x58\xae\x40\xe8\x5c\xe1\x00\x42\x43\x96\x54\x27\x2c\x69\x20\x21\x49\xf8\x3e\xae\xc3\x94\x9e\xab\x98\x77\x89\x85\xb7\x94\x8a\xcb\xbf\xcc\x23\x6b\xe2\xa9\xcc\x8f\x9b\xf7\x7e\x49\x08\xde\xb9\xf3\x43\x73\x4f\x88\xb9\x20\x9e\x54\x6b\x8e\x43\x1c\x79\x24\x0a\x0c\xa4\x38\x11\x94\x7b\x38\x34\x53\x78\x72\xdf\x5a\x4b\xbd\x0d\x04\xfc\xc2\xd4\x11\xf1\x5d\x63\x2e\xb2\xbd\xd0\x40\xc4\x7c\x13\x3b\xff\xcd\xd4\x03\x9e\xa9\x87\xe0\x95\x33\xc6\x9c\xa7\x2b\xb3\x9e\xa3\x36\x17\x57\xda\x71\x64\x28\xcd\xe8\x8c\x81\xd0\x90\x39\xef\x3e\x4e\x90\x21\x9a\x8b\x26\xe9\xfd\xee\xe4\xdb\x8b\x95\xdf\x90\x2c\xc0\xdb\x78\x59\x98\xcd\x59\x2a\x1d\x33\xf8\x2c\x1c\xf4\xb5\x80\xe6\x97\x12\xb2\x16\x4b\x42\xe0\x96\x83\xfe\x5e\x01\xfc\x4b\xcd\x00\x8a\x74\xcc\x08\x65\x44\x6c\x2c\x07\x75\xda\xff\x5b\xa7\xf1\xf3\xe3\x77\xcb\x41\xd6\x03\x40\x8c\xc4\x12\x90\xda\xd5\x10\x5d\x20\xb2\x92\x75\x1b\x12\x14\x5d\xb4\xad\x86\x01\xb8\x3a\xc9\xd4\xec\x75\x1d\x10\xb2\x04\x0e\xf4\x92\x96\xe3\x27\x91\xc0\x41\x00\x7e\xc3\x48\x08\x59\x4a\xa8\x34\x87\x24\x55\x82\xbb\xb2\xe5\x9a\x32\x98\x2d\x71\xb4\x83\xe9\x26\x59\xcd\x81\x59\x0e\xba\x68\xd7\x68\x9e\x1a\xb4\xc6\xbb\x55\x4e\x55\x80\xcf\x31\x61\x50\x0f\xaf\x4f\x27\xbb\xfe\xfe\xc7\x49\xb1\x47\x1d\x2f\xe4\x19\x7d\x25\xc7\x6f\xf6\xff\x34\x55\x4b\x6b\x86\x97\x1e\x2c\xf4\xa3\x47\xc2\x68\x54\xde\x65\xb4\xc8\xe1\xe0\xe6\xf6\x6f\xf7\xdd\xd1\xcd\xcc\x1d\xdc\xf4\x27\xdb\xde\x2e\x5d\xc5\x89\x00\x4d\x74\x79\x3b\x18\xf6\xee\xaf\xfa\x37\xfd\x89\x3b\xec\xdc\x4f\xaf\xdd\xe1\x70\x4b\x39\x66\xe4\x91\x84\x10\x80\xaf\x93\xd9\xc2\x49\x33\x42\x03\x69\x42\x07\xe1\x35\x3f\xdb\x06\xd8\x33\x9f\x7a\x0f\xc0\x9c\xce\xfb\x56\xfb\x63\x2b\xb7\x56\x41\xcb\x3b\xcc\x08\x9e\x87\xc0\xcb\x39\x85\xce\xff\xdc\x9f\xa7\xf7\xbd\xfe\x27\xf7\x76\x38\xbb\x9f\xf4\xaf\x06\xa3\x9b\xf2\x96\x5b\x3f\x5a\x2c\x62\xa3\x93\x33\xc3\xb0\xdf\x70\xb8\x49\x7f\x3c\x9a\x0e\x66\xa3\xc9\x2f\xf7\xb7\x93\xc1\xfe\x21\x6b\xc7\xeb\x79\x5e\xd9\xf2\x1f\x58\x0b\x3c\xd6\x2a\xe7\x97\xe5\xcc\xe8\xec\xf4\x4b\x35\xb8\x95\xb2\x94\xed\x34\xc7\x63\x55\x38\x1c\x33\xc9\xaa\x3f\xa5\x89\x5a\x9e\xba\xa2\x03\x4e\x88\x52\x7f\xdf\x75\x40\xa4\xf3\x99\x8a\x87\x76\x47\xbd\xfe\x78\x30\xee\x0f\x07\x37\xb9\xd2\x6a\xb0\x69\x0c\x5e\x39\x52\x3e\x66\xe9\x56\xbb\x75\x5e\x68\x8e\x97\x98\x43\xa5\xa2\x88\x19\xdc\xeb\xfd\xbe\x32\x6d\x99\x5e\xe0\xc8\x6f\x2c\x40\x4e\xbf\xc3\x6b\x8e\xc0\x63\x28\x00\x61\x87\x34\x20\x11\xb2\xed\x88\xda\x24\xf2\xc2\xc4\x07\x1b\x56\x98\x84\xdf\x37\x70\xce\xdc\xab\x1f\xad\xd3\xef\xc0\x5b\x52\x74\x2a\xa7\xa4\xd7\xd4\xa4\x3f\x1d\x0d\xef\xfa\xbd\xfb\xe9\xe8\x76\xd2\xed\xdf\xdf\xf5\x27\xd3\xc1\xe8\x06\x7d\x45\x4b\xc0\x3e\xb2\x3d\xf4\xe1\xfb\x7a\xf4\xb1\xd1\xe0\xda\xbd\xea\x4b\xc7\xfa\xd1\x3a\xfd\x52\x76\xb5\x27\xe7\xf4\xcb\xcc\xbd\x7a\x6a\x62\xd3\x2b\x0f\xc5\x49\x18\x22\xeb\xb4\xcc\x67\xa1\xaf\x5f\xcb\x8b\x37\xe7\x5b\x07\x20\xd0\x52\x88\x98\x3b\x67\x67\xfc\xa2\xe2\x79\x6b\x2c\xf0\xfb\xf3\xf7\x36\x83\x10\x24\xd0\x67\x59\x89\xd4\x30\x12\x89\xb8\xc0\x61\x88\x32\x12\x74\x96\x70\x76\x16\x52\x0f\x87\x67\x73\x52\x2e\x09\x8f\x36\x4f\x3a\x3d\xc5\x87\x6c\x5b\xe0\x00\x59\xa7\x5b\xa8\x2c\x64\xdb\x1e\xf6\x96\x60\x2f\x18\x5d\x35\xcc\xbf\x55\x76\x10\xca\xc5\xf1\x1e\x92\xaa\x50\x13\x5d\x93\xb6\xcb\x3a\x7c\x59\x62\xde\x4b\xba\x7f\xe8\x2d\xdc\x44\x5d\x61\xe0\x10\x05\x10\x01\x93\x65\xac\xc8\xee\xd7\x4f\xb3\xd0\x80\x72\xd9\xe8\xff\x90\xe9\xb8\x00\x37\x57\xea\x0b\x22\xc3\x76\x23\x57\x43\x6d\xdf\xb8\xc4\x1b\x22\xc6\x2b\x55\xc8\x05\x09\x7f\xb9\x02\x59\xb9\xe6\x7f\xab\xe3\x97\x54\xc7\x32\x92\xab\x3d\x2b\x11\x4b\xca\xc8\xef\xaa\x2c\x9a\xd1\x07\xa8\x1e\x4d\x1d\x2c\xad\xb1\x06\xca\x01\xc4\x2c\x72\xf0\x9a\x3b\x21\x0d\xb8\x53\xde\xcd\x9d\xda\xb6\x2f\xa9\xec\x80\xd1\x24\x76\xce\xca\x09\xd4\x4e\xc0\xff\x48\xb9\xce\x91\x27\x0f\x4a\x7e\x97\x01\x16\x30\xa4\xc1\x95\x94\x71\x00\xdd\x54\x30\xc0\x2b\x33\xe1\x38\x11\x43\x1a\xf4\x1f\x21\x12\xd5\xda\xfe\x25\x86\xab\xc3\xc7\x2f\x1c\xc7\xc9\xf3\xac\xc6\x03\xc7\xa7\x23\x21\xe1\x17\x52\xff\xd1\x5c\xe6\xf9\x26\x82\x2b\x38\x94\x20\x5d\x51\xaf\x81\x42\x06\x82\x5c\x33\x7b\x5d\x88\x6d\xcb\xe3\xa6\xbc\xf4\x28\x80\xd2\x35\xda\xa3\xeb\x28\xa4\xd8\xbf\x65\xe1\x27\xca\x86\x78\x53\xbb\x1e\xc9\xc9\xb3\xe3\x1e\x55\x7d\xec\xa6\xea\x2e\xc1\x7b\x50\xa3\x15\x9f\xeb\x18\x79\xc6\xc9\x9e\x41\x07\x11\x11\x44\xba\xad\x1c\x52\x1f\x51\x19\x69\x75\xb7\xa2\x1c\x63\xd6\x6c\x5c\x49\x27\x6b\xb1\x10\x76\x8d\xf9\x4d\x0d\x5c\x39\x25\xda\x6b\x6b\x2e\xc3\x41\x3d\x36\xd4\xcf\x83\xb2\xa8\x5b\x11\x70\x05\x22\xbb\xaf\xd0\xb5\x72\xe1\x6e\x29\x73\x1d\x37\x8e\x43\xa2\x6f\xbd\xbb\x61\xc2\x45\x53\xdd\xdc\xef\xca\xe2\x59\xf7\x1a\xdf\xf8\xe9\xee\x63\x8e\x8a\x0a\xa2\xb3\xfd\xb7\x59\x74\xda\xab\x45\xf7\x20\x86\xc8\xe7\xa3\xc2\x93\xc6\x21\xc5\xfe\xa5\x3a\x17\x04\x36\x24\x5c\xc8\x04\xcc\xa0\x66\x3a\xd4\xa1\xf5\x7d\x09\x12\x54\xbd\x4c\xac\x63\x97\xd2\xcd\x30\x7f\xe8\xc1\x82\x48\x97\x2d\xae\x3e\x23\x37\x8d\x04\x26\xd1\x96\x5f\xbf\x2d\xd3\x38\x7c\x72\x27\x57\xf9\xe5\x95\xb6\xa0\xdc\xb7\x0d\xd7\x68\xd7\xf8\x33\x59\x25\xab\x31\x30\x4f\x6e\xee\xe8\xbc\x9d\x9f\x03\x5c\x93\x48\xf6\xfd\x04\x38\x14\xcb\xcd\x96\xe4\x6d\x7b\x3b\x38\x27\x0c\x7c\x75\x30\x94\xdf\xdb\x15\xd1\x2d\xa5\x35\x33\xcc\x02\x10\x6a\xaf\xa9\xdd\x6b\x95\x66\x5a\x1c\xa1\xc0\x54\x60\xd8\x02\xa0\x4b\xe9\x35\xcc\x9b\x3a\xc7\x94\x09\x07\x7d\xc8\xd4\x4d\xdf\x9b\x18\x80\x70\xd7\xfc\x31\xf6\x8c\x97\x8d\x2e\xe7\x24\x88\xb2\xe7\x7c\x0e\xea\xdf\xb8\x97\xc3\x7e\xaf\x40\x31\x05\x2f\x61\x44\x6c\x94\xae\xb5\xcb\xd7\x5d\x57\x76\xf6\x0e\x17\x2f\x8d\xda\xc0\xa9\xda\x07\xc5\x30\xa4\xdf\xe1\xd4\x14\xd8\xff\x04\x69\x3f\xe5\xa5\x21\x10\x64\x80\x1b\xd6\x63\xd9\xbd\x4d\x11\xe1\x13\x5e\x91\x70\x73\xd0\x2a\x8b\x13\x07\x59\x9d\xf6\xf9\x9b\xed\x4b\x4b\x58\xa9\xfb\x69\xeb\xbc\xfd\xe6\x83\x55\xb6\xb7\x3e\x0c\xc3\xca\xbc\x59\x45\x02\xbf\x25\x84\x01\x97\xf1\x1c\x0b\xb2\xbd\xd3\x2d\x38\x6b\x79\x19\x6d\x1f\xe9\x1c\xfd\x1c\xa7\x09\xa6\x1d\x27\x2f\x5b\x9a\x1c\xaf\x86\x87\x3a\x65\x7f\x4f\x8f\xf6\xac\xa5\x10\xb1\xef\x9c\xb7\xde\x94\xb3\x7e\x26\xae\x71\x1c\x93\x28\xa8\xb9\x84\x69\xa1\x20\xb5\x8a\x83\x1d\x17\xef\x43\x1a\xf4\x18\x79\x04\xa6\x80\x95\xb9\x5f\xa9\x7b\x14\x37\x3c\x40\x40\x19\xa9\xcd\xd4\x0e\xd6\xf4\xf6\xc6\x78\x98\x57\x64\xd7\x19\x71\x33\xf7\x2e\xd8\x87\x55\x35\xf3\x21\xb9\x4a\x6c\xed\x98\xc1\x82\x7c\xce\xf0\xdd\x67\xc2\x57\x2a\x85\x4d\xe2\xcc\xd5\xdd\x35\x8e\x70\x00\xbe\x2e\xf0\x5c\x56\xf6\x98\x2c\x99\x20\x78\xe5\xa8\xff\xc4\x8a\xec\x8c\xeb\xf8\x62\x33\x1a\xc2\x99\xab\x8a\xd7\x7e\x77\x2a\x97\x6a\xc9\xd9\xf5\xa0\xcf\x2a\xc0\xdb\x1f\xfe\x80\x02\x1c\x3c\x6e\x0b\xcc\x1f\xf8\x73\x0a\xf0\x7d\xde\x52\xb7\xb0\x6a\x75\xb2\x92\xc9\x64\xe6\xac\xff\x39\xa6\x96\x12\xcc\xa6\x9e\x80\x80\x48\xd2\x0f\xa2\x1e\xde\x70\x07\x75\xde\xec\xcc\x90\x4a\xdb\x87\xe9\x45\x68\x91\xc6\x34\xa5\x63\xe7\xd3\x24\xdf\x3c\x2d\xd5\x5d\xfc\xf9\xc9\x0e\x21\xa8\x20\x25\xf3\x83\xed\xe6\xab\x47\x42\xdf\xd5\x24\x6d\x0f\x6f\x4b\x1a\x0d\xa2\x80\x01\x2f\xad\x97\x2e\xf1\x99\xdc\xe0\xeb\x6f\xba\x95\x3a\x8c\xae\x74\xb4\xb4\x4a\xed\x33\x9a\xb6\xbe\x7b\xfb\xf6\xe2\x6d\xb1\x67\x10\x8f\x19\x15\xd4\xa3\xa1\x83\x84\x97\x6d\xe1\x87\x3d\x75\x6e\x4a\x88\x1a\x8c\xa8\x6f\xbb\x73\x22\x12\x05\x77\xe7\xd2\x4b\x73\xae\x97\x5e\x5c\x99\x92\x1b\x73\x62\x63\x37\x6a\x6f\x4e\x67\xaa\xa9\x4c\x2d\x8d\x39\x2c\x85\x39\x34\x7d\x31\x2a\xf5\xea\x8b\xc4\x28\xf9\x1b\x2d\x8f\xe2\xf8\xff\x41\xeb\xa2\x50\x28\x1c\xbe\x44\x0a\x4c\x07\x55\x8b\x2f\x5c\x45\x5a\x9c\x56\x8c\x64\x53\xae\x24\x5f\x39\x2a\x3f\xcd\x66\xe3\xa3\x60\xc9\x45\xe8\x22\x4b\x08\x46\xe6\x89\x80\xfa\x8f\x40\x7c\x60\xfa\x08\x48\x45\xd1\x7b\x1f\x42\xbc\x69\x09\xb2\x02\x9a\x88\x7b\x0e\x1e\x8d\x4a\x8f\x73\xd2\x5f\x7c\xa9\xc2\x6f\x57\x11\x7d\x44\x6c\x4a\x39\xcc\x3b\x68\x3e\x7a\x29\xdf\x36\x16\x8a\x47\xa0\xd9\x83\x05\x4e\x42\x51\x7b\x26\xfb\x4d\x4b\x54\x8d\xc2\x82\xb2\x35\x66\xc5\xc7\x91\xc5\x17\x99\xaf\x94\x41\x16\x45\xfc\xb5\x6e\x53\x4a\xe7\x51\x7f\xce\x95\x4a\x06\xde\x5f\xfd\x4a\xa5\xd6\xbe\xfb\xc9\x5c\xf1\xa5\xe7\xb1\xef\xdc\x64\x69\x61\x1e\x35\x7d\x25\x68\x7a\xaf\xc6\x76\xb0\xea\x4b\xdf\x63\x5f\xba\xbd\xde\xeb\xbf\x13\xfd\x66\x5f\x79\x56\xf5\xe1\x5c\xe9\xe5\x46\x53\x52\xb4\xf3\x77\x21\x36\xea\x86\x34\x82\x5b\x16\x4e\xf9\xf2\x04\xa1\x7e\xe4\xc7\x94\x64\xae\x71\xc4\xd8\x0d\x21\x51\x76\xf7\x6e\xa6\x6a\x1f\xfa\x57\x00\x00\x00\xff\xff\xba\x74\xb9\xd2\x0a\x3e\x00\x00")
+var _assetsPlatformYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3c\xdb\x72\xe3\x36\xb2\xef\xfa\x8a\x2e\xc6\x0f\x49\x6a\x29\xcb\x76\x26\x99\x20\x95\x54\xd1\x12\xed\xa8\x56\x96\x54\x92\xec\x9c\xdd\xad\x2d\x17\x4c\x42\x12\x8e\x29\x80\x0b\x80\xd6\x78\x27\xfe\xf7\x53\xe0\xfd\x06\x5d\x3c\xe3\x9d\x4d\x9d\x88\x7e\x18\xb2\xaf\xe8\x6e\x34\x1a\x4d\x70\x9c\xdf\xe6\x0b\xb2\x09\x03\xac\xc8\x15\x17\x1b\xac\xee\x88\x90\x94\x33\x04\xd6\x79\xef\xac\x67\xf7\x7e\xb4\x7b\x3f\x5a\x9d\x01\x91\x9e\xa0\xa1\x8a\x21\xbf\x12\xc1\x57\x58\x11\x98\x06\x58\x2d\xb9\xd8\x40\xc6\x02\x9e\xce\xba\xef\x3a\x9d\x19\x91\x3c\x12\x1e\x91\xa8\x03\xf0\x15\x8c\x89\xda\x72\xf1\xd8\x81\x9c\x32\x7d\xa2\xc1\x00\x8b\xe7\x90\x20\xb0\x9c\xdf\xe6\x08\xb9\xfd\x73\x84\xee\xa6\x7d\x2b\x86\x4c\x05\x0f\x89\x50\x34\x61\xa4\xaf\x3e\xf5\xc5\x65\xc0\xbd\x47\x04\xd6\xd9\x8f\xe7\xdd\xb3\xef\xdf\x77\x7b\xdd\xde\xe9\xd9\xf7\x56\x8a\xb1\xc0\xab\x1c\x1b\xc0\x86\xbf\x92\x67\x04\x63\xbc\x21\xf9\x33\x80\x3b\x1c\x44\xa4\x40\xd2\xd7\x8c\x2c\x11\xc4\x2a\xcc\x15\xf6\x1e\x53\x82\x9a\xbe\xf3\xe8\x81\x11\xe5\x18\xd4\x4e\xa0\x26\xcd\xef\x42\x6f\xe8\x17\x32\x63\x79\x35\xf6\x29\xd0\x79\xc2\x34\xc0\x0f\x34\xa0\xea\xf9\xef\x9c\x95\x14\xbd\x62\x08\xcd\x49\x40\x3c\x55\x3c\xd3\x63\xec\x55\xee\x34\xd6\x35\x51\xce\xdf\x25\x02\xcb\xda\x63\xb8\xf3\x5e\x86\x71\x83\xc3\x69\xf4\x10\x50\x6f\x18\x4e\xd8\x08\x47\xcc\x5b\x23\x50\x22\x22\x47\x1b\x16\x4a\x4f\x52\xa5\xa3\x07\x04\xd6\xc9\xc7\xaa\x81\x5f\x20\x31\x19\x38\x96\xc9\xd6\x97\xff\x75\xb6\x3e\x7b\x9d\xad\xcf\xbe\x7f\x23\x63\x1f\x6b\xeb\xcb\x16\x5b\xcf\x78\xa4\xc8\x02\x3f\x04\xc4\x60\xee\x02\xe1\xd3\x4d\xfe\x96\x13\x74\x12\xa9\x07\x1e\x31\x3f\xd6\x77\xd7\x58\x4c\xc3\x28\x06\xba\x6f\x34\x05\x66\x8a\x36\x20\x52\x51\x86\x75\x8a\x2c\x07\x40\x4f\xe7\xa7\x6e\xef\x34\x77\xfd\x35\x56\x64\x8b\x9f\xf7\xf1\x4f\xd1\x3a\x60\x00\x18\x46\x37\x64\x8a\x08\x46\x54\x8a\x65\x1a\xe7\x5b\x3a\x21\x15\xed\x28\x85\xbd\xf5\x86\x30\x65\x50\xf5\x6e\xda\x6f\xa0\x7e\x7a\x78\xd5\x0c\xf0\x6a\x33\xa7\xb9\x7e\x57\x24\x25\x28\x45\x20\x38\x52\x72\x8f\xc6\x11\x60\x1a\x47\x42\xb2\x4f\xa9\x54\x76\x8a\x53\x08\xd8\x47\x57\x60\x9a\xc6\x73\xf9\x05\xc7\x73\x99\xe2\xbc\x6a\x3c\x71\x21\x31\xa5\x21\x09\x28\x2b\x8f\x2e\x7b\xd4\x1c\x52\x9f\xfb\x05\x14\x65\xff\x32\x0d\x44\x87\x32\xea\xec\x8e\x73\x7d\x39\x42\xd1\x25\xf6\xd4\x5c\x71\x51\x22\x48\x04\xcf\x2f\xf2\x07\x23\xee\xc5\x86\x2b\x50\x6a\x43\xcc\x14\xaa\x30\x4c\x91\x67\x44\x2a\x2c\x94\xfb\x81\x78\x91\x66\x32\x61\xb7\xa1\x8f\x15\x41\xb0\xc4\x81\xcc\x54\x99\xf1\x80\x38\xa2\x24\x21\x5b\x8c\x54\x6d\xc9\xaa\x8b\xd4\x84\x15\x04\x47\xb0\xf4\x7e\xae\xf0\xaa\x30\x8a\xce\x0c\xb1\x61\x60\x46\x42\x2e\xa9\xe2\xe2\x39\x07\x01\x38\x9e\x56\xae\x84\x5d\xa6\xe8\xaf\x31\x5b\x91\x79\x5c\x07\x56\x10\x32\x3a\x6d\xb2\x72\x00\x64\xbf\x3e\x56\x64\xc5\xc5\x33\x82\x56\x62\x80\xc9\x96\x11\x11\xa7\xa1\x06\x68\x2a\xf8\x13\xf5\x35\x54\x7b\xbf\xcf\x37\x1b\xaa\x1a\x48\x79\x85\x5b\x5e\xca\xf5\xd5\xe7\x6c\x49\x57\x91\x68\x38\x2e\xf3\x4a\x66\x83\x6a\xb0\x00\xec\x76\x40\x9b\x2b\x5a\xed\x59\x5c\x76\x3d\x17\x27\x7f\x97\x02\x33\x6f\xad\x41\x08\x36\x58\x2a\x22\x6a\x28\x93\x48\x85\x91\xca\x42\xaa\xe6\x99\xb2\x77\x32\x3d\x6a\x26\xce\xc0\x97\x11\x0d\x7c\x22\x0e\xf5\x75\x8c\xfe\x4a\x27\xb7\xd1\x1e\xe1\xe3\x76\xf2\xd7\xba\x78\x2a\xf8\xff\x12\x4f\x99\xfc\x5b\x99\xc0\x4d\x1b\xe9\x6b\xc8\x3e\xc9\x01\xaf\x74\x63\xd5\x0a\x19\x74\x41\xa4\x3a\xdc\x89\x1a\xfb\x95\x3e\x6c\x21\xfd\x43\xb8\xb0\x61\xa0\xd7\x78\xb0\xdd\xf6\x03\x12\x06\xfc\xf9\x70\xeb\x27\xf8\xaf\xb4\x7f\x2b\xf1\xa1\x1e\x08\x78\xe4\x27\x5b\x7f\xca\x59\xa7\x82\xf5\x09\x6e\xc8\xd7\x4d\xa3\x13\x5a\x97\xd7\xe2\x4a\x16\x89\x1b\xee\x13\x04\xfd\x99\xeb\x2c\xdc\xfb\xdb\xe9\xc0\x59\xb8\x0d\xcc\x3e\x0e\x93\x1d\x9c\xae\x4b\xa0\xef\x4c\x9d\xcb\xe1\x68\xb8\xf8\xdb\xfd\xd8\xb9\x71\x07\xf7\x43\xe7\xa6\x41\x92\xf5\x2b\xa6\x58\xad\x6b\x9e\x44\x28\x4c\xbb\x1a\xdd\x67\xbc\x09\x1a\xa4\x8d\x55\xf7\x35\xe9\x3f\x8b\x8e\xda\x4a\xdc\xbe\x26\x7f\x6a\x60\xd6\x97\xff\x96\x12\xa6\x5c\x37\xcd\x2f\x10\xba\x8c\xbc\x47\xf3\xf6\x3a\x81\x56\x9d\x5b\xec\x3c\xd7\xa9\x38\x3b\xdd\x82\x3a\x9e\xc7\x23\xa6\x86\xfe\x4b\xf6\x64\x46\x56\x94\xb3\xfc\x36\x8f\x81\x97\x6c\x8b\xf4\x15\xcc\x2f\x20\x69\x21\x01\x8e\xe3\x00\x04\xf9\x57\x44\x05\x91\xf0\x94\x04\x24\x65\xab\x4e\x25\x42\x29\x5b\x19\x42\x72\xae\xb0\x8a\x24\x02\x97\xe9\x4a\xb2\xcd\x22\xda\x0d\x4d\x43\x0c\x9d\x1b\xbd\xf3\x35\xef\x79\x35\xcc\x64\x85\x36\x11\xe6\x01\x3b\x52\x46\x9b\x18\x67\xca\x03\xea\x3d\x0f\xb8\x17\x15\x3b\xa7\x4a\x3a\xd4\x6d\xb9\x73\xfb\xac\x67\x9f\xfd\x90\x51\x27\x43\x24\x55\x02\x1d\x0f\xee\x72\xa9\xfb\x17\xe0\x04\x01\xdf\x96\x20\x7a\x30\x94\x79\x34\xc4\x41\x99\x40\x5f\x73\x22\x9e\xa8\x47\x10\x78\xdc\x27\x61\xaa\x7c\x17\x6f\xf0\xbf\x39\xc3\x5b\xd9\xf5\xf8\xa6\x42\x91\x4c\x53\x04\x52\x49\x54\x8c\x22\x45\x89\x07\x53\x32\x98\xd6\x29\x7e\xd6\x52\x41\x99\x4d\x97\x50\x98\x8d\x97\x4b\x6a\x31\xdb\x7e\xd3\x19\xcd\xb7\xcf\x84\x69\xa1\x1e\x47\x29\x02\xeb\x5b\xcb\x90\xc2\xea\x3c\xf5\x65\xc7\xe6\xf5\xe2\xfa\x14\xf5\x31\xf3\x48\x70\x1b\x06\x1c\xfb\x8e\xf0\xd6\xf4\x89\xec\x23\xb9\x26\x2a\xa9\x05\x0f\x40\x34\x54\xc1\x4d\xc4\x8a\x06\xda\x24\x91\xdc\x47\x75\x98\xd2\x0f\x7a\x85\x44\x97\x58\x79\x6b\xad\xb8\xbe\x33\x73\x4e\x90\xe7\x7a\xff\x93\x25\xb0\xfa\xcf\x06\xe2\x9d\xa3\x6f\xdb\x21\x01\x96\x8a\x7a\x5a\xad\x07\x1c\x60\xe6\x51\xb6\x32\xa0\xe2\x48\x71\xe9\xe1\xc0\x8c\xe1\xe9\xb5\x71\xab\xf5\x36\x20\xc8\x0b\x13\x80\x49\x03\x24\xe6\xb9\xcc\xd6\x5b\x03\x92\xf0\x4d\xe4\xf2\x5f\x26\x08\xf1\x4c\x10\x8a\x37\x68\x8a\xa5\x4c\x67\x66\x73\x0f\xd2\xcc\x7d\xc5\xf6\x09\xa1\x02\xcf\x98\x08\x73\x8c\x43\xb6\xd1\x85\xfc\x15\x95\xaa\x4d\xba\xdb\x9f\x7d\x7e\xb1\xfa\x1a\xd1\x25\xf1\x9e\xbd\x2c\xcd\x16\x24\x35\xc0\x82\x7c\x50\x08\x7e\xcf\xc1\x00\x1f\x4b\xff\x06\xb0\x44\x14\x10\x69\x21\xf8\x47\xe5\x71\x1d\x2d\x47\x9d\x0a\xca\x05\x55\xcf\x16\x82\xb3\xde\x5f\x9a\x38\x7e\xf1\x7a\xc5\x42\x60\x3d\x12\x12\x82\x5a\x13\x88\x57\x4e\xe0\x4b\xa0\x1b\xbd\x2f\x07\xc5\xe1\xa2\x67\xb5\x30\x90\x71\xa7\x5a\x37\x6a\x50\x8b\x0e\x00\x96\xc2\xab\x64\x4a\x6b\xfe\x11\x53\x78\xb5\x22\x7e\x0b\x27\x00\x2b\x16\xaa\xdd\xa1\x51\x63\xc1\x7d\xfd\xe4\x86\x0b\xb2\x58\x63\xb6\x83\x68\x1c\x6d\x1e\x88\xb0\x10\x5c\x94\xdf\x48\x24\xd7\x4b\x93\xcc\xc2\xbb\x55\x4e\x55\x20\x1f\x42\x2a\xd2\x45\xb8\x7c\xbd\x74\x76\xdd\xff\xb3\x74\xf7\x92\xb4\x8f\x8a\x1d\x5b\x6d\x0f\xd7\x1e\xff\x69\x39\x98\x6e\x28\x4c\x41\x78\x68\xe8\xb9\xec\x89\x0a\xce\xaa\xab\x4c\x22\x72\x34\x1c\xdf\xfe\xcf\x7d\x7f\x32\x5e\x38\xc3\xb1\x3b\xcb\xa1\x7d\xbe\x09\x75\x03\x2c\x46\xba\xbc\x1d\x8e\x06\xf7\xd7\xee\xd8\x9d\x39\xa3\xb3\xfb\xf9\x8d\x33\x1a\xe5\x98\x53\x41\x9f\x68\x40\x56\xc4\x4f\x0a\xe6\xd2\x9b\x04\x80\xa1\x76\x21\x02\xbc\x95\xa7\x79\x82\x3d\xf5\xb9\xf7\x48\x04\x3a\xfb\xa1\xdb\xfb\xb1\x5b\x78\xab\xa4\xe5\x1d\x16\x54\x17\x4c\xf9\x48\xcb\x35\xa6\xf3\xdb\xfc\x7e\xe0\x5e\x39\xb7\xa3\xc5\xfd\xcc\xbd\x1e\x4e\xc6\x25\xa4\xd6\xd6\x71\xd9\x36\x7a\xe6\x73\x66\x60\xfb\x19\xd9\xcd\xdc\xe9\x64\x3e\x5c\x4c\x66\x7f\xbb\xbf\x9d\x0d\xf7\xb3\x2c\x6a\x90\x46\xed\xda\xf5\x1f\x45\x97\x78\xa2\x5b\xad\x61\xab\x95\xd1\xe9\xc9\xc7\x7a\x72\x7b\xb1\x5a\xf4\x72\xa6\xd3\x78\x73\xb2\x5f\x23\x73\x3c\xa5\x85\x5a\x51\xba\x9a\x77\x20\x76\x3d\xde\x77\x35\x00\x93\x7a\x26\x87\x26\x11\xda\x9f\x0c\xdc\xe9\x70\xea\x8e\x86\xe3\x62\xff\x15\x33\x9b\x87\xc4\xab\x66\xca\xb4\x40\x47\xd0\xeb\x9e\x97\x1e\x87\x6b\x2c\xab\xb1\x04\x10\x0a\x72\x1f\x87\x63\xf5\x31\x80\x2e\x4a\x30\xf3\x6b\xe8\xfa\xcf\x86\x93\xaf\xf1\x56\x02\xf1\x04\xac\x88\xb2\x03\xbe\xa2\x0c\x6c\x9b\x71\x9b\x32\x2f\x88\x7c\x62\x93\x0d\xa6\xc1\x37\x2d\x94\x0b\xe7\xfa\x67\xeb\xe4\x6b\xe2\xad\x39\x9c\xe8\x21\x25\x73\x6a\xe6\xce\x27\xa3\x3b\x77\x70\x3f\x9f\xdc\xce\xfa\xee\xfd\x9d\x3b\x9b\x0f\x27\x63\xf8\x1d\xd6\x04\xfb\x60\x7b\xf0\xfe\x9b\x66\xf6\xb1\x61\x78\xe3\x5c\xbb\x3a\xb0\x7e\xb6\x4e\x3e\x56\x43\xed\x05\x9d\x7c\x5c\x38\xd7\x2f\x6d\x64\xc9\xcc\x83\x30\x0a\x02\xb0\x4e\xaa\x74\x16\xfc\xfe\x7b\x75\xf2\x66\x3f\x1b\xb6\x2b\xa2\x60\xad\x54\x28\xd1\xe9\xa9\xbc\xa8\x45\xde\x16\x2b\xfc\xc3\xf9\x0f\xb6\x20\x01\xd1\x86\x3e\xcd\xb6\x61\x2d\x9c\x28\x93\x0a\x07\x01\x64\x28\x70\x1a\x49\x71\x1a\x70\x0f\x07\xa7\x0f\xb4\x3c\x91\x00\x8e\x76\x4f\x62\xd3\xc9\x74\x31\x9c\x8c\xe7\x3f\xe7\xef\x44\x8b\xcb\x86\x25\x17\x80\xc5\x0a\x28\x83\x93\x5f\xdd\xd9\xe4\xda\x59\xb8\xf7\x09\x9d\x33\xbb\x9e\xff\x04\x3e\xaf\xb3\x39\xa9\xdc\x83\x6d\xc7\x7a\xd9\x9a\xcb\x09\x16\x2b\x4b\xd3\xb0\xd6\xb1\x2e\xe1\x1f\x60\x33\xb0\xea\x92\x16\xce\xec\xda\x5d\x58\xf0\xcf\x9f\xf4\x4a\xcb\xf6\x0a\x54\x58\x68\x07\x18\xd8\xfc\x04\x4b\xda\x22\x7d\x30\xe9\xff\xd5\x9d\x5d\x0d\x47\xae\x0e\xbc\xdc\xde\x34\x7e\x47\x85\x83\xc4\xba\xdf\xec\x88\x92\x18\x21\x96\xbe\x02\xeb\x24\x8f\x38\x0b\x6c\xdb\xc3\xde\x9a\xd8\x4b\xc1\x37\x2d\x61\x64\xdb\x4b\x1a\x10\xb0\x4e\x0a\x0d\x2c\xa8\x8d\xa9\x5b\x11\x1b\x72\xa9\x8e\x9f\x8c\xa9\x9a\x0d\xf5\x1a\x1a\x99\x69\xc3\x48\xae\x2b\xc4\x7b\x51\xf7\xb3\x6e\x5a\x7a\x45\x18\x11\xba\x2b\xa1\xb2\xa3\x2a\x27\x59\x16\x86\x42\x36\xfc\x02\xbb\xbb\x3f\x6d\xac\x15\x91\xca\x4e\x8d\x04\xbf\x54\x6e\xbb\xea\x43\x73\xe7\xa5\x4b\xf5\x35\xaf\xda\xeb\x97\xa4\xc2\xb3\x23\x41\x6b\x34\xb8\xbd\xe1\xa3\xdd\xdb\xf0\x88\xbd\x53\x7b\x7b\x9f\x6a\x76\xab\x12\x2d\x1d\xa7\xd6\x45\xe1\xab\xb4\xdf\x0c\x22\x62\x52\xcf\xa9\x58\x5c\x16\x3d\x7a\xb2\xeb\x67\x3a\xc2\x54\x22\xa7\x0b\x43\x05\x3e\x27\x12\x18\x57\x6b\xca\x56\x7a\xb2\x6a\x1c\x1c\x86\xb0\xc6\xfa\x71\xcc\x42\x76\x4b\x15\x5b\x22\xe3\xf3\x15\x6c\x8d\x25\x3f\x5f\x67\x5f\x6c\x2d\x9c\x08\xab\xd3\x28\x8d\xfe\x2c\xe0\xde\xb0\x80\xfb\xb3\xb0\x39\xa2\xb0\x39\x5a\x48\x79\x40\xd9\x2f\x59\x23\x65\x23\x3d\x64\x6b\x63\x0b\x09\x64\x29\x59\x44\xba\xfa\x12\x1b\xb0\x6d\xc2\x9e\xa0\x3f\xfc\x59\x87\x2e\x58\x27\x5f\x7b\x58\x55\xf3\xc9\x37\x16\xc8\xb5\x2e\xa7\x52\x60\x5d\x5c\xcb\x3a\x08\x40\x8a\xf7\xdd\xd5\x5f\x92\x43\xc7\x69\x8a\x00\x2c\x08\xf8\x64\x49\x19\xf1\xbb\x30\x7f\xa4\x61\x9e\x81\xba\x6d\x6c\x97\xf4\xc8\xec\xd6\x12\x68\x6f\xd4\x31\x2e\x49\xf8\xc3\x35\x8c\xe3\x70\xac\x56\xa6\x9d\x96\x8e\xe8\x9f\xdd\x62\x73\xb7\x58\x27\x80\x38\xd5\x45\x6a\xcd\x05\xfd\x77\xdc\x26\x5c\xf0\x47\xc2\x5e\x2b\xad\x01\xc9\x0e\x2e\x26\x06\xc4\x82\x21\xbc\x95\x28\xe0\x2b\x89\xaa\xbb\x5b\xd4\xd8\x06\x6b\x2c\x7b\x25\x78\x14\xa2\xd3\xea\x7a\xb4\xd3\xe0\xff\x49\xb9\xe8\xdb\x2f\x25\xb9\x56\x30\x7c\x21\xf1\xc8\x1c\x5b\xad\x8a\xc5\x6a\xf4\x05\xc1\x8a\x8c\xf8\xea\x5a\x8f\xf4\x00\xbc\xb9\x12\x04\x6f\xcc\x88\xd3\x48\x8d\xf8\xca\x7d\x22\x4c\xc9\xcf\x18\xb8\x4d\x2b\xca\x0b\x84\xd0\xc9\xc7\x7a\x1a\xc8\xf2\x7a\xfc\x92\xf3\xe5\x48\x93\xc8\x0b\x34\x8d\xd4\xe4\x41\x97\x91\x26\x84\x6b\x72\x28\x42\x9a\x51\xde\xc2\x0a\x99\x11\x74\xce\xd8\x1b\x49\x22\x6f\x97\xb7\xf5\xa9\x8e\x32\x50\x9a\xa3\x06\x7c\xcb\xf4\x9b\x96\x5b\x11\x5c\x71\x31\xc2\xd5\xf3\x0e\xd9\x4f\xef\x77\x44\xfe\xfa\x27\xee\x46\xee\xc6\xea\xaf\x89\xf7\x18\x73\x2b\x1f\xcf\x36\xd2\x4c\xa3\x3d\x4c\x87\x8c\x2a\xaa\xc3\x5b\xb3\x4c\x5e\x59\x19\x71\x13\x70\x2c\x7c\x8a\x45\xbb\x73\x35\x4f\x5d\xda\x07\x64\x17\xcf\xcf\xea\xe0\xda\x5b\xa3\xbd\xbe\x96\x3a\x29\x35\x53\xc4\xb7\x26\x2f\x43\x4d\xc0\x35\x51\xd9\x19\x89\xa4\x77\x5e\x3a\xcf\x92\x85\x8e\x13\x86\x01\x4d\x4e\x39\xf6\x83\xa8\x7d\x5b\xe6\xf6\xe7\x08\xa5\x50\x53\x51\x94\x82\xab\x8b\x7c\xb1\x39\xc8\xb5\x6f\x17\x9d\xd5\x1f\xed\xa2\x53\x68\x22\x7a\x40\x42\xc2\x7c\x39\x29\x7d\xc2\x32\xe2\xd8\xbf\x8c\xdf\x13\x12\x31\xa2\x52\xe9\x2e\x81\x41\xcd\x94\xd5\x21\x6a\x96\x46\x55\x43\x6d\x19\x40\x8a\x98\xe2\x2d\xb0\x7c\x1c\xe8\x3a\x96\x56\x67\x9f\x91\x9a\x33\x85\x69\xa6\x35\x40\xf2\x2d\x41\xe2\x82\x2b\x67\x76\x5d\x1c\x98\x49\x3c\xa8\x0b\x1e\xc3\x39\x89\x1b\xfc\x81\x6e\xa2\xcd\x94\x08\x4f\x17\x37\x70\xde\x2b\xb6\x95\x37\x94\x69\xd8\xaf\x04\x07\x6a\xfd\x9c\xa3\xbc\xcb\x30\x06\x44\x52\x41\xfc\xf8\x45\x51\x71\x56\xa8\x6c\xdd\xdc\x8e\x7a\x66\x2c\xe2\x66\x5a\xbc\xd6\x34\xce\xd2\x54\x46\x5a\xe6\x50\x22\x2a\x11\xe4\x06\x88\x1d\x03\x5b\xf2\xd0\x06\x9c\x72\xa1\x10\xbc\xcf\xd4\x4d\xcf\x17\x1b\x0c\xe1\x6c\xe5\x53\xe8\x19\x80\x71\xed\x4d\x57\x2c\xfb\x7c\x03\x81\x3b\x76\x2e\x47\xee\xa0\x84\x31\x27\x5e\xa4\x5f\xf7\xc5\xba\x96\x06\x5e\x54\x02\xcd\xbd\x6c\x06\x6d\xf1\x71\x1a\x79\x15\xae\x2d\x94\xf1\xf3\x61\x39\x0d\x25\x07\xba\x1b\x0a\xb4\x1d\xb5\xae\x1e\x39\xdf\x8f\x79\x69\x48\x04\x99\xc1\x0d\xf3\xb1\x1a\xde\xa6\x8c\x70\x85\x37\x34\x78\x3e\x68\x96\x85\x91\xfe\x12\xac\x77\xfe\x5d\xb6\xc2\xdf\x90\x4d\x7c\x26\xce\x3a\xef\x7d\xf7\x3e\x7b\x98\xaa\x9e\xf4\x56\x70\xec\xde\x14\x32\x4b\xcf\x15\xe9\x7c\x8e\x15\xcd\xcf\x91\xa5\x60\x6d\x84\xea\x34\xca\x0f\x65\x1f\x7d\xfc\xba\xcd\x4c\x3b\x1a\x16\x39\x4e\x61\xaf\x8a\x5a\x6d\xf1\x9e\x76\x8a\x2c\xfd\x9a\xc0\x47\xe7\xdd\xdc\x2a\xc6\x06\x56\x99\xd7\x74\x32\x5b\x54\x00\x59\xe3\x06\xac\xf7\xf9\xe7\x2b\xfa\x4f\xcf\xa6\x1b\x1c\x86\x94\x95\xbf\x22\xd1\x97\x6d\x9c\x72\xfa\x6f\xc4\x4d\x67\xb4\x52\xe8\x40\xd0\x27\x7d\x7a\x1b\x6f\xa5\xae\x22\x2b\xe0\x49\xd8\x72\x7c\x12\x32\x54\x5b\x90\x55\x83\xe3\x8e\x2e\x53\x9d\x3c\x29\xb1\xdb\xa9\x77\x39\x70\x54\x57\xb3\x60\x29\xe3\x12\xd9\x0e\x05\x59\xd2\x0f\x99\xa7\xf6\x05\xc3\x1b\x35\x15\x4c\xe2\xcc\xfb\xe4\x1b\xcc\xf0\x8a\xf8\xc9\x56\xd9\x11\xd5\xd8\xcb\xca\x12\x7d\xb2\x24\xae\x4f\xc2\x18\xed\x54\x26\x99\xca\x16\x3c\x20\xa7\x4e\xdc\x06\x70\xfb\x73\x3d\xe9\x2b\xd3\x26\x61\xfa\xaa\x56\x46\xef\xfd\x7f\xa0\x95\x41\x3c\x69\x2b\x2c\x1f\xe5\x6b\x5a\x19\xbb\x4c\xae\xa3\xa5\xe9\xe1\xf8\x29\xca\x36\x5f\x26\x37\x67\xf0\xd7\xb8\x5a\x4b\x30\xbb\x7a\x46\x14\x61\x7a\x72\x0d\xd9\x00\x3f\x4b\x04\x67\xdf\x75\xe0\xd0\x85\xa8\x39\x9c\xf8\x3b\xb4\x0a\x8e\x69\x48\xc7\x8e\xa7\x4d\xbe\x79\x58\x31\xf7\xf2\x87\xcb\x3b\x84\x40\x49\x4a\x16\x07\x90\xc9\x49\x38\xc1\xd7\x0d\x49\x79\xc7\xb2\xa2\xd1\x90\xad\x04\x91\xf9\x20\xf5\x7c\xd1\x1f\x04\x0e\xc3\xb6\xaf\x01\xf5\x75\x25\xf8\x26\xc9\x96\x56\xe5\xf9\x82\xa7\x4f\xbf\x7f\xf7\xee\xe2\x5d\x19\x32\x0c\xa7\x82\x2b\xee\xf1\x00\x81\xf2\xc2\xa3\x3e\x92\x6b\x2b\xad\x5a\x9c\x98\x9c\xa3\x2b\x90\x28\x5b\xdd\x9d\x23\x54\x3c\x30\x17\xf4\x55\x8f\x9a\x17\xef\x8a\xd5\x2a\xf6\x32\x95\x48\xc5\x62\x5a\xd6\xa3\xc2\xa7\xb3\xab\x28\x6a\x14\x44\x87\x15\x43\x87\x16\x42\x46\xa5\xde\x7c\x92\x18\x25\x7f\xa6\xe9\x51\xe6\xff\xff\x68\x5e\x94\xb6\x1c\x87\x4f\x91\x12\xd1\x41\xfb\xce\x18\xe7\x2b\x70\xfb\x73\xd0\x45\x8c\xde\x48\x4b\x48\x8e\x1b\x48\xd8\x52\xb5\x4e\x8f\x02\xa6\xb9\x1c\x42\x2e\xd4\x5f\x40\xf2\xf8\xb1\xbe\xd1\x07\x04\xf5\xbf\xd3\x23\x0a\x71\x29\x03\x54\xc2\x92\x7e\x20\x7e\xca\x1d\x33\xbf\x15\x87\x91\x27\x22\x40\x90\x30\xc0\x1e\xf1\x61\xab\x8f\x42\x78\xfa\xd3\x3d\xfd\x3e\x36\x13\xd0\xfd\xc4\x89\x9e\x58\x24\xb1\x1d\xcd\xbc\x52\xab\x0f\x0b\xc7\xfd\xba\x58\x4c\x8f\xf2\x5c\x21\x22\x36\xba\xa3\x94\xa0\x0f\x91\x22\xcd\x2f\x9c\x7d\x92\x58\x38\x29\x3f\xef\x7d\x12\xe0\xe7\xae\xa2\x1b\xc2\x23\x75\x2f\x89\xc7\x99\x2f\x9b\xff\x9d\x41\xbc\xcb\xdd\xd5\x31\x38\x3c\x36\x32\x0a\xd3\x64\x2f\x73\xaf\x6c\x2e\x2a\x43\x2f\x63\x1d\x61\xcd\x01\x59\xe2\x28\x50\x8d\xef\x90\x3e\xeb\x7e\x3c\xb1\xc2\x92\x8b\x2d\x16\xe5\x6f\x2d\xca\x9f\xbc\xbc\x51\x91\x5b\x16\x61\xce\x7b\xff\x8d\xaf\xce\x2a\xcd\xb7\x2f\xf3\xfe\x2c\x33\xde\x1f\xfd\xfd\xd9\x91\xdf\x0b\x94\x3f\x73\x39\xf6\x90\xbf\xde\xfd\xb4\x43\x4a\x9f\x48\xb4\x23\xe8\x16\xb2\x99\x34\x7e\xbf\x74\xf4\x31\xff\xb7\xfb\xf4\xa1\x93\x7c\x90\x1a\x4f\xc6\xe2\xd0\x3d\xea\x14\x39\xb2\x63\x6e\x81\x14\x35\x5b\x41\x5a\x02\xf6\x03\xce\xc8\xad\x08\xe6\x72\xdd\xd1\x3d\x0a\x3f\xe4\x94\xa9\xa3\x79\xb7\xa4\x44\x3d\x8c\xc1\x78\x3e\xc6\x1b\xd2\xf9\xbf\x01\x00\xcf\x20\xd1\x8e\xe7\x48\x00\x00")
 
 func assetsPlatformYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -83,7 +83,7 @@ func assetsPlatformYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "assets/platform.yaml", size: 15882, mode: os.FileMode(420), modTime: time.Unix(1518940347, 0)}
+	info := bindataFileInfo{name: "assets/platform.yaml", size: 18663, mode: os.FileMode(420), modTime: time.Unix(1792385296, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api/objects"
+)
+
+// BuildConfigPlatformVersion is the minimum platform version whose buildspec reads the build settings.
+const BuildConfigPlatformVersion = "1.3"
+
+// buildConfigPath is the path of the build settings in the template.
+// The settings are applied to the builder project, and kept here to restore them when upgrading.
+const buildConfigPath = "Metadata.HerogateBuild"
+
+const (
+	builderComputeTypePath = "Resources.HerogateBuilder.Properties.Environment.ComputeType"
+	builderEnvVarsPath     = "Resources.HerogateBuilder.Properties.Environment.EnvironmentVariables"
+	envVarsPath            = "Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Environment"
+)
+
+// defaultComputeType is the compute type of the builder written in `assets/platform.yaml`.
+const defaultComputeType = "BUILD_GENERAL1_SMALL"
+
+// computeTypes maps compute sizes to CodeBuild compute types.
+var computeTypes = map[string]string{
+	"small":  "BUILD_GENERAL1_SMALL",
+	"medium": "BUILD_GENERAL1_MEDIUM",
+	"large":  "BUILD_GENERAL1_LARGE",
+}
+
+// reservedBuildArgs are environment variables of the builder which cannot be used as build args.
+var reservedBuildArgs = []string{"AWS_", "CODEBUILD_", "HEROGATE_", "REPOSITORY_URI", "APP_NAME", "IMAGE_URI", "TAG", "BUILD_OPTIONS", "DOCKERFILE"}
+
+// GetBuildConfig returns the build settings of the application.
+func (c *Client) GetBuildConfig(appName string) (*objects.BuildConfig, error) {
+	if _, err := c.GetApp(appName); err != nil {
+		return nil, err
+	}
+
+	template := c.GetTemplate(appName)
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	return templateBuildConfig(cfg), nil
+}
+
+// SetBuildConfig updates CloudFormation stack with the new build settings.
+// Build args are passed to the builder with the current values of config vars.
+// When the settings did not change, it does not perform updates.
+// When the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) SetBuildConfig(appName string, build *objects.BuildConfig) error {
+	if err := ValidateBuildConfig(build); err != nil {
+		return err
+	}
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return err
+	}
+	if err = checkStackIdle(app); err != nil {
+		return err
+	}
+
+	base := c.GetTemplate(appName)
+	template := generateBuildConfigTemplate(base, build)
+	if base == template {
+		return nil
+	}
+
+	return c.updateStack(appName, template)
+}
+
+// PreviewSetBuildConfig returns resource changes when setting the build settings.
+// It creates a CloudFormation change set from the generated template and deletes it without executing.
+func (c *Client) PreviewSetBuildConfig(appName string, build *objects.BuildConfig) ([]*objects.Change, error) {
+	if err := ValidateBuildConfig(build); err != nil {
+		return nil, err
+	}
+	if _, err := c.GetApp(appName); err != nil {
+		return nil, err
+	}
+
+	base := c.GetTemplate(appName)
+	template := generateBuildConfigTemplate(base, build)
+	if base == template {
+		return []*objects.Change{}, nil
+	}
+
+	return c.previewStackUpdate(appName, template)
+}
+
+// ValidateBuildConfig returns an error if the compute size is unknown or build args use reserved names.
+func ValidateBuildConfig(build *objects.BuildConfig) error {
+	if _, ok := computeTypes[build.Compute]; build.Compute != "" && !ok {
+		return fmt.Errorf("Unknown compute size `%s`, must be small, medium or large", build.Compute)
+	}
+	for _, arg := range build.Args {
+		for _, reserved := range reservedBuildArgs {
+			if arg == reserved || (strings.HasSuffix(reserved, "_") && strings.HasPrefix(arg, reserved)) {
+				return fmt.Errorf("`%s` is reserved by the builder, it cannot be used as a build arg", arg)
+			}
+		}
+	}
+	return nil
+}
+
+func templateBuildConfig(cfg *config.Config) *objects.BuildConfig {
+	build := &objects.BuildConfig{Args: []string{}}
+	build.Dockerfile, _ = cfg.String(buildConfigPath + ".Dockerfile")
+	build.Target, _ = cfg.String(buildConfigPath + ".Target")
+	build.Compute, _ = cfg.String(buildConfigPath + ".Compute")
+	if args, err := cfg.List(buildConfigPath + ".Args"); err == nil {
+		for _, arg := range args {
+			if name, ok := arg.(string); ok {
+				build.Args = append(build.Args, name)
+			}
+		}
+	}
+	return build
+}
+
+func generateBuildConfigTemplate(base string, build *objects.BuildConfig) string {
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	current := templateBuildConfig(cfg)
+	args := []interface{}{}
+	for _, arg := range build.Args {
+		args = append(args, arg)
+	}
+	err = cfg.Set(buildConfigPath, map[string]interface{}{
+		"Dockerfile": build.Dockerfile,
+		"Target":     build.Target,
+		"Compute":    build.Compute,
+		"Args":       args,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"build":  build,
+			"config": cfg,
+		}).Fatal("Failed to set build settings to template" + err.Error())
+	}
+	applyBuildConfig(cfg, current.Args)
+
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template
+}
+
+// syncBuildArgs updates values of build args passed to the builder after changing config vars.
+// It does nothing if the application has no build settings.
+func syncBuildArgs(cfg *config.Config) {
+	if _, err := cfg.Get(buildConfigPath); err != nil {
+		return
+	}
+	applyBuildConfig(cfg, templateBuildConfig(cfg).Args)
+}
+
+// applyBuildConfig writes the build settings in the template to the builder project.
+// Environment variables of previous build args are replaced with the current ones.
+func applyBuildConfig(cfg *config.Config, previousArgs []string) {
+	build := templateBuildConfig(cfg)
+
+	computeType := defaultComputeType
+	if build.Compute != "" {
+		computeType = computeTypes[build.Compute]
+	}
+	if err := cfg.Set(builderComputeTypePath, computeType); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"computeType": computeType,
+			"config":      cfg,
+		}).Fatal("Failed to set compute type to template" + err.Error())
+	}
+
+	envVars, err := cfg.List(builderEnvVarsPath)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg,
+		}).Fatal("Failed to get environment variables of the builder" + err.Error())
+	}
+
+	newEnvVars := []interface{}{}
+	for i, envVar := range envVars {
+		name, _ := cfg.String(fmt.Sprintf("%s.%d.Name", builderEnvVarsPath, i))
+		if strings.HasPrefix(name, "HEROGATE_") || contains(previousArgs, name) {
+			continue
+		}
+		newEnvVars = append(newEnvVars, envVar)
+	}
+
+	// Config vars may be set as []map[string]string by generateUpdatedEnvVarsTemplate before rendering.
+	appEnvVars := map[string]string{}
+	if list, err := cfg.Get(envVarsPath); err == nil {
+		switch envs := list.Root.(type) {
+		case []map[string]string:
+			for _, env := range envs {
+				appEnvVars[env["Name"]] = env["Value"]
+			}
+		case []map[string]interface{}:
+			for _, env := range envs {
+				name, _ := env["Name"].(string)
+				value, _ := env["Value"].(string)
+				appEnvVars[name] = value
+			}
+		case []interface{}:
+			for _, e := range envs {
+				env, _ := e.(map[string]interface{})
+				name, _ := env["Name"].(string)
+				value, _ := env["Value"].(string)
+				appEnvVars[name] = value
+			}
+		}
+	}
+
+	settings := map[string]string{
+		"HEROGATE_DOCKERFILE":   build.Dockerfile,
+		"HEROGATE_BUILD_TARGET": build.Target,
+		"HEROGATE_BUILD_ARGS":   strings.Join(build.Args, " "),
+	}
+	names := []string{}
+	for name, value := range settings {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		newEnvVars = append(newEnvVars, map[string]interface{}{"Name": name, "Value": settings[name]})
+	}
+	for _, arg := range build.Args {
+		value, ok := appEnvVars[arg]
+		if !ok {
+			logrus.WithFields(logrus.Fields{
+				"arg": arg,
+			}).Debug("The config var of the build arg is not set")
+			continue
+		}
+		newEnvVars = append(newEnvVars, map[string]interface{}{"Name": arg, "Value": value})
+	}
+
+	if err = cfg.Set(builderEnvVarsPath, newEnvVars); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"envVars": newEnvVars,
+			"config":  cfg,
+		}).Fatal("Failed to set environment variables of the builder" + err.Error())
+	}
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
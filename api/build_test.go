@@ -0,0 +1,167 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/wata727/herogate/api/objects"
+)
+
+func TestGenerateBuildConfigTemplate(t *testing.T) {
+	base := `Metadata:
+  HerogateBuild:
+    Args:
+    - OLD_ARG
+    Compute: ""
+    Dockerfile: ""
+    Target: ""
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Environment:
+        - Name: NPM_TOKEN
+          Value: secret
+        - Name: OLD_ARG
+          Value: old
+  HerogateBuilder:
+    Properties:
+      Environment:
+        ComputeType: BUILD_GENERAL1_SMALL
+        EnvironmentVariables:
+        - Name: APP_NAME
+          Value: young-eyrie-24091
+        - Name: HEROGATE_BUILD_ARGS
+          Value: OLD_ARG
+        - Name: OLD_ARG
+          Value: old
+`
+
+	template := generateBuildConfigTemplate(base, &objects.BuildConfig{
+		Dockerfile: "api/Dockerfile",
+		Target:     "prod",
+		Compute:    "large",
+		Args:       []string{"NPM_TOKEN"},
+	})
+	expected := `Metadata:
+  HerogateBuild:
+    Args:
+    - NPM_TOKEN
+    Compute: large
+    Dockerfile: api/Dockerfile
+    Target: prod
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Environment:
+        - Name: NPM_TOKEN
+          Value: secret
+        - Name: OLD_ARG
+          Value: old
+  HerogateBuilder:
+    Properties:
+      Environment:
+        ComputeType: BUILD_GENERAL1_LARGE
+        EnvironmentVariables:
+        - Name: APP_NAME
+          Value: young-eyrie-24091
+        - Name: HEROGATE_BUILD_ARGS
+          Value: NPM_TOKEN
+        - Name: HEROGATE_BUILD_TARGET
+          Value: prod
+        - Name: HEROGATE_DOCKERFILE
+          Value: api/Dockerfile
+        - Name: NPM_TOKEN
+          Value: secret
+`
+	if template != expected {
+		t.Fatalf("\nExpected: %s\nActual: %s", expected, template)
+	}
+}
+
+func TestGenerateUpdatedEnvVarsTemplate__syncBuildArgs(t *testing.T) {
+	base := `Metadata:
+  HerogateBuild:
+    Args:
+    - NPM_TOKEN
+    Compute: ""
+    Dockerfile: ""
+    Target: ""
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Environment:
+        - Name: NPM_TOKEN
+          Value: secret
+  HerogateBuilder:
+    Properties:
+      Environment:
+        ComputeType: BUILD_GENERAL1_SMALL
+        EnvironmentVariables:
+        - Name: HEROGATE_BUILD_ARGS
+          Value: NPM_TOKEN
+        - Name: NPM_TOKEN
+          Value: secret
+`
+
+	template := generateUpdatedEnvVarsTemplate(base, map[string]string{"NPM_TOKEN": "rotated"})
+	expected := `Metadata:
+  HerogateBuild:
+    Args:
+    - NPM_TOKEN
+    Compute: ""
+    Dockerfile: ""
+    Target: ""
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Environment:
+        - Name: NPM_TOKEN
+          Value: rotated
+  HerogateBuilder:
+    Properties:
+      Environment:
+        ComputeType: BUILD_GENERAL1_SMALL
+        EnvironmentVariables:
+        - Name: HEROGATE_BUILD_ARGS
+          Value: NPM_TOKEN
+        - Name: NPM_TOKEN
+          Value: rotated
+`
+	if template != expected {
+		t.Fatalf("\nExpected: %s\nActual: %s", expected, template)
+	}
+}
+
+func TestValidateBuildConfig(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Build *objects.BuildConfig
+		Error bool
+	}{
+		{
+			Name:  "valid",
+			Build: &objects.BuildConfig{Compute: "medium", Args: []string{"NPM_TOKEN"}},
+			Error: false,
+		},
+		{
+			Name:  "unknown compute",
+			Build: &objects.BuildConfig{Compute: "huge"},
+			Error: true,
+		},
+		{
+			Name:  "reserved arg",
+			Build: &objects.BuildConfig{Args: []string{"AWS_SECRET_ACCESS_KEY"}},
+			Error: true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := ValidateBuildConfig(tc.Build)
+		if tc.Error != (err != nil) {
+			t.Fatalf("Test `%s` failed: Expected error is %t, but get `%v`", tc.Name, tc.Error, err)
+		}
+	}
+}
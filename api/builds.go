@@ -0,0 +1,228 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/codepipeline"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/log"
+)
+
+// maxBuilds is the number of builds returned by DescribeBuilds.
+const maxBuilds = 10
+
+// ErrBuildNotFound is returned when the build ID does not match any builds of the application.
+var ErrBuildNotFound = errors.New("The build is not found")
+
+// ErrBuildNotRunning is returned when cancelling the build which has already finished.
+var ErrBuildNotRunning = errors.New("The build is not running")
+
+// DescribeBuilds returns recent builds of the application in the order of newest first.
+func (c *Client) DescribeBuilds(appName string) ([]*objects.Build, error) {
+	ids, err := c.listBuildIDs(appName)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > maxBuilds {
+		ids = ids[:maxBuilds]
+	}
+
+	return c.describeBuilds(appName, ids)
+}
+
+// GetBuild returns the build of the application.
+// The ID can be the full build ID or a prefix of the UUID part such as displayed by `herogate builds`.
+func (c *Client) GetBuild(appName string, id string) (*objects.Build, error) {
+	buildID, err := c.findBuildID(appName, id)
+	if err != nil {
+		return nil, err
+	}
+
+	builds, err := c.describeBuilds(appName, []*string{aws.String(buildID)})
+	if err != nil {
+		return nil, err
+	}
+	if len(builds) == 0 {
+		return nil, ErrBuildNotFound
+	}
+	return builds[0], nil
+}
+
+// DescribeBuildOutput returns the complete log of the build.
+// Unlike DescribeLogs, it follows the pagination of CloudWatch Logs.
+func (c *Client) DescribeBuildOutput(appName string, id string) ([]*log.Log, error) {
+	buildID, err := c.findBuildID(appName, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.codeBuild.BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: []*string{aws.String(buildID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Builds) == 0 {
+		return nil, ErrBuildNotFound
+	}
+	build := resp.Builds[0]
+	if build.Logs == nil || build.Logs.StreamName == nil {
+		// The log stream is not created until the build starts
+		return []*log.Log{}, nil
+	}
+
+	logs := []*log.Log{}
+	var token *string
+	for {
+		eventsResp, err := c.cloudWatchLogs.GetLogEvents(&cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  build.Logs.GroupName,
+			LogStreamName: build.Logs.StreamName,
+			StartFromHead: aws.Bool(true),
+			NextToken:     token,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range eventsResp.Events {
+			logs = append(logs, &log.Log{
+				ID:        fmt.Sprintf("%s-%d-%s", buildID, aws.Int64Value(event.Timestamp), aws.StringValue(event.Message)),
+				Timestamp: aws.MillisecondsTimeValue(event.Timestamp).UTC(),
+				Source:    log.HerogateSource,
+				Process:   log.BuilderProcess,
+				Message:   strings.TrimRight(aws.StringValue(event.Message), "\n"),
+			})
+		}
+
+		// GetLogEvents returns the same token at the end of the stream
+		if eventsResp.NextForwardToken == nil || aws.StringValue(eventsResp.NextForwardToken) == aws.StringValue(token) {
+			break
+		}
+		token = eventsResp.NextForwardToken
+	}
+
+	return logs, nil
+}
+
+// CancelBuild stops the running build of the application, and returns it.
+// If the build has already finished, returns ErrBuildNotRunning.
+func (c *Client) CancelBuild(appName string, id string) (*objects.Build, error) {
+	build, err := c.GetBuild(appName, id)
+	if err != nil {
+		return nil, err
+	}
+	if build.Status != codebuild.StatusTypeInProgress {
+		return nil, ErrBuildNotRunning
+	}
+
+	if _, err = c.codeBuild.StopBuild(&codebuild.StopBuildInput{
+		Id: aws.String(build.ID),
+	}); err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+func (c *Client) listBuildIDs(appName string) ([]*string, error) {
+	resp, err := c.codeBuild.ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String(appName),
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ids, nil
+}
+
+// findBuildID returns the full build ID matching the ID. Build ID is "project:uuid".
+func (c *Client) findBuildID(appName string, id string) (string, error) {
+	ids, err := c.listBuildIDs(appName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, buildID := range aws.StringValueSlice(ids) {
+		if buildID == id || strings.HasPrefix(buildID[strings.LastIndex(buildID, ":")+1:], id) {
+			return buildID, nil
+		}
+	}
+	return "", ErrBuildNotFound
+}
+
+func (c *Client) describeBuilds(appName string, ids []*string) ([]*objects.Build, error) {
+	builds := []*objects.Build{}
+	if len(ids) == 0 {
+		return builds, nil
+	}
+
+	resp, err := c.codeBuild.BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, build := range resp.Builds {
+		builds = append(builds, &objects.Build{
+			ID:        aws.StringValue(build.Id),
+			Status:    aws.StringValue(build.BuildStatus),
+			Phase:     aws.StringValue(build.CurrentPhase),
+			StartTime: aws.TimeValue(build.StartTime),
+			EndTime:   aws.TimeValue(build.EndTime),
+		})
+	}
+
+	if err = c.setBuildRevisions(appName, builds); err != nil {
+		return nil, err
+	}
+	return builds, nil
+}
+
+// setBuildRevisions sets source revisions to the builds.
+// CodeBuild doesn't know the commit of the pipeline source, so it looks for the pipeline execution
+// started last before the build.
+func (c *Client) setBuildRevisions(appName string, builds []*objects.Build) error {
+	resp, err := c.codePipeline.ListPipelineExecutions(&codepipeline.ListPipelineExecutionsInput{
+		PipelineName: aws.String(appName),
+	})
+	if err != nil {
+		return err
+	}
+
+	revisions := map[string]string{}
+	for _, build := range builds {
+		var execution *codepipeline.PipelineExecutionSummary
+		for _, summary := range resp.PipelineExecutionSummaries {
+			startTime := aws.TimeValue(summary.StartTime)
+			if startTime.After(build.StartTime) {
+				continue
+			}
+			if execution == nil || startTime.After(aws.TimeValue(execution.StartTime)) {
+				execution = summary
+			}
+		}
+		if execution == nil {
+			continue
+		}
+
+		executionID := aws.StringValue(execution.PipelineExecutionId)
+		if _, ok := revisions[executionID]; !ok {
+			revision, _, err := c.describeExecutionRevision(appName, executionID)
+			if err != nil {
+				return err
+			}
+			revisions[executionID] = revision
+		}
+
+		build.Revision = revisions[executionID]
+		build.ImageTag = imageTag(build.Revision)
+	}
+
+	return nil
+}
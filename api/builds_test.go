@@ -0,0 +1,254 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/codepipeline"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/log"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestDescribeBuilds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Date(2018, time.February, 3, 1, 30, 0, 0, time.UTC)
+	codeBuildMock := mock.NewMockCodeBuildAPI(ctrl)
+	// Expect to list builds
+	codeBuildMock.EXPECT().ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("young-eyrie-24091"),
+		SortOrder:   aws.String("DESCENDING"),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: []*string{
+			aws.String("young-eyrie-24091:0b1c2d3e"),
+			aws.String("young-eyrie-24091:9a8b7c6d"),
+		},
+	}, nil)
+	// Expect to get builds
+	codeBuildMock.EXPECT().BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: []*string{
+			aws.String("young-eyrie-24091:0b1c2d3e"),
+			aws.String("young-eyrie-24091:9a8b7c6d"),
+		},
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:           aws.String("young-eyrie-24091:0b1c2d3e"),
+				BuildStatus:  aws.String("IN_PROGRESS"),
+				CurrentPhase: aws.String("BUILD"),
+				StartTime:    aws.Time(start.Add(10 * time.Minute)),
+			},
+			{
+				Id:           aws.String("young-eyrie-24091:9a8b7c6d"),
+				BuildStatus:  aws.String("SUCCEEDED"),
+				CurrentPhase: aws.String("COMPLETED"),
+				StartTime:    aws.Time(start),
+				EndTime:      aws.Time(start.Add(3 * time.Minute)),
+			},
+		},
+	}, nil)
+
+	codePipelineMock := mock.NewMockCodePipelineAPI(ctrl)
+	// Expect to list pipeline executions
+	codePipelineMock.EXPECT().ListPipelineExecutions(&codepipeline.ListPipelineExecutionsInput{
+		PipelineName: aws.String("young-eyrie-24091"),
+	}).Return(&codepipeline.ListPipelineExecutionsOutput{
+		PipelineExecutionSummaries: []*codepipeline.PipelineExecutionSummary{
+			{
+				PipelineExecutionId: aws.String("2b3c4d5e-0000-0000-0000-000000000000"),
+				StartTime:           aws.Time(start.Add(9 * time.Minute)),
+			},
+			{
+				PipelineExecutionId: aws.String("1a2b3c4d-0000-0000-0000-000000000000"),
+				StartTime:           aws.Time(start.Add(-1 * time.Minute)),
+			},
+		},
+	}, nil)
+	// Expect to get source revisions of executions
+	codePipelineMock.EXPECT().GetPipelineExecution(&codepipeline.GetPipelineExecutionInput{
+		PipelineName:        aws.String("young-eyrie-24091"),
+		PipelineExecutionId: aws.String("2b3c4d5e-0000-0000-0000-000000000000"),
+	}).Return(&codepipeline.GetPipelineExecutionOutput{
+		PipelineExecution: &codepipeline.PipelineExecution{
+			ArtifactRevisions: []*codepipeline.ArtifactRevision{
+				{
+					Name:       aws.String("HerogateSource"),
+					RevisionId: aws.String("d4e5f60718293a4b5c6d7e8f90a1b2c3c9f1a2b3"),
+				},
+			},
+		},
+	}, nil)
+	codePipelineMock.EXPECT().GetPipelineExecution(&codepipeline.GetPipelineExecutionInput{
+		PipelineName:        aws.String("young-eyrie-24091"),
+		PipelineExecutionId: aws.String("1a2b3c4d-0000-0000-0000-000000000000"),
+	}).Return(&codepipeline.GetPipelineExecutionOutput{
+		PipelineExecution: &codepipeline.PipelineExecution{
+			ArtifactRevisions: []*codepipeline.ArtifactRevision{
+				{
+					Name:       aws.String("HerogateSource"),
+					RevisionId: aws.String("c9f1a2b3d4e5f60718293a4b5c6d7e8f90a1b2c3"),
+				},
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.codeBuild = codeBuildMock
+	client.codePipeline = codePipelineMock
+
+	builds, err := client.DescribeBuilds("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := []*objects.Build{
+		{
+			ID:        "young-eyrie-24091:0b1c2d3e",
+			Status:    "IN_PROGRESS",
+			Phase:     "BUILD",
+			Revision:  "d4e5f60718293a4b5c6d7e8f90a1b2c3c9f1a2b3",
+			ImageTag:  "d4e5f607",
+			StartTime: start.Add(10 * time.Minute),
+		},
+		{
+			ID:        "young-eyrie-24091:9a8b7c6d",
+			Status:    "SUCCEEDED",
+			Phase:     "COMPLETED",
+			Revision:  "c9f1a2b3d4e5f60718293a4b5c6d7e8f90a1b2c3",
+			ImageTag:  "c9f1a2b3",
+			StartTime: start,
+			EndTime:   start.Add(3 * time.Minute),
+		},
+	}
+	if !cmp.Equal(expected, builds) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, builds))
+	}
+}
+
+func TestDescribeBuildOutput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	codeBuildMock := mock.NewMockCodeBuildAPI(ctrl)
+	// Expect to list builds
+	codeBuildMock.EXPECT().ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("young-eyrie-24091"),
+		SortOrder:   aws.String("DESCENDING"),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: []*string{
+			aws.String("young-eyrie-24091:0b1c2d3e"),
+			aws.String("young-eyrie-24091:9a8b7c6d"),
+		},
+	}, nil)
+	// Expect to get the build matching the short ID
+	codeBuildMock.EXPECT().BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: []*string{aws.String("young-eyrie-24091:9a8b7c6d")},
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id: aws.String("young-eyrie-24091:9a8b7c6d"),
+				Logs: &codebuild.LogsLocation{
+					GroupName:  aws.String("/aws/codebuild/young-eyrie-24091"),
+					StreamName: aws.String("9a8b7c6d"),
+				},
+			},
+		},
+	}, nil)
+
+	cloudWatchLogsMock := mock.NewMockCloudWatchLogsAPI(ctrl)
+	// Expect to get log events until the token does not change
+	cloudWatchLogsMock.EXPECT().GetLogEvents(&cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String("/aws/codebuild/young-eyrie-24091"),
+		LogStreamName: aws.String("9a8b7c6d"),
+		StartFromHead: aws.Bool(true),
+	}).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{
+				Timestamp: aws.Int64(1517621400000),
+				Message:   aws.String("[Container] Entering phase BUILD\n"),
+			},
+		},
+		NextForwardToken: aws.String("f/1"),
+	}, nil)
+	cloudWatchLogsMock.EXPECT().GetLogEvents(&cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String("/aws/codebuild/young-eyrie-24091"),
+		LogStreamName: aws.String("9a8b7c6d"),
+		StartFromHead: aws.Bool(true),
+		NextToken:     aws.String("f/1"),
+	}).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{
+				Timestamp: aws.Int64(1517621460000),
+				Message:   aws.String("Successfully built 3f2e1d0c\n"),
+			},
+		},
+		NextForwardToken: aws.String("f/2"),
+	}, nil)
+	cloudWatchLogsMock.EXPECT().GetLogEvents(&cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String("/aws/codebuild/young-eyrie-24091"),
+		LogStreamName: aws.String("9a8b7c6d"),
+		StartFromHead: aws.Bool(true),
+		NextToken:     aws.String("f/2"),
+	}).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events:           []*cloudwatchlogs.OutputLogEvent{},
+		NextForwardToken: aws.String("f/2"),
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.codeBuild = codeBuildMock
+	client.cloudWatchLogs = cloudWatchLogsMock
+
+	logs, err := client.DescribeBuildOutput("young-eyrie-24091", "9a8b")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := []*log.Log{
+		{
+			ID:        "young-eyrie-24091:9a8b7c6d-1517621400000-[Container] Entering phase BUILD\n",
+			Timestamp: time.Date(2018, time.February, 3, 1, 30, 0, 0, time.UTC),
+			Source:    log.HerogateSource,
+			Process:   log.BuilderProcess,
+			Message:   "[Container] Entering phase BUILD",
+		},
+		{
+			ID:        "young-eyrie-24091:9a8b7c6d-1517621460000-Successfully built 3f2e1d0c\n",
+			Timestamp: time.Date(2018, time.February, 3, 1, 31, 0, 0, time.UTC),
+			Source:    log.HerogateSource,
+			Process:   log.BuilderProcess,
+			Message:   "Successfully built 3f2e1d0c",
+		},
+	}
+	if !cmp.Equal(expected, logs) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, logs))
+	}
+}
+
+func TestCancelBuild__notFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	codeBuildMock := mock.NewMockCodeBuildAPI(ctrl)
+	// Expect to list builds
+	codeBuildMock.EXPECT().ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("young-eyrie-24091"),
+		SortOrder:   aws.String("DESCENDING"),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: []*string{aws.String("young-eyrie-24091:0b1c2d3e")},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.codeBuild = codeBuildMock
+
+	_, err := client.CancelBuild("young-eyrie-24091", "9a8b")
+	if err != ErrBuildNotFound {
+		t.Fatalf("Expected error is ErrBuildNotFound, but get `%v`", err)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/wata727/herogate/api/objects"
+)
+
+// CIPlatformVersion is the minimum platform version which has the test stage.
+const CIPlatformVersion = "1.2"
+
+// maxTestRuns is the number of test runs returned by DescribeTestRuns.
+const maxTestRuns = 10
+
+func testerProjectName(appName string) string {
+	return appName + "-tester"
+}
+
+// DescribeTestRuns returns recent test runs of the application in the order of newest first.
+func (c *Client) DescribeTestRuns(appName string) ([]*objects.TestRun, error) {
+	listResp, err := c.codeBuild.ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String(testerProjectName(appName)),
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []*objects.TestRun{}
+	ids := listResp.Ids
+	if len(ids) > maxTestRuns {
+		ids = ids[:maxTestRuns]
+	}
+	if len(ids) == 0 {
+		return runs, nil
+	}
+
+	buildsResp, err := c.codeBuild.BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, build := range buildsResp.Builds {
+		runs = append(runs, &objects.TestRun{
+			ID:        aws.StringValue(build.Id),
+			Status:    aws.StringValue(build.BuildStatus),
+			StartTime: aws.TimeValue(build.StartTime),
+			EndTime:   aws.TimeValue(build.EndTime),
+		})
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestDescribeTestRuns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Date(2018, time.February, 3, 1, 30, 0, 0, time.UTC)
+	codeBuildMock := mock.NewMockCodeBuildAPI(ctrl)
+	// Expect to list builds of the tester
+	codeBuildMock.EXPECT().ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("young-eyrie-24091-tester"),
+		SortOrder:   aws.String("DESCENDING"),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: []*string{
+			aws.String("young-eyrie-24091-tester:0b1c2d3e"),
+			aws.String("young-eyrie-24091-tester:9a8b7c6d"),
+		},
+	}, nil)
+	// Expect to get builds
+	codeBuildMock.EXPECT().BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: []*string{
+			aws.String("young-eyrie-24091-tester:0b1c2d3e"),
+			aws.String("young-eyrie-24091-tester:9a8b7c6d"),
+		},
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:          aws.String("young-eyrie-24091-tester:0b1c2d3e"),
+				BuildStatus: aws.String("IN_PROGRESS"),
+				StartTime:   aws.Time(start.Add(10 * time.Minute)),
+			},
+			{
+				Id:          aws.String("young-eyrie-24091-tester:9a8b7c6d"),
+				BuildStatus: aws.String("FAILED"),
+				StartTime:   aws.Time(start),
+				EndTime:     aws.Time(start.Add(80 * time.Second)),
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.codeBuild = codeBuildMock
+
+	runs, err := client.DescribeTestRuns("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := []*objects.TestRun{
+		{
+			ID:        "young-eyrie-24091-tester:0b1c2d3e",
+			Status:    "IN_PROGRESS",
+			StartTime: start.Add(10 * time.Minute),
+		},
+		{
+			ID:        "young-eyrie-24091-tester:9a8b7c6d",
+			Status:    "FAILED",
+			StartTime: start,
+			EndTime:   start.Add(80 * time.Second),
+		},
+	}
+	if !cmp.Equal(expected, runs) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, runs))
+	}
+}
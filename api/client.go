@@ -26,6 +26,7 @@ import (
 //go:generate mockgen -source ../vendor/github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface/interface.go -destination ../mock/cloudformation.go -package mock
 //go:generate mockgen -source ../vendor/github.com/aws/aws-sdk-go/service/s3/s3iface/interface.go -destination ../mock/s3.go -package mock
 //go:generate mockgen -source ../vendor/github.com/aws/aws-sdk-go/service/ecr/ecriface/interface.go -destination ../mock/ecr.go -package mock
+//go:generate mockgen -source ../vendor/github.com/aws/aws-sdk-go/service/codepipeline/codepipelineiface/interface.go -destination ../mock/codepipeline.go -package mock
 
 // Client is the Herogate API client.
 // This is a wrapper of AWS API clients.
@@ -5,10 +5,10 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/olebedev/config"
 	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api/objects"
 )
 
 // DescribeEnvVars describes environment variables from container deifinitions.
@@ -48,6 +48,10 @@ func (c *Client) DescribeEnvVars(appName string) (map[string]string, error) {
 
 	envVars := map[string]string{}
 	for _, env := range definiation.Environment {
+		// PORT is injected by Herogate, not a config var
+		if aws.StringValue(env.Name) == portEnvName {
+			continue
+		}
 		envVars[aws.StringValue(env.Name)] = aws.StringValue(env.Value)
 	}
 
@@ -57,9 +61,14 @@ func (c *Client) DescribeEnvVars(appName string) (map[string]string, error) {
 // SetEnvVars updates CloudFormation stack with new environment variables.
 // It generates new template by adding or merging environment variables from existing template.
 // When the template did not change, it does not perform updates.
+// When the stack is being changed by another operation, returns StackBusyError.
 // Because this operation restarts existing containers, It takes time to complete.
 func (c *Client) SetEnvVars(appName string, envVars map[string]string) error {
-	if _, err := c.GetApp(appName); err != nil {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return err
+	}
+	if err = checkStackIdle(app); err != nil {
 		return err
 	}
 
@@ -69,26 +78,23 @@ func (c *Client) SetEnvVars(appName string, envVars map[string]string) error {
 		return nil
 	}
 
-	_, err := c.cloudFormation.UpdateStack(&cloudformation.UpdateStackInput{
-		StackName:    aws.String(appName),
-		TemplateBody: aws.String(template),
-		Capabilities: []*string{aws.String("CAPABILITY_NAMED_IAM")},
-	})
-	if err != nil {
-		logrus.WithFields(logrus.Fields{
-			"appName": appName,
-		}).Fatal("Failed to request for updating stack: " + err.Error())
+	return c.updateStack(appName, template)
+}
+
+// PreviewSetEnvVars returns resource changes when setting environment variables.
+// It creates a CloudFormation change set from the generated template and deletes it without executing.
+func (c *Client) PreviewSetEnvVars(appName string, envVars map[string]string) ([]*objects.Change, error) {
+	if _, err := c.GetApp(appName); err != nil {
+		return nil, err
 	}
-	err = c.cloudFormation.WaitUntilStackUpdateComplete(&cloudformation.DescribeStacksInput{
-		StackName: aws.String(appName),
-	})
-	if err != nil {
-		logrus.WithFields(logrus.Fields{
-			"appName": appName,
-		}).Fatal("Failed to wait stack update: " + err.Error())
+
+	base := c.GetTemplate(appName)
+	template := generateUpdatedEnvVarsTemplate(base, envVars)
+	if base == template {
+		return []*objects.Change{}, nil
 	}
 
-	return nil
+	return c.previewStackUpdate(appName, template)
 }
 
 func generateUpdatedEnvVarsTemplate(base string, envVars map[string]string) string {
@@ -155,6 +161,9 @@ func generateUpdatedEnvVarsTemplate(base string, envVars map[string]string) stri
 		}).Fatal("Failed to set environments to template" + err.Error())
 	}
 
+	// Build args are taken from config vars, so update them with the new values.
+	syncBuildArgs(cfg)
+
 	result, err := config.RenderYaml(cfg.Root)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -168,9 +177,14 @@ func generateUpdatedEnvVarsTemplate(base string, envVars map[string]string) stri
 // UnsetEnvVars updates CloudFormation stack with new environment variables.
 // It generates new template by deleting environment variables from existing template.
 // When the template did not change, it does not perform updates.
+// When the stack is being changed by another operation, returns StackBusyError.
 // Because this operation restarts existing containers, It takes time to complete.
 func (c *Client) UnsetEnvVars(appName string, envList []string) error {
-	if _, err := c.GetApp(appName); err != nil {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return err
+	}
+	if err = checkStackIdle(app); err != nil {
 		return err
 	}
 
@@ -180,26 +194,23 @@ func (c *Client) UnsetEnvVars(appName string, envList []string) error {
 		return nil
 	}
 
-	_, err := c.cloudFormation.UpdateStack(&cloudformation.UpdateStackInput{
-		StackName:    aws.String(appName),
-		TemplateBody: aws.String(template),
-		Capabilities: []*string{aws.String("CAPABILITY_NAMED_IAM")},
-	})
-	if err != nil {
-		logrus.WithFields(logrus.Fields{
-			"appName": appName,
-		}).Fatal("Failed to request for updating stack: " + err.Error())
+	return c.updateStack(appName, template)
+}
+
+// PreviewUnsetEnvVars returns resource changes when unsetting environment variables.
+// It creates a CloudFormation change set from the generated template and deletes it without executing.
+func (c *Client) PreviewUnsetEnvVars(appName string, envList []string) ([]*objects.Change, error) {
+	if _, err := c.GetApp(appName); err != nil {
+		return nil, err
 	}
-	err = c.cloudFormation.WaitUntilStackUpdateComplete(&cloudformation.DescribeStacksInput{
-		StackName: aws.String(appName),
-	})
-	if err != nil {
-		logrus.WithFields(logrus.Fields{
-			"appName": appName,
-		}).Fatal("Failed to wait stack update: " + err.Error())
+
+	base := c.GetTemplate(appName)
+	template := generateUnsettedEnvVarsTemplate(base, envList)
+	if base == template {
+		return []*objects.Change{}, nil
 	}
 
-	return nil
+	return c.previewStackUpdate(appName, template)
 }
 
 func generateUnsettedEnvVarsTemplate(base string, envList []string) string {
@@ -254,6 +265,9 @@ func generateUnsettedEnvVarsTemplate(base string, envList []string) string {
 		}).Fatal("Failed to set environments to template" + err.Error())
 	}
 
+	// Build args are taken from config vars, so update them with the new values.
+	syncBuildArgs(cfg)
+
 	result, err := config.RenderYaml(cfg.Root)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
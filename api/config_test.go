@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/wata727/herogate/api/objects"
 	"github.com/wata727/herogate/mock"
 )
 
@@ -602,3 +603,217 @@ func TestUnsetEnvVars__notFound(t *testing.T) {
 		t.Fatal("Expected error is not nil, but get nil as error")
 	}
 }
+
+func TestPreviewSetEnvVars(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	changeSetName = func() string { return "herogate-dry-run-1521378000" }
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to call GetApp and return App
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackStatus: aws.String("CREATE_COMPLETE"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.0"),
+					},
+				},
+			},
+		},
+	}, nil)
+	// Expect to get template with application name
+	cfnMock.EXPECT().GetTemplate(&cloudformation.GetTemplateInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.GetTemplateOutput{
+		TemplateBody: aws.String(`AWSTemplateFormatVersion: 2010-09-09
+Description: Herogate Platform Template v1.0
+Resources:
+  HerogateApplicationContainer:
+    Type: "AWS::ECS::TaskDefinition"
+    Properties:
+      ContainerDefinitions:
+        - Name: web
+          Image: "httpd:2.4"
+`),
+	}, nil)
+	// Expect to create change set
+	cfnMock.EXPECT().CreateChangeSet(&cloudformation.CreateChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+		ChangeSetType: aws.String("UPDATE"),
+		TemplateBody: aws.String(`AWSTemplateFormatVersion: 2010-09-09
+Description: Herogate Platform Template v1.0
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Environment:
+        - Name: RAILS_ENV
+          Value: production
+        Image: httpd:2.4
+        Name: web
+    Type: AWS::ECS::TaskDefinition
+`),
+		Capabilities: []*string{aws.String("CAPABILITY_NAMED_IAM")},
+	}).Return(&cloudformation.CreateChangeSetOutput{}, nil)
+	// Expect to wait change set creation
+	cfnMock.EXPECT().WaitUntilChangeSetCreateComplete(&cloudformation.DescribeChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+	}).Return(nil)
+	// Expect to describe change set
+	cfnMock.EXPECT().DescribeChangeSet(&cloudformation.DescribeChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+	}).Return(&cloudformation.DescribeChangeSetOutput{
+		Status: aws.String("CREATE_COMPLETE"),
+		Changes: []*cloudformation.Change{
+			{
+				Type: aws.String("Resource"),
+				ResourceChange: &cloudformation.ResourceChange{
+					Action:            aws.String("Modify"),
+					LogicalResourceId: aws.String("HerogateApplicationContainer"),
+					ResourceType:      aws.String("AWS::ECS::TaskDefinition"),
+					Replacement:       aws.String("True"),
+				},
+			},
+			{
+				Type: aws.String("Resource"),
+				ResourceChange: &cloudformation.ResourceChange{
+					Action:            aws.String("Modify"),
+					LogicalResourceId: aws.String("HerogateApplicationService"),
+					ResourceType:      aws.String("AWS::ECS::Service"),
+					Replacement:       aws.String("False"),
+				},
+			},
+		},
+	}, nil)
+	// Expect to delete change set
+	cfnMock.EXPECT().DeleteChangeSet(&cloudformation.DeleteChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+	}).Return(&cloudformation.DeleteChangeSetOutput{}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	changes, err := client.PreviewSetEnvVars("young-eyrie-24091", map[string]string{
+		"RAILS_ENV": "production",
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := []*objects.Change{
+		{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateApplicationContainer",
+			ResourceType:      "AWS::ECS::TaskDefinition",
+			Replacement:       "True",
+		},
+		{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateApplicationService",
+			ResourceType:      "AWS::ECS::Service",
+			Replacement:       "False",
+		},
+	}
+	if !cmp.Equal(expected, changes) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, changes))
+	}
+}
+
+func TestPreviewUnsetEnvVars__noUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to call GetApp and return App
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackStatus: aws.String("CREATE_COMPLETE"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.0"),
+					},
+				},
+			},
+		},
+	}, nil)
+	// Expect to get template with application name
+	cfnMock.EXPECT().GetTemplate(&cloudformation.GetTemplateInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.GetTemplateOutput{
+		TemplateBody: aws.String(`AWSTemplateFormatVersion: 2010-09-09
+Description: Herogate Platform Template v1.0
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Environment:
+        - Name: RACK_ENV
+          Value: production
+        Image: httpd:2.4
+        Name: web
+    Type: AWS::ECS::TaskDefinition
+`),
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	changes, err := client.PreviewUnsetEnvVars("young-eyrie-24091", []string{"RAILS_ENV"})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Expected changes are empty, but get `%#v`", changes)
+	}
+}
+
+func TestSetEnvVars__busy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to call GetApp and return App in progress
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackStatus: aws.String("UPDATE_IN_PROGRESS"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.0"),
+					},
+				},
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	err := client.SetEnvVars("young-eyrie-24091", map[string]string{
+		"RAILS_ENV": "production",
+	})
+	busyErr, ok := err.(*StackBusyError)
+	if !ok {
+		t.Fatalf("Expected error is StackBusyError, but get `%#v`", err)
+	}
+	if busyErr.Status != "UPDATE_IN_PROGRESS" {
+		t.Fatalf("Expected status is `UPDATE_IN_PROGRESS`, but get `%s`", busyErr.Status)
+	}
+}
@@ -0,0 +1,316 @@
+package fake
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/assets"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/api/options"
+	"github.com/wata727/herogate/container"
+)
+
+// defaultBranch is the branch deployed by the pipeline unless the app sets it.
+const defaultBranch = "master"
+
+// defaultImage is the image which new apps run until the first release.
+const defaultImage = "httpd:2.4"
+
+// CreateApp creates the app and waits until the creation completes.
+// A review app takes over config vars and processes of the parent.
+func (c *Client) CreateApp(appName string, options *options.CreateApp) (*objects.App, error) {
+	template, err := assets.Asset("assets/platform.yaml")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to load the template: " + err.Error())
+	}
+
+	var created *app
+	err = c.transaction(func(s *state) error {
+		t := now()
+		if a, ok := s.Apps[appName]; ok && !a.deleted(t) {
+			return fmt.Errorf("AlreadyExistsException: Stack [%s] already exists", appName)
+		}
+
+		created = &app{
+			Name:            appName,
+			PlatformVersion: api.PlatformVersion,
+			Parent:          options.Parent,
+			Branch:          defaultBranch,
+			Template:        string(template),
+			EnvVars:         map[string]string{},
+			Port:            container.DefaultPort,
+			BuildConfig:     &objects.BuildConfig{Args: []string{}},
+			DesiredCount:    1,
+			Processes:       map[string][]string{"web": nil},
+			Releases:        []*release{},
+			Builds:          []*build{},
+			TestRuns:        []*build{},
+			Approvals:       []*approval{},
+		}
+		if options.Branch != "" {
+			created.Branch = options.Branch
+		}
+		if options.Parent != "" {
+			parent, err := s.findApp(options.Parent, t)
+			if err != nil {
+				return err
+			}
+			for key, value := range parent.EnvVars {
+				created.EnvVars[key] = value
+			}
+			created.Processes = parent.processes(t)
+			created.Port = parent.Port
+			created.BuildConfig = parent.BuildConfig
+			created.HealthCheck = parent.HealthCheck
+		}
+		for key, value := range options.EnvVars {
+			created.EnvVars[key] = value
+		}
+		if options.DesiredCount > 0 {
+			created.DesiredCount = options.DesiredCount
+		}
+
+		created.start(s, "CREATE", t, c.delay)
+		s.Apps[appName] = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.wait(created.OperationEndsAt)
+	return created.object(now()), nil
+}
+
+// GetAppCreationProgress returns the proportion of resources whose creation has completed.
+func (c *Client) GetAppCreationProgress(appName string) int {
+	return c.progress(appName, "CREATE_COMPLETE", 0)
+}
+
+// GetAppDeletionProgress returns the proportion of resources whose deletion has completed.
+// When the app has been deleted, returns 100%.
+func (c *Client) GetAppDeletionProgress(appName string) int {
+	return c.progress(appName, "DELETE_COMPLETE", 100)
+}
+
+func (c *Client) progress(appName string, status string, deleted int) int {
+	progress := deleted
+	c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		total := len(operationResources[a.Operation])
+		completed := 0
+		for _, event := range a.Events {
+			if event.ResourceStatus == status && event.LogicalResourceID != a.Name && !event.Timestamp.After(t) {
+				completed++
+			}
+		}
+		progress = completed * 100 / total
+		if progress > 100 {
+			progress = 100
+		}
+		return nil
+	})
+	return progress
+}
+
+// GetApp returns the app. If the app is not found, returns nil and error.
+func (c *Client) GetApp(appName string) (*objects.App, error) {
+	var result *objects.App
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		result = a.object(t)
+		return nil
+	})
+	return result, err
+}
+
+// GetAppInfo returns the app with containers and settings.
+func (c *Client) GetAppInfo(appName string) (*objects.AppInfo, error) {
+	var result *objects.AppInfo
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+
+		containers := []*objects.Container{}
+		for name, command := range a.processes(t) {
+			containers = append(containers, &objects.Container{Name: name, Count: a.DesiredCount, Command: command})
+		}
+		sort.Slice(containers, func(i, j int) bool {
+			return containers[i].Name < containers[j].Name
+		})
+
+		// The pipeline deploying the uploaded source has no branch
+		branch := a.Branch
+		if a.S3Source {
+			branch = ""
+		}
+		result = &objects.AppInfo{
+			App:         a.object(t),
+			Branch:      branch,
+			Containers:  containers,
+			HealthCheck: healthCheck(a),
+			Region:      region,
+		}
+		return nil
+	})
+	return result, err
+}
+
+// ListApps returns apps in the order of names.
+func (c *Client) ListApps() []*objects.App {
+	apps := []*objects.App{}
+	c.view(func(s *state) error {
+		t := now()
+		for _, a := range s.Apps {
+			if !a.deleted(t) {
+				apps = append(apps, a.object(t))
+			}
+		}
+		return nil
+	})
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Name < apps[j].Name
+	})
+	return apps
+}
+
+// StackExists returns whether or not the app exists.
+func (c *Client) StackExists(stackName string) bool {
+	_, err := c.GetApp(stackName)
+	return err == nil
+}
+
+// DestroyApp deletes the app and waits until the deletion completes.
+// When the app is being changed by another operation, returns StackBusyError.
+// When review apps of the app exist, returns ReviewAppsExistError.
+func (c *Client) DestroyApp(appName string) error {
+	var endsAt time.Time
+	err := c.transaction(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		if status := a.status(t); api.StackInProgress(status) {
+			return &api.StackBusyError{AppName: appName, Status: status}
+		}
+		apps := []*objects.App{}
+		for _, other := range s.Apps {
+			if !other.deleted(t) {
+				apps = append(apps, other.object(t))
+			}
+		}
+		if reviewApps := api.ReviewApps(apps, appName); len(reviewApps) > 0 {
+			sort.Strings(reviewApps)
+			return &api.ReviewAppsExistError{AppName: appName, ReviewApps: reviewApps}
+		}
+		a.start(s, "DELETE", t, c.delay)
+		endsAt = a.OperationEndsAt
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.wait(endsAt)
+	return nil
+}
+
+// RepairApp does nothing because fake operations never get stuck.
+func (c *Client) RepairApp(appName string) (*objects.Repair, error) {
+	current, err := c.GetApp(appName)
+	if err != nil {
+		return nil, err
+	}
+	if api.StackInProgress(current.Status) {
+		return nil, &api.StackBusyError{AppName: appName, Status: current.Status}
+	}
+	return &objects.Repair{Status: current.Status, Actions: []string{}}, nil
+}
+
+// UpgradeApp updates the platform version of the app to the latest.
+func (c *Client) UpgradeApp(appName string) error {
+	return c.update(appName, func(a *app) (bool, error) {
+		if a.PlatformVersion == api.PlatformVersion {
+			return false, nil
+		}
+		a.PlatformVersion = api.PlatformVersion
+		return true, nil
+	})
+}
+
+// PreviewUpgradeApp returns the change of the service if the app is not the latest platform version.
+func (c *Client) PreviewUpgradeApp(appName string) ([]*objects.Change, error) {
+	current, err := c.GetApp(appName)
+	if err != nil {
+		return nil, err
+	}
+	if current.PlatformVersion == api.PlatformVersion {
+		return []*objects.Change{}, nil
+	}
+	return []*objects.Change{serviceChange()}, nil
+}
+
+// GetTemplate returns the template which the app was created with.
+func (c *Client) GetTemplate(appName string) string {
+	var template string
+	c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"appName": appName,
+			}).Fatal("Failed to get stack template: " + err.Error())
+		}
+		template = a.Template
+		return nil
+	})
+	return template
+}
+
+// DescribeAppEvents returns events of the app in chronological order.
+func (c *Client) DescribeAppEvents(appName string) ([]*objects.StackEvent, error) {
+	events := []*objects.StackEvent{}
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		for _, event := range a.Events {
+			if !event.Timestamp.After(t) {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events, err
+}
+
+// serviceChange is the change of the service which most updates cause.
+func serviceChange() *objects.Change {
+	return &objects.Change{
+		Action:            "Modify",
+		LogicalResourceID: "HerogateApplicationService",
+		ResourceType:      "AWS::ECS::Service",
+		Replacement:       "False",
+	}
+}
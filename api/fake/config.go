@@ -0,0 +1,348 @@
+package fake
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/objects"
+)
+
+// DescribeEnvVars returns config vars of the app. PORT is injected by Herogate, so it is not included.
+func (c *Client) DescribeEnvVars(appName string) (map[string]string, error) {
+	envVars := map[string]string{}
+	err := c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			return err
+		}
+		for key, value := range a.EnvVars {
+			envVars[key] = value
+		}
+		return nil
+	})
+	return envVars, err
+}
+
+// SetEnvVars adds or merges config vars of the app.
+// When config vars did not change, it does not perform updates.
+func (c *Client) SetEnvVars(appName string, envVars map[string]string) error {
+	return c.update(appName, func(a *app) (bool, error) {
+		if !changesEnvVars(a, envVars) {
+			return false, nil
+		}
+		for key, value := range envVars {
+			a.EnvVars[key] = value
+		}
+		return true, nil
+	})
+}
+
+// UnsetEnvVars removes config vars of the app.
+// When config vars did not change, it does not perform updates.
+func (c *Client) UnsetEnvVars(appName string, envList []string) error {
+	return c.update(appName, func(a *app) (bool, error) {
+		if !removesEnvVars(a, envList) {
+			return false, nil
+		}
+		for _, key := range envList {
+			delete(a.EnvVars, key)
+		}
+		return true, nil
+	})
+}
+
+// PreviewSetEnvVars returns the change of the task definition if config vars change.
+func (c *Client) PreviewSetEnvVars(appName string, envVars map[string]string) ([]*objects.Change, error) {
+	return c.previewTaskDefinition(appName, func(a *app) bool { return changesEnvVars(a, envVars) })
+}
+
+// PreviewUnsetEnvVars returns the change of the task definition if config vars change.
+func (c *Client) PreviewUnsetEnvVars(appName string, envList []string) ([]*objects.Change, error) {
+	return c.previewTaskDefinition(appName, func(a *app) bool { return removesEnvVars(a, envList) })
+}
+
+// previewTaskDefinition returns the replacement of the task definition and the change of the service if it changes.
+func (c *Client) previewTaskDefinition(appName string, changes func(a *app) bool) ([]*objects.Change, error) {
+	result := []*objects.Change{}
+	err := c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			return err
+		}
+		if changes(a) {
+			result = append(result, &objects.Change{
+				Action:            "Modify",
+				LogicalResourceID: "HerogateApplicationContainer",
+				ResourceType:      "AWS::ECS::TaskDefinition",
+				Replacement:       "True",
+			}, serviceChange())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func changesEnvVars(a *app, envVars map[string]string) bool {
+	for key, value := range envVars {
+		if current, ok := a.EnvVars[key]; !ok || current != value {
+			return true
+		}
+	}
+	return false
+}
+
+func removesEnvVars(a *app, envList []string) bool {
+	for _, key := range envList {
+		if _, ok := a.EnvVars[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPort returns the port which the web process of the app listens on.
+func (c *Client) GetPort(appName string) (int, error) {
+	port := 0
+	err := c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			return err
+		}
+		port = a.Port
+		return nil
+	})
+	return port, err
+}
+
+// SetPort updates the port of the app. When the port did not change, it does not perform updates.
+func (c *Client) SetPort(appName string, port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("Invalid port %d, must be between 1 and 65535", port)
+	}
+	return c.update(appName, func(a *app) (bool, error) {
+		if a.Port == port {
+			return false, nil
+		}
+		a.Port = port
+		return true, nil
+	})
+}
+
+// PreviewSetPort returns the change of the task definition if the port changes.
+func (c *Client) PreviewSetPort(appName string, port int) ([]*objects.Change, error) {
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("Invalid port %d, must be between 1 and 65535", port)
+	}
+	return c.previewTaskDefinition(appName, func(a *app) bool { return a.Port != port })
+}
+
+// GetHealthCheck returns the health check settings of the app.
+// If they are not set, returns the defaults of the load balancer.
+func (c *Client) GetHealthCheck(appName string) (*objects.HealthCheck, error) {
+	var result *objects.HealthCheck
+	err := c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			return err
+		}
+		result = healthCheck(a)
+		return nil
+	})
+	return result, err
+}
+
+// SetHealthCheck updates the health check settings of the app.
+// When the settings did not change, it does not perform updates.
+func (c *Client) SetHealthCheck(appName string, healthCheck *objects.HealthCheck) error {
+	if err := api.ValidateHealthCheck(healthCheck); err != nil {
+		return err
+	}
+	return c.update(appName, func(a *app) (bool, error) {
+		if reflect.DeepEqual(a.HealthCheck, healthCheck) {
+			return false, nil
+		}
+		a.HealthCheck = healthCheck
+		return true, nil
+	})
+}
+
+// PreviewSetHealthCheck returns the change of the target group if the health check settings change.
+// When the command changes, the task definition is also replaced.
+func (c *Client) PreviewSetHealthCheck(appName string, settings *objects.HealthCheck) ([]*objects.Change, error) {
+	if err := api.ValidateHealthCheck(settings); err != nil {
+		return nil, err
+	}
+	result := []*objects.Change{}
+	err := c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(a.HealthCheck, settings) {
+			return nil
+		}
+		result = append(result, &objects.Change{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateLoadBalancerTargetGroup",
+			ResourceType:      "AWS::ElasticLoadBalancingV2::TargetGroup",
+			Replacement:       "False",
+		})
+		if healthCheck(a).Command != settings.Command {
+			result = append(result, &objects.Change{
+				Action:            "Modify",
+				LogicalResourceID: "HerogateApplicationContainer",
+				ResourceType:      "AWS::ECS::TaskDefinition",
+				Replacement:       "True",
+			}, serviceChange())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func healthCheck(a *app) *objects.HealthCheck {
+	if a.HealthCheck != nil {
+		return a.HealthCheck
+	}
+	return &objects.HealthCheck{
+		Path:             "/",
+		Interval:         30,
+		HealthyThreshold: 5,
+	}
+}
+
+// GetBuildConfig returns the build settings of the app.
+func (c *Client) GetBuildConfig(appName string) (*objects.BuildConfig, error) {
+	var result *objects.BuildConfig
+	err := c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			return err
+		}
+		result = a.BuildConfig
+		return nil
+	})
+	return result, err
+}
+
+// SetBuildConfig updates the build settings of the app.
+// When the settings did not change, it does not perform updates.
+func (c *Client) SetBuildConfig(appName string, build *objects.BuildConfig) error {
+	if err := api.ValidateBuildConfig(build); err != nil {
+		return err
+	}
+	return c.update(appName, func(a *app) (bool, error) {
+		if reflect.DeepEqual(a.BuildConfig, build) {
+			return false, nil
+		}
+		a.BuildConfig = build
+		return true, nil
+	})
+}
+
+// PreviewSetBuildConfig returns the change of the builder project if the build settings change.
+func (c *Client) PreviewSetBuildConfig(appName string, build *objects.BuildConfig) ([]*objects.Change, error) {
+	if err := api.ValidateBuildConfig(build); err != nil {
+		return nil, err
+	}
+	result := []*objects.Change{}
+	err := c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(a.BuildConfig, build) {
+			result = append(result, &objects.Change{
+				Action:            "Modify",
+				LogicalResourceID: "HerogateBuilder",
+				ResourceType:      "AWS::CodeBuild::Project",
+				Replacement:       "False",
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBranch returns the branch deployed by the pipeline of the app.
+// When the pipeline deploys the uploaded source, returns api.ErrS3Source.
+func (c *Client) GetBranch(appName string) (string, error) {
+	branch := ""
+	err := c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			return err
+		}
+		if a.S3Source {
+			return api.ErrS3Source
+		}
+		branch = a.Branch
+		return nil
+	})
+	return branch, err
+}
+
+// SetBranch updates the branch deployed by the pipeline of the app.
+// When the branch did not change, it does not perform updates.
+// When the pipeline deploys the uploaded source, returns api.ErrS3Source.
+func (c *Client) SetBranch(appName string, branch string) error {
+	return c.update(appName, func(a *app) (bool, error) {
+		if a.S3Source {
+			return false, api.ErrS3Source
+		}
+		if a.Branch == branch {
+			return false, nil
+		}
+		a.Branch = branch
+		return true, nil
+	})
+}
+
+// GetApproval returns whether or not the pipeline of the app has the manual approval stage.
+func (c *Client) GetApproval(appName string) (bool, error) {
+	enabled := false
+	err := c.view(func(s *state) error {
+		a, err := s.findApp(appName, now())
+		if err != nil {
+			return err
+		}
+		enabled = a.Approval
+		return nil
+	})
+	return enabled, err
+}
+
+// SetApproval adds or removes the manual approval stage.
+// When the approval did not change, it does not perform updates.
+func (c *Client) SetApproval(appName string, enabled bool) error {
+	return c.update(appName, func(a *app) (bool, error) {
+		if a.Approval == enabled {
+			return false, nil
+		}
+		a.Approval = enabled
+		return true, nil
+	})
+}
+
+// SetPipeline adds the app to the pipeline as the stage.
+// When the pipeline and the stage did not change, it does not perform updates.
+func (c *Client) SetPipeline(appName string, pipeline string, stage string) error {
+	return c.update(appName, func(a *app) (bool, error) {
+		if a.Pipeline == pipeline && a.Stage == stage {
+			return false, nil
+		}
+		a.Pipeline = pipeline
+		a.Stage = stage
+		return true, nil
+	})
+}
@@ -0,0 +1,390 @@
+// Package fake is an in-memory backend of Herogate for offline development and end-to-end tests.
+// It implements iface.ClientInterface without AWS. The state is kept in memory, or saved to a local file
+// so that it is shared between commands. Stack operations complete after the delay, and logs, builds
+// and releases of the pipeline appear over time like the real backend.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/log"
+)
+
+// DefaultDelay is the duration of stack operations and pipeline executions.
+const DefaultDelay = 3 * time.Second
+
+// region is the region of fake apps. Currently, Fargate supported region is only `us-east-1`.
+const region = "us-east-1"
+
+// now returns the current time. It is a variable so that tests can replace it.
+var now = time.Now
+
+// sleep waits for the duration. It is a variable so that tests can replace it.
+var sleep = time.Sleep
+
+// ClientOption is the options of the fake client.
+// StatePath is the file to save the state. If it is empty, the state is kept in memory.
+// Delay is the duration of stack operations and pipeline executions.
+type ClientOption struct {
+	StatePath string
+	Delay     time.Duration
+}
+
+// Client is the fake API client.
+type Client struct {
+	statePath string
+	delay     time.Duration
+	mutex     sync.Mutex
+	memory    *state
+}
+
+var _ iface.ClientInterface = (*Client)(nil)
+
+// NewClient returns the fake API client.
+func NewClient(option *ClientOption) *Client {
+	return &Client{
+		statePath: option.StatePath,
+		delay:     option.Delay,
+		memory:    newState(),
+	}
+}
+
+// state is the whole state of the fake backend. Sequence is used to generate IDs.
+type state struct {
+	Apps     map[string]*app
+	Sequence int
+}
+
+func newState() *state {
+	return &state{Apps: map[string]*app{}}
+}
+
+// nextID returns a new ID with the prefix.
+func (s *state) nextID(prefix string) string {
+	s.Sequence++
+	return fmt.Sprintf("%s%08d", prefix, s.Sequence)
+}
+
+// app is an application. The stack status is derived from the operation and the time.
+// Changes are applied when the operation starts, and the operation completes at OperationEndsAt.
+type app struct {
+	Name            string
+	PlatformVersion string
+	Parent          string
+	Pipeline        string
+	Stage           string
+	Branch          string
+	Template        string
+	Operation       string
+	OperationEndsAt time.Time
+	EnvVars         map[string]string
+	Port            int
+	HealthCheck     *objects.HealthCheck
+	BuildConfig     *objects.BuildConfig
+	Approval        bool
+	S3Source        bool
+	DesiredCount    int64
+	Processes       map[string][]string
+	Releases        []*release
+	Builds          []*build
+	TestRuns        []*build
+	Approvals       []*approval
+	Events          []*objects.StackEvent
+	Logs            []*log.Log
+}
+
+// release is a release which takes effect at CreatedAt. Execution is the pipeline execution which made it.
+type release struct {
+	objects.Release
+	Processes map[string][]string
+	Execution string
+}
+
+// build is a build or a test run. Status is the result, and it is in progress until EndTime.
+type build struct {
+	ID        string
+	Execution string
+	Status    string
+	Revision  string
+	ImageTag  string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// approval is a pending approval which is visible after RequestedAt.
+type approval struct {
+	objects.Approval
+	Processes map[string][]string
+}
+
+// status returns the stack status at the time.
+func (a *app) status(t time.Time) string {
+	if t.Before(a.OperationEndsAt) {
+		return a.Operation + "_IN_PROGRESS"
+	}
+	return a.Operation + "_COMPLETE"
+}
+
+// deleted returns whether or not the deletion of the app has completed.
+func (a *app) deleted(t time.Time) bool {
+	return a.Operation == "DELETE" && !t.Before(a.OperationEndsAt)
+}
+
+func (a *app) object(t time.Time) *objects.App {
+	return &objects.App{
+		Name:            a.Name,
+		Status:          a.status(t),
+		Repository:      fmt.Sprintf("ssh://git-codecommit.%s.amazonaws.com/v1/repos/%s", region, a.Name),
+		Endpoint:        fmt.Sprintf("http://%s-000000000.%s.elb.amazonaws.com", a.Name, region),
+		PlatformVersion: a.PlatformVersion,
+		Parent:          a.Parent,
+		Pipeline:        a.Pipeline,
+		Stage:           a.Stage,
+	}
+}
+
+// operationResources are resources which change in each operation.
+var operationResources = map[string][][2]string{
+	"CREATE": {
+		{"HerogateRepository", "AWS::CodeCommit::Repository"},
+		{"HerogateRegistry", "AWS::ECR::Repository"},
+		{"HerogateLoadBalancer", "AWS::ElasticLoadBalancingV2::LoadBalancer"},
+		{"HerogatePipeline", "AWS::CodePipeline::Pipeline"},
+		{"HerogateApplicationService", "AWS::ECS::Service"},
+	},
+	"UPDATE": {
+		{"HerogateApplicationContainer", "AWS::ECS::TaskDefinition"},
+		{"HerogateApplicationService", "AWS::ECS::Service"},
+	},
+	"DELETE": {
+		{"HerogateApplicationService", "AWS::ECS::Service"},
+		{"HerogatePipeline", "AWS::CodePipeline::Pipeline"},
+		{"HerogateLoadBalancer", "AWS::ElasticLoadBalancingV2::LoadBalancer"},
+		{"HerogateRegistry", "AWS::ECR::Repository"},
+		{"HerogateRepository", "AWS::CodeCommit::Repository"},
+	},
+}
+
+// start starts the operation. Stack events and deployer logs are scheduled over the delay.
+func (a *app) start(s *state, operation string, t time.Time, delay time.Duration) {
+	a.Operation = operation
+	a.OperationEndsAt = t.Add(delay)
+
+	resources := operationResources[operation]
+	step := delay / time.Duration(len(resources)+1)
+	event := func(at time.Time, id string, resourceType string, status string) {
+		a.Events = append(a.Events, &objects.StackEvent{
+			EventID:           s.nextID("event-"),
+			Timestamp:         at,
+			LogicalResourceID: id,
+			ResourceType:      resourceType,
+			ResourceStatus:    status,
+		})
+	}
+	event(t, a.Name, "AWS::CloudFormation::Stack", operation+"_IN_PROGRESS")
+	for i, resource := range resources {
+		event(t.Add(step*time.Duration(i)), resource[0], resource[1], operation+"_IN_PROGRESS")
+		event(t.Add(step*time.Duration(i+1)), resource[0], resource[1], operation+"_COMPLETE")
+	}
+	event(a.OperationEndsAt, a.Name, "AWS::CloudFormation::Stack", operation+"_COMPLETE")
+
+	a.log(s, "", log.DeployerProcess, t, fmt.Sprintf("%s %s started", a.Name, api.StackOperation(operation+"_IN_PROGRESS")))
+	a.log(s, "", log.DeployerProcess, a.OperationEndsAt, fmt.Sprintf("%s %s completed", a.Name, api.StackOperation(operation+"_IN_PROGRESS")))
+}
+
+// log appends the Herogate log. The prefix of the ID is used to find logs of a build.
+func (a *app) log(s *state, prefix string, process string, t time.Time, message string) {
+	a.Logs = append(a.Logs, &log.Log{
+		ID:        s.nextID(prefix + "log-"),
+		Timestamp: t,
+		Source:    log.HerogateSource,
+		Process:   process,
+		Message:   message,
+	})
+}
+
+// currentRelease returns the release running at the time. If there are no releases, returns nil.
+func (a *app) currentRelease(t time.Time) *release {
+	var current *release
+	for _, r := range a.Releases {
+		if !r.CreatedAt.After(t) && (current == nil || !r.CreatedAt.Before(current.CreatedAt)) {
+			current = r
+		}
+	}
+	return current
+}
+
+// processes returns the commands of processes running at the time.
+func (a *app) processes(t time.Time) map[string][]string {
+	if current := a.currentRelease(t); current != nil {
+		return current.Processes
+	}
+	return a.Processes
+}
+
+// findApp returns the app which is not deleted. If it is not found, returns the same error as CloudFormation.
+func (s *state) findApp(name string, t time.Time) (*app, error) {
+	a, ok := s.Apps[name]
+	if !ok || a.deleted(t) {
+		return nil, fmt.Errorf("ValidationError: Stack with id %s does not exist", name)
+	}
+	return a, nil
+}
+
+// view runs the function with the current state without saving it.
+func (c *Client) view(f func(s *state) error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return f(c.load())
+}
+
+// transaction runs the function with the current state, and saves the state if the function succeeds.
+// The state file is locked while the transaction runs, so that other commands don't lose its changes.
+func (c *Client) transaction(f func(s *state) error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	unlock := c.lock()
+	defer unlock()
+
+	s := c.load()
+	if err := f(s); err != nil {
+		return err
+	}
+	c.save(s)
+	return nil
+}
+
+// update starts an update of the app, and waits until it completes.
+// The function applies changes to the app, and returns false if nothing changed. Then the update is skipped.
+// If the app is being changed by another operation, returns StackBusyError.
+func (c *Client) update(appName string, f func(a *app) (bool, error)) error {
+	var endsAt time.Time
+	err := c.transaction(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		if status := a.status(t); api.StackInProgress(status) {
+			return &api.StackBusyError{AppName: appName, Status: status}
+		}
+		changed, err := f(a)
+		if err != nil || !changed {
+			return err
+		}
+		a.start(s, "UPDATE", t, c.delay)
+		endsAt = a.OperationEndsAt
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.wait(endsAt)
+	return nil
+}
+
+// wait sleeps until the time.
+func (c *Client) wait(t time.Time) {
+	if d := t.Sub(now()); d > 0 {
+		sleep(d)
+	}
+}
+
+// lock takes the lock of the state file, and returns the function to release it.
+// The lock file is separated from the state file because the state file is replaced when saving.
+func (c *Client) lock() func() {
+	if c.statePath == "" {
+		return func() {}
+	}
+
+	path := c.statePath + ".lock"
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"path": path,
+		}).Fatal("Failed to open the lock file: " + err.Error())
+	}
+	if err = lockFile(file); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"path": path,
+		}).Fatal("Failed to lock the fake state: " + err.Error())
+	}
+
+	return func() {
+		if err := unlockFile(file); err != nil {
+			logrus.Debug("Failed to unlock the fake state: " + err.Error())
+		}
+		file.Close()
+	}
+}
+
+// load reads the state from the file. If the file doesn't exist, it returns an empty state.
+func (c *Client) load() *state {
+	if c.statePath == "" {
+		return c.memory
+	}
+
+	s := newState()
+	data, err := ioutil.ReadFile(c.statePath)
+	if os.IsNotExist(err) {
+		return s
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"path": c.statePath,
+		}).Fatal("Failed to read the fake state: " + err.Error())
+	}
+	if err = json.Unmarshal(data, s); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"path": c.statePath,
+		}).Fatal("Failed to parse the fake state: " + err.Error())
+	}
+	return s
+}
+
+// save writes the state to the file. Deleted apps are removed.
+// The file is replaced atomically so that other commands never read a partial state.
+func (c *Client) save(s *state) {
+	t := now()
+	for name, a := range s.Apps {
+		if a.deleted(t) {
+			delete(s.Apps, name)
+		}
+	}
+	if c.statePath == "" {
+		c.memory = s
+		return
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		logrus.Fatal("Failed to encode the fake state: " + err.Error())
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(c.statePath), filepath.Base(c.statePath))
+	if err == nil {
+		_, err = tmp.Write(data)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.statePath)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"path": c.statePath,
+		}).Fatal("Failed to write the fake state: " + err.Error())
+	}
+}
@@ -0,0 +1,454 @@
+package fake
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/api/options"
+	"github.com/wata727/herogate/log"
+)
+
+// stubClock replaces the clock of the fake backend. Sleeping advances the clock without waiting.
+func stubClock() (*time.Time, func()) {
+	clock := time.Date(2018, time.February, 2, 11, 0, 9, 0, time.UTC)
+	now = func() time.Time { return clock }
+	sleep = func(d time.Duration) { clock = clock.Add(d) }
+	return &clock, func() {
+		now = time.Now
+		sleep = time.Sleep
+	}
+}
+
+func TestClient_lifecycle(t *testing.T) {
+	_, restore := stubClock()
+	defer restore()
+
+	client := NewClient(&ClientOption{Delay: 10 * time.Second})
+	app, err := client.CreateApp("young-eyrie-24091", &options.CreateApp{EnvVars: map[string]string{"RAILS_ENV": "production"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if app.Status != "CREATE_COMPLETE" {
+		t.Fatalf("Expected status is `CREATE_COMPLETE`, but get `%s`", app.Status)
+	}
+	if progress := client.GetAppCreationProgress("young-eyrie-24091"); progress != 100 {
+		t.Fatalf("Expected progress is 100, but get %d", progress)
+	}
+
+	if err = client.SetEnvVars("young-eyrie-24091", map[string]string{"SECRET_KEY_BASE": "secret"}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if err = client.SetPort("young-eyrie-24091", 3000); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	envVars, err := client.DescribeEnvVars("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	expected := map[string]string{"RAILS_ENV": "production", "SECRET_KEY_BASE": "secret"}
+	if !cmp.Equal(envVars, expected) {
+		t.Fatalf("Unexpected config vars: Diff=%s", cmp.Diff(envVars, expected))
+	}
+	if port, _ := client.GetPort("young-eyrie-24091"); port != 3000 {
+		t.Fatalf("Expected port is 3000, but get %d", port)
+	}
+
+	logs, err := client.DescribeLogs("young-eyrie-24091", &options.DescribeLogs{Process: log.DeployerProcess})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	messages := []string{}
+	for _, l := range logs {
+		messages = append(messages, l.Message)
+	}
+	expectedMessages := []string{
+		"young-eyrie-24091 create started",
+		"young-eyrie-24091 create completed",
+		"young-eyrie-24091 update started",
+		"young-eyrie-24091 update completed",
+		"young-eyrie-24091 update started",
+		"young-eyrie-24091 update completed",
+	}
+	if !cmp.Equal(messages, expectedMessages) {
+		t.Fatalf("Unexpected logs: Diff=%s", cmp.Diff(messages, expectedMessages))
+	}
+
+	if err = client.DestroyApp("young-eyrie-24091"); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if client.StackExists("young-eyrie-24091") {
+		t.Fatal("Expected the app is deleted, but it exists")
+	}
+	if progress := client.GetAppDeletionProgress("young-eyrie-24091"); progress != 100 {
+		t.Fatalf("Expected progress is 100, but get %d", progress)
+	}
+	if apps := client.ListApps(); len(apps) != 0 {
+		t.Fatalf("Expected apps are empty, but get %d apps", len(apps))
+	}
+}
+
+func TestClient_DestroyApp__reviewApps(t *testing.T) {
+	_, restore := stubClock()
+	defer restore()
+
+	client := NewClient(&ClientOption{})
+	if _, err := client.CreateApp("young-eyrie-24091", &options.CreateApp{}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if _, err := client.CreateApp("young-eyrie-24091-pr-feature", &options.CreateApp{Parent: "young-eyrie-24091"}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	err := client.DestroyApp("young-eyrie-24091")
+	expected := &api.ReviewAppsExistError{
+		AppName:    "young-eyrie-24091",
+		ReviewApps: []string{"young-eyrie-24091-pr-feature"},
+	}
+	if !cmp.Equal(err, expected) {
+		t.Fatalf("Expected error is `%#v`, but get `%#v`", expected, err)
+	}
+
+	if err = client.DestroyApp("young-eyrie-24091-pr-feature"); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if err = client.DestroyApp("young-eyrie-24091"); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+}
+
+func TestClient_busy(t *testing.T) {
+	clock, restore := stubClock()
+	defer restore()
+
+	client := NewClient(&ClientOption{Delay: 10 * time.Second})
+	if _, err := client.CreateApp("young-eyrie-24091", &options.CreateApp{}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	// Start an update without waiting for it
+	client.transaction(func(s *state) error {
+		s.Apps["young-eyrie-24091"].start(s, "UPDATE", *clock, 10*time.Second)
+		return nil
+	})
+
+	*clock = clock.Add(5 * time.Second)
+	if progress := client.GetAppCreationProgress("young-eyrie-24091"); progress != 100 {
+		t.Fatalf("Expected progress is 100, but get %d", progress)
+	}
+	err := client.SetEnvVars("young-eyrie-24091", map[string]string{"RAILS_ENV": "production"})
+	expected := &api.StackBusyError{AppName: "young-eyrie-24091", Status: "UPDATE_IN_PROGRESS"}
+	if !cmp.Equal(err, expected) {
+		t.Fatalf("Expected error is `%s`, but get `%v`", expected, err)
+	}
+
+	*clock = clock.Add(5 * time.Second)
+	if err = client.SetEnvVars("young-eyrie-24091", map[string]string{"RAILS_ENV": "production"}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+}
+
+func TestClient_DeploySource(t *testing.T) {
+	clock, restore := stubClock()
+	defer restore()
+
+	client := NewClient(&ClientOption{Delay: 8 * time.Second})
+	if _, err := client.CreateApp("young-eyrie-24091", &options.CreateApp{}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	file, err := w.Create("Procfile")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	file.Write([]byte("web: bundle exec rails server\nworker: bundle exec sidekiq\ntest: bundle exec rake test\n"))
+	w.Close()
+
+	if _, err = client.DeploySource("young-eyrie-24091", bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	cases := []struct {
+		Name      string
+		Elapsed   time.Duration
+		Build     string
+		TestRuns  int
+		Processes []string
+		Logs      int
+		Releases  int
+	}{
+		{
+			Name:      "building",
+			Elapsed:   0,
+			Build:     "IN_PROGRESS",
+			TestRuns:  0,
+			Processes: []string{"web"},
+			Logs:      1,
+			Releases:  0,
+		},
+		{
+			Name:      "testing",
+			Elapsed:   2 * time.Second,
+			Build:     "SUCCEEDED",
+			TestRuns:  1,
+			Processes: []string{"web"},
+			Logs:      3,
+			Releases:  0,
+		},
+		{
+			Name:      "deployed",
+			Elapsed:   6 * time.Second,
+			Build:     "SUCCEEDED",
+			TestRuns:  1,
+			Processes: []string{"web", "worker"},
+			Logs:      6,
+			Releases:  1,
+		},
+	}
+
+	start := *clock
+	for _, tc := range cases {
+		*clock = start.Add(tc.Elapsed)
+
+		builds, err := client.DescribeBuilds("young-eyrie-24091")
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if len(builds) != 1 || builds[0].Status != tc.Build {
+			t.Fatalf("Expected build is `%s`, but get `%#v` in %s", tc.Build, builds, tc.Name)
+		}
+		runs, err := client.DescribeTestRuns("young-eyrie-24091")
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if len(runs) != tc.TestRuns {
+			t.Fatalf("Expected test runs are %d, but get %d in %s", tc.TestRuns, len(runs), tc.Name)
+		}
+		info, err := client.GetAppInfo("young-eyrie-24091")
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		processes := []string{}
+		for _, container := range info.Containers {
+			processes = append(processes, container.Name)
+		}
+		if !cmp.Equal(processes, tc.Processes) {
+			t.Fatalf("Unexpected processes: Diff=%s in %s", cmp.Diff(processes, tc.Processes), tc.Name)
+		}
+		logs, err := client.DescribeLogs("young-eyrie-24091", &options.DescribeLogs{Source: log.HerogateSource})
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		// Logs of the creation are included
+		if len(logs)-2 != tc.Logs {
+			t.Fatalf("Expected logs are %d, but get %d in %s", tc.Logs, len(logs)-2, tc.Name)
+		}
+		releases, err := client.DescribeReleases("young-eyrie-24091")
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if len(releases) != tc.Releases {
+			t.Fatalf("Expected releases are %d, but get %d in %s", tc.Releases, len(releases), tc.Name)
+		}
+	}
+}
+
+func TestClient_UseS3Source(t *testing.T) {
+	_, restore := stubClock()
+	defer restore()
+
+	client := NewClient(&ClientOption{})
+	if _, err := client.CreateApp("young-eyrie-24091", &options.CreateApp{}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if _, err := client.UseS3Source("young-eyrie-24091"); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	if _, err := client.GetBranch("young-eyrie-24091"); err != api.ErrS3Source {
+		t.Fatalf("Expected error is `%s`, but get `%v`", api.ErrS3Source, err)
+	}
+	if err := client.SetBranch("young-eyrie-24091", "main"); err != api.ErrS3Source {
+		t.Fatalf("Expected error is `%s`, but get `%v`", api.ErrS3Source, err)
+	}
+	info, err := client.GetAppInfo("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if info.Branch != "" {
+		t.Fatalf("Expected branch is empty, but get `%s`", info.Branch)
+	}
+}
+
+func TestClient_approval(t *testing.T) {
+	clock, restore := stubClock()
+	defer restore()
+
+	client := NewClient(&ClientOption{Delay: 8 * time.Second})
+	if _, err := client.CreateApp("young-eyrie-24091", &options.CreateApp{}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if err := client.SetApproval("young-eyrie-24091", true); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if _, err := client.ResolveApproval("young-eyrie-24091", true, ""); err != api.ErrNoPendingApproval {
+		t.Fatalf("Expected error is `%s`, but get `%v`", api.ErrNoPendingApproval, err)
+	}
+
+	if _, err := client.DeploySource("young-eyrie-24091", bytes.NewReader([]byte("source"))); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	*clock = clock.Add(2 * time.Second)
+	approvals, err := client.DescribeApprovals("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(approvals) != 1 {
+		t.Fatalf("Expected approvals are 1, but get %d", len(approvals))
+	}
+
+	approval, err := client.ResolveApproval("young-eyrie-24091", false, "Not ready")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if !cmp.Equal(approval, approvals[0]) {
+		t.Fatalf("Unexpected approval: Diff=%s", cmp.Diff(approval, approvals[0]))
+	}
+	if approvals, _ = client.DescribeApprovals("young-eyrie-24091"); len(approvals) != 0 {
+		t.Fatalf("Expected approvals are empty, but get %d", len(approvals))
+	}
+}
+
+func TestClient_CancelBuild(t *testing.T) {
+	clock, restore := stubClock()
+	defer restore()
+
+	client := NewClient(&ClientOption{Delay: 8 * time.Second})
+	if _, err := client.CreateApp("young-eyrie-24091", &options.CreateApp{}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if _, err := client.DeploySource("young-eyrie-24091", bytes.NewReader([]byte("source"))); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	builds, err := client.DescribeBuilds("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	if _, err = client.CancelBuild("young-eyrie-24091", "0000"); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if _, err = client.CancelBuild("young-eyrie-24091", builds[0].ID); err != api.ErrBuildNotRunning {
+		t.Fatalf("Expected error is `%s`, but get `%v`", api.ErrBuildNotRunning, err)
+	}
+	if _, err = client.GetBuild("young-eyrie-24091", "unknown"); err != api.ErrBuildNotFound {
+		t.Fatalf("Expected error is `%s`, but get `%v`", api.ErrBuildNotFound, err)
+	}
+
+	*clock = clock.Add(8 * time.Second)
+	build, err := client.GetBuild("young-eyrie-24091", builds[0].ID)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if build.Status != "STOPPED" {
+		t.Fatalf("Expected status is `STOPPED`, but get `%s`", build.Status)
+	}
+	logs, err := client.DescribeLogs("young-eyrie-24091", &options.DescribeLogs{Process: log.DeployerProcess})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	for _, l := range logs {
+		if l.Message != "young-eyrie-24091 create started" && l.Message != "young-eyrie-24091 create completed" {
+			t.Fatalf("Expected the deployment is cancelled, but get `%s`", l.Message)
+		}
+	}
+}
+
+func TestClient_statePath(t *testing.T) {
+	_, restore := stubClock()
+	defer restore()
+
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state.json")
+
+	client := NewClient(&ClientOption{StatePath: path, Delay: time.Second})
+	if _, err = client.CreateApp("young-eyrie-24091", &options.CreateApp{}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if _, err = client.ReleaseImage("young-eyrie-24091", "nginx:latest", map[string][]string{"web": {"nginx"}}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	// Another command reads the state from the file
+	another := NewClient(&ClientOption{StatePath: path, Delay: time.Second})
+	releases, err := another.DescribeReleases("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	expected := []*objects.Release{
+		{
+			Version:     1,
+			Description: "Deploy nginx:latest",
+			Image:       "nginx:latest",
+			CreatedAt:   time.Date(2018, time.February, 2, 11, 0, 10, 0, time.UTC),
+		},
+	}
+	if !cmp.Equal(releases, expected) {
+		t.Fatalf("Unexpected releases: Diff=%s", cmp.Diff(releases, expected))
+	}
+
+	if _, err = another.PromoteApp("young-eyrie-24091", "unknown"); err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+}
+
+func TestClient_transaction__lock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state.json")
+
+	// Clients don't share the mutex like separate commands, so only the file lock serializes them
+	clients := []*Client{
+		NewClient(&ClientOption{StatePath: path}),
+		NewClient(&ClientOption{StatePath: path}),
+	}
+	var wg sync.WaitGroup
+	for _, client := range clients {
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(c *Client) {
+				defer wg.Done()
+				c.transaction(func(s *state) error {
+					s.nextID("")
+					return nil
+				})
+			}(client)
+		}
+	}
+	wg.Wait()
+
+	var sequence int
+	clients[0].view(func(s *state) error {
+		sequence = s.Sequence
+		return nil
+	})
+	if sequence != 40 {
+		t.Fatalf("Expected sequence is 40, but get %d", sequence)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+// +build !windows
+
+package fake
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes the exclusive advisory lock of the file. It blocks until other processes release it.
+func lockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+}
+
+// unlockFile releases the lock of the file.
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
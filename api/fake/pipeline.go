@@ -0,0 +1,464 @@
+package fake
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hecticjeff/procfile"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/api/options"
+	"github.com/wata727/herogate/log"
+)
+
+// maxBuilds is the number of builds returned by DescribeBuilds.
+const maxBuilds = 10
+
+// maxTestRuns is the number of test runs returned by DescribeTestRuns.
+const maxTestRuns = 10
+
+// testProcess is the process which the tester runs instead of deploying it.
+const testProcess = "test"
+
+// UseS3Source changes the source of the pipeline to S3.
+// It returns whether or not the source is changed.
+func (c *Client) UseS3Source(appName string) (bool, error) {
+	current, err := c.GetApp(appName)
+	if err != nil {
+		return false, err
+	}
+	if api.ComparePlatformVersions(current.PlatformVersion, api.S3SourcePlatformVersion) < 0 {
+		return false, fmt.Errorf("S3 source requires the platform version %s or later", api.S3SourcePlatformVersion)
+	}
+
+	changed := false
+	err = c.update(appName, func(a *app) (bool, error) {
+		changed = !a.S3Source
+		a.S3Source = true
+		return changed, nil
+	})
+	return changed, err
+}
+
+// DeploySource starts the pipeline with the source archive, and returns the pipeline execution ID.
+// The pipeline builds, tests and deploys the source over the delay, but the image is never built actually.
+// Processes are read from Procfile in the archive. If it is not found, the processes currently deployed are used.
+func (c *Client) DeploySource(appName string, archive io.ReadSeeker) (string, error) {
+	data, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return "", err
+	}
+	processes, test := sourceProcesses(data)
+	revision := fmt.Sprintf("%x", sha1.Sum(data))
+	tag := revision[:8]
+	image := registryURI(appName) + ":" + tag
+
+	execution := ""
+	err = c.transaction(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		if processes == nil {
+			processes = a.processes(t)
+		}
+
+		execution = s.nextID("execution-")
+		prefix := execution + "-"
+		step := c.delay / 4
+
+		a.Builds = append(a.Builds, &build{
+			ID:        appName + ":" + s.nextID(""),
+			Execution: execution,
+			Status:    "SUCCEEDED",
+			Revision:  revision,
+			ImageTag:  tag,
+			StartTime: t,
+			EndTime:   t.Add(step),
+		})
+		a.log(s, prefix, log.BuilderProcess, t, "Building "+image)
+		a.log(s, prefix, log.BuilderProcess, t.Add(step), "Successfully built "+image)
+		t = t.Add(step)
+
+		if test != nil {
+			a.TestRuns = append(a.TestRuns, &build{
+				ID:        appName + "-tester:" + s.nextID(""),
+				Execution: execution,
+				Status:    "SUCCEEDED",
+				Revision:  revision,
+				ImageTag:  tag,
+				StartTime: t,
+				EndTime:   t.Add(step),
+			})
+			a.log(s, prefix, log.TesterProcess, t, fmt.Sprintf("Running `%s`", strings.Join(test, " ")))
+			a.log(s, prefix, log.TesterProcess, t.Add(step), "Tests are not run by the fake backend")
+			t = t.Add(step)
+		}
+
+		if a.Approval {
+			a.Approvals = append(a.Approvals, &approval{
+				Approval: objects.Approval{
+					ExecutionID: execution,
+					Revision:    revision,
+					ImageTag:    tag,
+					Token:       s.nextID("token-"),
+					RequestedAt: t,
+				},
+				Processes: processes,
+			})
+			a.log(s, prefix, log.DeployerProcess, t, "Waiting for approval of "+image)
+			return nil
+		}
+
+		a.deploy(s, execution, image, processes, t, step)
+		return nil
+	})
+	return execution, err
+}
+
+// deploy schedules the deployment of the image by the pipeline.
+// Like the real backend, it is recorded in the release history when it takes effect.
+func (a *app) deploy(s *state, execution string, image string, processes map[string][]string, t time.Time, step time.Duration) {
+	a.Releases = append(a.Releases, &release{
+		Release: objects.Release{
+			Version:     a.nextReleaseVersion(),
+			Description: "Deploy " + image,
+			Image:       image,
+			CreatedAt:   t.Add(step),
+		},
+		Processes: processes,
+		Execution: execution,
+	})
+	a.log(s, execution+"-", log.DeployerProcess, t, "Deploying "+image)
+	a.log(s, execution+"-", log.DeployerProcess, t.Add(step), "Successfully deployed "+image)
+}
+
+// sourceProcesses returns processes and the test command in Procfile of the archive.
+// If Procfile is not found, returns nil.
+func sourceProcesses(data []byte) (map[string][]string, []string) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil
+	}
+
+	for _, file := range r.File {
+		if file.Name != "Procfile" {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, nil
+		}
+		content, err := ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, nil
+		}
+
+		processes := map[string][]string{}
+		var test []string
+		for name, process := range procfile.Parse(string(content)) {
+			command := append([]string{process.Command}, process.Arguments...)
+			if name == testProcess {
+				test = command
+				continue
+			}
+			processes[name] = command
+		}
+		return processes, test
+	}
+	return nil, nil
+}
+
+// object returns the build at the time. Until EndTime, it is in progress.
+func (b *build) object(t time.Time) *objects.Build {
+	if t.Before(b.EndTime) {
+		return &objects.Build{
+			ID:        b.ID,
+			Status:    "IN_PROGRESS",
+			Phase:     "BUILD",
+			Revision:  b.Revision,
+			ImageTag:  b.ImageTag,
+			StartTime: b.StartTime,
+		}
+	}
+	return &objects.Build{
+		ID:        b.ID,
+		Status:    b.Status,
+		Phase:     "COMPLETED",
+		Revision:  b.Revision,
+		ImageTag:  b.ImageTag,
+		StartTime: b.StartTime,
+		EndTime:   b.EndTime,
+	}
+}
+
+// matches returns whether or not the ID is the build ID or a prefix of the part after the project name.
+func (b *build) matches(id string) bool {
+	return b.ID == id || strings.HasPrefix(b.ID[strings.LastIndex(b.ID, ":")+1:], id)
+}
+
+// DescribeBuilds returns recent builds of the app in the order of newest first.
+func (c *Client) DescribeBuilds(appName string) ([]*objects.Build, error) {
+	builds := []*objects.Build{}
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		for i := len(a.Builds) - 1; i >= 0 && len(builds) < maxBuilds; i-- {
+			if !a.Builds[i].StartTime.After(t) {
+				builds = append(builds, a.Builds[i].object(t))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return builds, nil
+}
+
+// GetBuild returns the build of the app. The ID can be a prefix of the part after the project name.
+func (c *Client) GetBuild(appName string, id string) (*objects.Build, error) {
+	var result *objects.Build
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		b, err := a.findBuild(id, t)
+		if err != nil {
+			return err
+		}
+		result = b.object(t)
+		return nil
+	})
+	return result, err
+}
+
+// DescribeBuildOutput returns the log of the build until the time.
+func (c *Client) DescribeBuildOutput(appName string, id string) ([]*log.Log, error) {
+	logs := []*log.Log{}
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		b, err := a.findBuild(id, t)
+		if err != nil {
+			return err
+		}
+		for _, l := range a.Logs {
+			if l.Process == log.BuilderProcess && strings.HasPrefix(l.ID, b.Execution+"-") && !l.Timestamp.After(t) {
+				logs = append(logs, l)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].Timestamp.Before(logs[j].Timestamp)
+	})
+	return logs, nil
+}
+
+// CancelBuild stops the running build, and returns it. Later stages of the pipeline execution are cancelled too.
+// If the build has already finished, returns ErrBuildNotRunning.
+func (c *Client) CancelBuild(appName string, id string) (*objects.Build, error) {
+	var result *objects.Build
+	err := c.transaction(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		b, err := a.findBuild(id, t)
+		if err != nil {
+			return err
+		}
+		if !t.Before(b.EndTime) {
+			return api.ErrBuildNotRunning
+		}
+		result = b.object(t)
+
+		b.Status = "STOPPED"
+		b.EndTime = t
+		a.cancelExecution(b.Execution, t)
+		a.log(s, b.Execution+"-", log.BuilderProcess, t, "Build stopped")
+		return nil
+	})
+	return result, err
+}
+
+// findBuild returns the build which has started by the time. If it is not found, returns ErrBuildNotFound.
+func (a *app) findBuild(id string, t time.Time) (*build, error) {
+	for i := len(a.Builds) - 1; i >= 0; i-- {
+		if b := a.Builds[i]; !b.StartTime.After(t) && b.matches(id) {
+			return b, nil
+		}
+	}
+	return nil, api.ErrBuildNotFound
+}
+
+// cancelExecution removes test runs, approvals, releases and logs of the execution scheduled after the time.
+func (a *app) cancelExecution(execution string, t time.Time) {
+	testRuns := []*build{}
+	for _, run := range a.TestRuns {
+		if run.Execution != execution || !run.StartTime.After(t) {
+			testRuns = append(testRuns, run)
+		}
+	}
+	a.TestRuns = testRuns
+
+	approvals := []*approval{}
+	for _, approval := range a.Approvals {
+		if approval.ExecutionID != execution {
+			approvals = append(approvals, approval)
+		}
+	}
+	a.Approvals = approvals
+
+	releases := []*release{}
+	for _, r := range a.Releases {
+		if r.Execution != execution || !r.CreatedAt.After(t) {
+			releases = append(releases, r)
+		}
+	}
+	a.Releases = releases
+
+	logs := []*log.Log{}
+	for _, l := range a.Logs {
+		if !strings.HasPrefix(l.ID, execution+"-") || !l.Timestamp.After(t) {
+			logs = append(logs, l)
+		}
+	}
+	a.Logs = logs
+}
+
+// DescribeTestRuns returns recent test runs of the app in the order of newest first.
+func (c *Client) DescribeTestRuns(appName string) ([]*objects.TestRun, error) {
+	runs := []*objects.TestRun{}
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		for i := len(a.TestRuns) - 1; i >= 0 && len(runs) < maxTestRuns; i-- {
+			if !a.TestRuns[i].StartTime.After(t) {
+				b := a.TestRuns[i].object(t)
+				runs = append(runs, &objects.TestRun{ID: b.ID, Status: b.Status, StartTime: b.StartTime, EndTime: b.EndTime})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// DescribeApprovals returns the pending approval of the pipeline. Like CodePipeline, it returns at most one approval.
+func (c *Client) DescribeApprovals(appName string) ([]*objects.Approval, error) {
+	approvals := []*objects.Approval{}
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		if pending := a.pendingApproval(t); pending != nil {
+			approval := pending.Approval
+			approvals = append(approvals, &approval)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return approvals, nil
+}
+
+// ResolveApproval approves or rejects the pending approval, and returns it.
+// When it is approved, the pipeline deploys the image. If no approvals are pending, returns ErrNoPendingApproval.
+func (c *Client) ResolveApproval(appName string, approved bool, summary string) (*objects.Approval, error) {
+	var result *objects.Approval
+	err := c.transaction(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		pending := a.pendingApproval(t)
+		if pending == nil {
+			return api.ErrNoPendingApproval
+		}
+		approval := pending.Approval
+		result = &approval
+
+		a.cancelExecution(pending.ExecutionID, t)
+		if approved {
+			a.deploy(s, pending.ExecutionID, registryURI(appName)+":"+pending.ImageTag, pending.Processes, t, c.delay/4)
+		} else {
+			a.log(s, pending.ExecutionID+"-", log.DeployerProcess, t, "Rejected "+pending.ImageTag+": "+summary)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// pendingApproval returns the latest approval requested by the time. If no approvals are pending, returns nil.
+func (a *app) pendingApproval(t time.Time) *approval {
+	for i := len(a.Approvals) - 1; i >= 0; i-- {
+		if !a.Approvals[i].RequestedAt.After(t) {
+			return a.Approvals[i]
+		}
+	}
+	return nil
+}
+
+// DescribeLogs returns Herogate logs of the app until the time in the order of timestamps.
+// Logs of application containers are not available in the fake backend.
+func (c *Client) DescribeLogs(appName string, options *options.DescribeLogs) ([]*log.Log, error) {
+	logs := []*log.Log{}
+	if options == nil || (options.Source != "" && options.Source != log.HerogateSource) {
+		return logs, nil
+	}
+
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		for _, l := range a.Logs {
+			if (options.Process == "" || options.Process == l.Process) && !l.Timestamp.After(t) {
+				logs = append(logs, l)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []*log.Log{}, err
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].Timestamp.Before(logs[j].Timestamp)
+	})
+	return logs, nil
+}
@@ -0,0 +1,140 @@
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/wata727/herogate/api/objects"
+)
+
+// accountID is the AWS account of fake registries.
+const accountID = "000000000000"
+
+// DescribeReleases returns the release history of the app in the order of newest first.
+func (c *Client) DescribeReleases(appName string) ([]*objects.Release, error) {
+	releases := []*objects.Release{}
+	err := c.view(func(s *state) error {
+		t := now()
+		a, err := s.findApp(appName, t)
+		if err != nil {
+			return err
+		}
+		for _, r := range a.Releases {
+			if !r.CreatedAt.After(t) {
+				release := r.Release
+				releases = append(releases, &release)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Version > releases[j].Version
+	})
+	return releases, nil
+}
+
+// ReleaseImage deploys the image to the app. If processes are empty, the processes currently deployed are used.
+// The deployment is recorded in the release history.
+func (c *Client) ReleaseImage(appName string, image string, processes map[string][]string) (*objects.Release, error) {
+	var result *objects.Release
+	err := c.update(appName, func(a *app) (bool, error) {
+		if len(processes) == 0 {
+			processes = a.processes(now())
+		}
+		result = a.release("Deploy "+image, image, processes, now())
+		return true, nil
+	})
+	return result, err
+}
+
+// PromoteApp deploys the image running in the source app to the target app.
+// Processes are copied from the source, but config vars of the target are kept.
+func (c *Client) PromoteApp(sourceName string, targetName string) (*objects.Release, error) {
+	var image string
+	var processes map[string][]string
+	err := c.view(func(s *state) error {
+		t := now()
+		source, err := s.findApp(sourceName, t)
+		if err != nil {
+			return err
+		}
+		image = source.image(t)
+		processes = source.processes(t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(processes) == 0 {
+		return nil, errors.New("No containers are running in " + sourceName)
+	}
+
+	var result *objects.Release
+	err = c.update(targetName, func(a *app) (bool, error) {
+		result = a.release("Promote from "+sourceName, image, processes, now())
+		return true, nil
+	})
+	return result, err
+}
+
+// release records the release in the history, and returns it.
+func (a *app) release(description string, image string, processes map[string][]string, t time.Time) *objects.Release {
+	r := &release{
+		Release: objects.Release{
+			Version:     a.nextReleaseVersion(),
+			Description: description,
+			Image:       image,
+			CreatedAt:   t,
+		},
+		Processes: processes,
+	}
+	a.Releases = append(a.Releases, r)
+
+	release := r.Release
+	return &release
+}
+
+// nextReleaseVersion returns the version of the next release.
+// Releases scheduled by the pipeline already have their versions, so they are counted as well.
+func (a *app) nextReleaseVersion() int {
+	version := 1
+	for _, r := range a.Releases {
+		if r.Version >= version {
+			version = r.Version + 1
+		}
+	}
+	return version
+}
+
+// image returns the image running at the time.
+func (a *app) image(t time.Time) string {
+	if current := a.currentRelease(t); current != nil {
+		return current.Image
+	}
+	return defaultImage
+}
+
+// registryURI returns the URI of the registry of the app.
+func registryURI(appName string) string {
+	return fmt.Sprintf("%s.dkr.ecr.%s.amazonaws.com/%s", accountID, region, appName)
+}
+
+// GetRegistry returns the registry of the app. Credentials are dummy, so images cannot be pushed to it.
+func (c *Client) GetRegistry(appName string) (*objects.Registry, error) {
+	if _, err := c.GetApp(appName); err != nil {
+		return nil, err
+	}
+
+	return &objects.Registry{
+		URI:      registryURI(appName),
+		Endpoint: fmt.Sprintf("https://%s.dkr.ecr.%s.amazonaws.com", accountID, region),
+		Username: "AWS",
+		Password: "fake",
+	}, nil
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/container"
+)
+
+// healthCheckPath is the path of the health check settings in the template.
+// The settings are applied to the target group and the web process, and kept here to restore them when upgrading.
+const healthCheckPath = "Metadata.HerogateHealthCheck"
+
+const (
+	targetGroupHealthCheckPath              = "Resources.HerogateLoadBalancerTargetGroup.Properties.HealthCheckPath"
+	targetGroupHealthCheckIntervalPath      = "Resources.HerogateLoadBalancerTargetGroup.Properties.HealthCheckIntervalSeconds"
+	targetGroupHealthyThresholdCountPath    = "Resources.HerogateLoadBalancerTargetGroup.Properties.HealthyThresholdCount"
+	defaultHealthCheckPath                  = "/"
+	defaultHealthCheckInterval              = 30
+	defaultHealthCheckHealthyThresholdCount = 5
+)
+
+// GetHealthCheck returns the health check settings of the application.
+// If they are not set, returns the defaults of the load balancer.
+func (c *Client) GetHealthCheck(appName string) (*objects.HealthCheck, error) {
+	if _, err := c.GetApp(appName); err != nil {
+		return nil, err
+	}
+
+	template := c.GetTemplate(appName)
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	return templateHealthCheck(cfg), nil
+}
+
+// SetHealthCheck updates CloudFormation stack with the new health check settings.
+// When the settings did not change, it does not perform updates.
+// When the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) SetHealthCheck(appName string, healthCheck *objects.HealthCheck) error {
+	if err := ValidateHealthCheck(healthCheck); err != nil {
+		return err
+	}
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return err
+	}
+	if err = checkStackIdle(app); err != nil {
+		return err
+	}
+
+	base := c.GetTemplate(appName)
+	template := generateHealthCheckTemplate(base, healthCheck)
+	if base == template {
+		return nil
+	}
+
+	return c.updateStack(appName, template)
+}
+
+// PreviewSetHealthCheck returns resource changes when setting the health check settings.
+// It creates a CloudFormation change set from the generated template and deletes it without executing.
+func (c *Client) PreviewSetHealthCheck(appName string, healthCheck *objects.HealthCheck) ([]*objects.Change, error) {
+	if err := ValidateHealthCheck(healthCheck); err != nil {
+		return nil, err
+	}
+	if _, err := c.GetApp(appName); err != nil {
+		return nil, err
+	}
+
+	base := c.GetTemplate(appName)
+	template := generateHealthCheckTemplate(base, healthCheck)
+	if base == template {
+		return []*objects.Change{}, nil
+	}
+
+	return c.previewStackUpdate(appName, template)
+}
+
+// ValidateHealthCheck returns an error if the settings are out of the range of the load balancer.
+// The timeout of the load balancer is 5 seconds, so the interval must be longer than it.
+func ValidateHealthCheck(healthCheck *objects.HealthCheck) error {
+	if !strings.HasPrefix(healthCheck.Path, "/") {
+		return fmt.Errorf("Invalid path `%s`, must start with /", healthCheck.Path)
+	}
+	if healthCheck.Interval < 6 || healthCheck.Interval > 300 {
+		return fmt.Errorf("Invalid interval %d, must be between 6 and 300 seconds", healthCheck.Interval)
+	}
+	if healthCheck.HealthyThreshold < 2 || healthCheck.HealthyThreshold > 10 {
+		return fmt.Errorf("Invalid healthy threshold %d, must be between 2 and 10", healthCheck.HealthyThreshold)
+	}
+	return nil
+}
+
+// WebHealthCheck returns the health check of the web process in the template.
+// If the command is not set, returns nil.
+func WebHealthCheck(cfg *config.Config) *container.HealthCheck {
+	healthCheck := templateHealthCheck(cfg)
+	if healthCheck.Command == "" {
+		return nil
+	}
+	return container.NewHealthCheck(healthCheck.Command, healthCheck.Interval)
+}
+
+func templateHealthCheck(cfg *config.Config) *objects.HealthCheck {
+	healthCheck := &objects.HealthCheck{
+		Path:             defaultHealthCheckPath,
+		Interval:         defaultHealthCheckInterval,
+		HealthyThreshold: defaultHealthCheckHealthyThresholdCount,
+	}
+	if path, err := cfg.String(healthCheckPath + ".Path"); err == nil && path != "" {
+		healthCheck.Path = path
+	}
+	if interval, err := cfg.Int(healthCheckPath + ".Interval"); err == nil && interval > 0 {
+		healthCheck.Interval = interval
+	}
+	if threshold, err := cfg.Int(healthCheckPath + ".HealthyThreshold"); err == nil && threshold > 0 {
+		healthCheck.HealthyThreshold = threshold
+	}
+	healthCheck.Command, _ = cfg.String(healthCheckPath + ".Command")
+	return healthCheck
+}
+
+func generateHealthCheckTemplate(base string, healthCheck *objects.HealthCheck) string {
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	err = cfg.Set(healthCheckPath, map[string]interface{}{
+		"Path":             healthCheck.Path,
+		"Interval":         healthCheck.Interval,
+		"HealthyThreshold": healthCheck.HealthyThreshold,
+		"Command":          healthCheck.Command,
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"healthCheck": healthCheck,
+			"config":      cfg,
+		}).Fatal("Failed to set health check settings to template" + err.Error())
+	}
+	applyHealthCheck(cfg)
+
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template
+}
+
+// applyHealthCheck applies the health check settings to the target group and the web process.
+// If the settings are not found in the template, it does nothing so that the template keeps the defaults.
+func applyHealthCheck(cfg *config.Config) {
+	if _, err := cfg.Get(healthCheckPath); err != nil {
+		return
+	}
+	healthCheck := templateHealthCheck(cfg)
+
+	settings := map[string]interface{}{
+		targetGroupHealthCheckPath:           healthCheck.Path,
+		targetGroupHealthCheckIntervalPath:   healthCheck.Interval,
+		targetGroupHealthyThresholdCountPath: healthCheck.HealthyThreshold,
+	}
+	for path, value := range settings {
+		if err := cfg.Set(path, value); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"path":   path,
+				"value":  value,
+				"config": cfg,
+			}).Fatal("Failed to set health check to template" + err.Error())
+		}
+	}
+
+	definitions, err := cfg.List(containerDefinitions)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg,
+		}).Debug("Failed to get container definitions" + err.Error())
+		return
+	}
+	for _, d := range definitions {
+		definition, ok := d.(map[string]interface{})
+		if !ok || definition["Name"] != "web" {
+			continue
+		}
+		if webHealthCheck := WebHealthCheck(cfg); webHealthCheck != nil {
+			definition["HealthCheck"] = webHealthCheck
+		} else {
+			delete(definition, "HealthCheck")
+		}
+	}
+}
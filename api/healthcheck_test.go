@@ -0,0 +1,131 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/wata727/herogate/api/objects"
+)
+
+func TestGenerateHealthCheckTemplate(t *testing.T) {
+	base := `Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Image: myapp:0.1
+        Name: web
+      - Image: myapp:0.1
+        Name: worker
+  HerogateLoadBalancerTargetGroup:
+    Properties:
+      Port: 80
+`
+
+	template := generateHealthCheckTemplate(base, &objects.HealthCheck{
+		Path:             "/healthz",
+		Interval:         15,
+		HealthyThreshold: 2,
+		Command:          "curl -f http://localhost:$PORT/healthz",
+	})
+	expected := `Metadata:
+  HerogateHealthCheck:
+    Command: curl -f http://localhost:$PORT/healthz
+    HealthyThreshold: 2
+    Interval: 15
+    Path: /healthz
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - HealthCheck:
+          Command:
+          - CMD-SHELL
+          - curl -f http://localhost:$PORT/healthz
+          Interval: 15
+        Image: myapp:0.1
+        Name: web
+      - Image: myapp:0.1
+        Name: worker
+  HerogateLoadBalancerTargetGroup:
+    Properties:
+      HealthCheckIntervalSeconds: 15
+      HealthCheckPath: /healthz
+      HealthyThresholdCount: 2
+      Port: 80
+`
+	if template != expected {
+		t.Fatalf("Expected template is `%s`, but get `%s`", expected, template)
+	}
+
+	// Removing the command removes the health check of the web process
+	template = generateHealthCheckTemplate(template, &objects.HealthCheck{
+		Path:             "/healthz",
+		Interval:         15,
+		HealthyThreshold: 2,
+	})
+	expected = `Metadata:
+  HerogateHealthCheck:
+    Command: ""
+    HealthyThreshold: 2
+    Interval: 15
+    Path: /healthz
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Image: myapp:0.1
+        Name: web
+      - Image: myapp:0.1
+        Name: worker
+  HerogateLoadBalancerTargetGroup:
+    Properties:
+      HealthCheckIntervalSeconds: 15
+      HealthCheckPath: /healthz
+      HealthyThresholdCount: 2
+      Port: 80
+`
+	if template != expected {
+		t.Fatalf("Expected template is `%s`, but get `%s`", expected, template)
+	}
+}
+
+func TestValidateHealthCheck(t *testing.T) {
+	cases := []struct {
+		Name        string
+		HealthCheck *objects.HealthCheck
+		Expected    string
+	}{
+		{
+			Name:        "valid",
+			HealthCheck: &objects.HealthCheck{Path: "/healthz", Interval: 15, HealthyThreshold: 2},
+			Expected:    "",
+		},
+		{
+			Name:        "invalid path",
+			HealthCheck: &objects.HealthCheck{Path: "healthz", Interval: 15, HealthyThreshold: 2},
+			Expected:    "Invalid path `healthz`, must start with /",
+		},
+		{
+			Name:        "invalid interval",
+			HealthCheck: &objects.HealthCheck{Path: "/", Interval: 5, HealthyThreshold: 2},
+			Expected:    "Invalid interval 5, must be between 6 and 300 seconds",
+		},
+		{
+			Name:        "invalid healthy threshold",
+			HealthCheck: &objects.HealthCheck{Path: "/", Interval: 30, HealthyThreshold: 1},
+			Expected:    "Invalid healthy threshold 1, must be between 2 and 10",
+		},
+	}
+
+	for _, tc := range cases {
+		err := ValidateHealthCheck(tc.HealthCheck)
+		if tc.Expected == "" {
+			if err != nil {
+				t.Fatalf("Expected error is nil, but get `%s` in %s", err.Error(), tc.Name)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.Expected {
+			t.Fatalf("Expected error is `%s`, but get `%v` in %s", tc.Expected, err, tc.Name)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package iface
 
 import (
+	"io"
+
 	"github.com/wata727/herogate/api/objects"
 	"github.com/wata727/herogate/api/options"
 	"github.com/wata727/herogate/log"
@@ -9,16 +11,49 @@ import (
 // ClientInterface is the API client's interface.
 type ClientInterface interface {
 	ListApps() []*objects.App
-	CreateApp(appName string) *objects.App
+	CreateApp(appName string, options *options.CreateApp) (*objects.App, error)
 	GetAppCreationProgress(appName string) int
 	DescribeLogs(appName string, options *options.DescribeLogs) ([]*log.Log, error)
 	GetApp(appName string) (*objects.App, error)
 	GetTemplate(appName string) string
 	DestroyApp(appName string) error
+	RepairApp(appName string) (*objects.Repair, error)
+	UpgradeApp(appName string) error
+	GetBranch(appName string) (string, error)
+	SetBranch(appName string, branch string) error
+	SetPipeline(appName string, pipeline string, stage string) error
+	PromoteApp(sourceName string, targetName string) (*objects.Release, error)
+	DescribeReleases(appName string) ([]*objects.Release, error)
+	ReleaseImage(appName string, image string, processes map[string][]string) (*objects.Release, error)
+	GetRegistry(appName string) (*objects.Registry, error)
+	UseS3Source(appName string) (bool, error)
+	DeploySource(appName string, archive io.ReadSeeker) (string, error)
+	DescribeTestRuns(appName string) ([]*objects.TestRun, error)
+	GetApproval(appName string) (bool, error)
+	SetApproval(appName string, enabled bool) error
+	DescribeApprovals(appName string) ([]*objects.Approval, error)
+	ResolveApproval(appName string, approved bool, summary string) (*objects.Approval, error)
+	GetBuildConfig(appName string) (*objects.BuildConfig, error)
+	SetBuildConfig(appName string, build *objects.BuildConfig) error
+	PreviewSetBuildConfig(appName string, build *objects.BuildConfig) ([]*objects.Change, error)
+	DescribeBuilds(appName string) ([]*objects.Build, error)
+	GetBuild(appName string, id string) (*objects.Build, error)
+	DescribeBuildOutput(appName string, id string) ([]*log.Log, error)
+	CancelBuild(appName string, id string) (*objects.Build, error)
+	GetPort(appName string) (int, error)
+	SetPort(appName string, port int) error
+	PreviewSetPort(appName string, port int) ([]*objects.Change, error)
+	GetHealthCheck(appName string) (*objects.HealthCheck, error)
+	SetHealthCheck(appName string, healthCheck *objects.HealthCheck) error
+	PreviewSetHealthCheck(appName string, healthCheck *objects.HealthCheck) ([]*objects.Change, error)
+	PreviewUpgradeApp(appName string) ([]*objects.Change, error)
 	GetAppDeletionProgress(appName string) int
 	StackExists(stackName string) bool
 	GetAppInfo(appName string) (*objects.AppInfo, error)
 	DescribeEnvVars(appName string) (map[string]string, error)
 	SetEnvVars(appName string, envVars map[string]string) error
 	UnsetEnvVars(appName string, envList []string) error
+	PreviewSetEnvVars(appName string, envVars map[string]string) ([]*objects.Change, error)
+	PreviewUnsetEnvVars(appName string, envList []string) ([]*objects.Change, error)
+	DescribeAppEvents(appName string) ([]*objects.StackEvent, error)
 }
@@ -34,17 +34,27 @@ func (c *Client) DescribeLogs(appName string, options *options.DescribeLogs) ([]
 			if err != nil {
 				return []*log.Log{}, err
 			}
+			testerLogs, err := c.describeTesterLogs(appName)
+			if err != nil {
+				return []*log.Log{}, err
+			}
 			deployerLogs, err := c.describeDeployerLogs(appName)
 			if err != nil {
 				return []*log.Log{}, err
 			}
-			logs = append(builderLogs, deployerLogs...)
+			logs = append(append(builderLogs, testerLogs...), deployerLogs...)
 		case log.BuilderProcess:
 			builderLogs, err := c.describeBuilderLogs(appName)
 			if err != nil {
 				return []*log.Log{}, err
 			}
 			logs = builderLogs
+		case log.TesterProcess:
+			testerLogs, err := c.describeTesterLogs(appName)
+			if err != nil {
+				return []*log.Log{}, err
+			}
+			logs = testerLogs
 		case log.DeployerProcess:
 			deployerLogs, err := c.describeDeployerLogs(appName)
 			if err != nil {
@@ -63,8 +73,23 @@ func (c *Client) DescribeLogs(appName string, options *options.DescribeLogs) ([]
 }
 
 func (c *Client) describeBuilderLogs(appName string) ([]*log.Log, error) {
+	return c.describeBuildLogs(appName, log.BuilderProcess)
+}
+
+// describeTesterLogs returns logs of the latest test run.
+// Apps created before the platform version 1.2 don't have the tester, so it returns no logs for them.
+func (c *Client) describeTesterLogs(appName string) ([]*log.Log, error) {
+	logs, err := c.describeBuildLogs(testerProjectName(appName), log.TesterProcess)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == codebuild.ErrCodeResourceNotFoundException {
+		return []*log.Log{}, nil
+	}
+	return logs, err
+}
+
+// describeBuildLogs returns logs of the latest build in the CodeBuild project as the process.
+func (c *Client) describeBuildLogs(projectName string, process string) ([]*log.Log, error) {
 	listBuildsForProjectResponse, err := c.codeBuild.ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
-		ProjectName: aws.String(appName),
+		ProjectName: aws.String(projectName),
 	})
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == codebuild.ErrCodeResourceNotFoundException {
@@ -72,7 +97,7 @@ func (c *Client) describeBuilderLogs(appName string) ([]*log.Log, error) {
 		}
 
 		logrus.WithFields(logrus.Fields{
-			"ProjectName": appName,
+			"ProjectName": projectName,
 		}).Fatal("Failed to get the project: " + err.Error())
 	}
 	if len(listBuildsForProjectResponse.Ids) == 0 {
@@ -111,7 +136,7 @@ func (c *Client) describeBuilderLogs(appName string) ([]*log.Log, error) {
 			ID:        fmt.Sprintf("%s-%d-%s", aws.StringValue(buildID), aws.Int64Value(event.Timestamp), aws.StringValue(event.Message)),
 			Timestamp: aws.MillisecondsTimeValue(event.Timestamp).UTC(),
 			Source:    log.HerogateSource,
-			Process:   log.BuilderProcess,
+			Process:   process,
 			Message:   strings.TrimRight(aws.StringValue(event.Message), "\n"),
 		})
 	}
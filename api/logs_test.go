@@ -177,6 +177,67 @@ func TestDescribeLogs__processBuilder(t *testing.T) {
 	}
 }
 
+func TestDescribeLogs__processTester(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	codeBuildMock := mock.NewMockCodeBuildAPI(ctrl)
+	// Mock codebuild.ListBuildsForProject
+	codeBuildMock.EXPECT().ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("TestApp-tester"),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: []*string{aws.String("TestApp-tester:0b1c2d3e-4f5a-6b7c-8d9e-0f1a2b3c4d5e")},
+	}, nil)
+	// Mock codebuild.BatchGetBuilds
+	codeBuildMock.EXPECT().BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: []*string{aws.String("TestApp-tester:0b1c2d3e-4f5a-6b7c-8d9e-0f1a2b3c4d5e")},
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Logs: &codebuild.LogsLocation{
+					GroupName:  aws.String("/aws/codebuild/TestApp-tester"),
+					StreamName: aws.String("0b1c2d3e-4f5a-6b7c-8d9e-0f1a2b3c4d5e"),
+				},
+			},
+		},
+	}, nil)
+	cloudWatchLogsMock := mock.NewMockCloudWatchLogsAPI(ctrl)
+	// Mock cloudwatchlogs.GetLogEvents
+	cloudWatchLogsMock.EXPECT().GetLogEvents(&cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String("/aws/codebuild/TestApp-tester"),
+		LogStreamName: aws.String("0b1c2d3e-4f5a-6b7c-8d9e-0f1a2b3c4d5e"),
+	}).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{
+				Message:   aws.String("1 example, 0 failures\n"),
+				Timestamp: aws.Int64(aws.TimeUnixMilli(time.Date(2018, time.February, 3, 1, 33, 0, 0, time.FixedZone("UTC", 0)))),
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.codeBuild = codeBuildMock
+	client.cloudWatchLogs = cloudWatchLogsMock
+
+	expected := []*log.Log{
+		{
+			ID:        "TestApp-tester:0b1c2d3e-4f5a-6b7c-8d9e-0f1a2b3c4d5e-1517621580000-1 example, 0 failures\n",
+			Timestamp: time.Date(2018, time.February, 3, 1, 33, 0, 0, time.FixedZone("UTC", 0)),
+			Source:    "herogate",
+			Process:   "tester",
+			Message:   "1 example, 0 failures",
+		},
+	}
+
+	logs, err := client.DescribeLogs("TestApp", &options.DescribeLogs{Process: "tester"})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+	if !cmp.Equal(expected, logs) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, logs))
+	}
+}
+
 func TestDescribeLogs__processDeployer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -432,6 +493,11 @@ func mockCodeBuild(ctrl *gomock.Controller) *mock.MockCodeBuildAPI {
 		},
 	}, nil)
 
+	// Mock codebuild.ListBuildsForProject for the app created before the tester
+	codeBuildMock.EXPECT().ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("TestApp-tester"),
+	}).Return(nil, awserr.New(codebuild.ErrCodeResourceNotFoundException, "Not found", errors.New("Not found"))).AnyTimes()
+
 	return codeBuildMock
 }
 
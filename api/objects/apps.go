@@ -1,19 +1,28 @@
 package objects
 
+import "time"
+
 // App is Herogate application object. This is a copy of CloudFormation stack.
+// Parent is the name of the parent application if it is a review app.
+// Pipeline and Stage are set if the application is added to a pipeline.
 type App struct {
 	Name            string
 	Status          string
 	Repository      string
 	Endpoint        string
 	PlatformVersion string
+	Parent          string
+	Pipeline        string
+	Stage           string
 }
 
 // AppInfo is Herogate application info object.
 type AppInfo struct {
 	*App
-	Containers []*Container
-	Region     string
+	Branch      string
+	Containers  []*Container
+	HealthCheck *HealthCheck
+	Region      string
 }
 
 // Container is Herogate application container.
@@ -22,3 +31,103 @@ type Container struct {
 	Count   int64
 	Command []string
 }
+
+// Change is a planned change of Herogate application resources. This is a copy of CloudFormation resource change.
+type Change struct {
+	Action            string
+	LogicalResourceID string
+	ResourceType      string
+	Replacement       string
+}
+
+// StackEvent is an event of Herogate application resources. This is a copy of CloudFormation stack event.
+type StackEvent struct {
+	EventID              string
+	Timestamp            time.Time
+	LogicalResourceID    string
+	ResourceType         string
+	ResourceStatus       string
+	ResourceStatusReason string
+}
+
+// ResourceFailure is a failed Herogate application resource. Hint is advice for known errors.
+type ResourceFailure struct {
+	LogicalResourceID string
+	ResourceType      string
+	ResourceStatus    string
+	Reason            string
+	Hint              string
+}
+
+// Repair is the result of repairing Herogate application. Status is the application status before repairing.
+type Repair struct {
+	Status  string
+	Actions []string
+}
+
+// Release is a deployment of Herogate application. Version is incremented for each release.
+type Release struct {
+	Version     int
+	Description string
+	Image       string
+	CreatedAt   time.Time
+}
+
+// Registry is the Docker registry of Herogate application. This is a copy of ECR repository with credentials.
+type Registry struct {
+	URI      string
+	Endpoint string
+	Username string
+	Password string
+}
+
+// TestRun is a run of the test stage in Herogate application pipeline. This is a copy of CodeBuild build.
+// EndTime is zero if the run is in progress.
+type TestRun struct {
+	ID        string
+	Status    string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Approval is a pending manual approval in Herogate application pipeline.
+// Revision is the source revision such as the commit SHA, and ImageTag is the tag of the built image.
+type Approval struct {
+	ExecutionID string
+	Revision    string
+	Summary     string
+	ImageTag    string
+	Token       string
+	RequestedAt time.Time
+}
+
+// BuildConfig is the build settings of Herogate application.
+// Compute is one of "small", "medium" and "large", and Args are names of config vars passed as build args.
+type BuildConfig struct {
+	Dockerfile string
+	Target     string
+	Compute    string
+	Args       []string
+}
+
+// Build is a build of the builder in Herogate application pipeline. This is a copy of CodeBuild build.
+// Revision is the source revision of the pipeline execution, and EndTime is zero if the build is in progress.
+type Build struct {
+	ID        string
+	Status    string
+	Phase     string
+	Revision  string
+	ImageTag  string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// HealthCheck is the health check settings of the web process in Herogate application.
+// Path, Interval and HealthyThreshold are used by the load balancer, and Interval is in seconds.
+// Command is run in the container if it is not empty.
+type HealthCheck struct {
+	Path             string
+	Interval         int
+	HealthyThreshold int
+	Command          string
+}
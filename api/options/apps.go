@@ -0,0 +1,16 @@
+package options
+
+// CreateApp is the options for CreateApp API.
+// KeepOnFailure is whether or not to keep the stack when the creation is failed.
+// Branch is the branch name deployed by the pipeline. (default: master)
+// Parent is the name of the parent application when creating a review app.
+// EnvVars, DesiredCount, CPU and Memory are applied to the application containers if they are specified.
+type CreateApp struct {
+	KeepOnFailure bool
+	Branch        string
+	Parent        string
+	EnvVars       map[string]string
+	DesiredCount  int64
+	CPU           string
+	Memory        string
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/olebedev/config"
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/container"
+)
+
+// defaultBranch is the branch name written in `assets/platform.yaml`.
+const defaultBranch = "master"
+
+const branchPath = "Resources.HerogatePipeline.Properties.Stages.0.Actions.0.Configuration.BranchName"
+
+// ErrS3Source is returned when the branch of the app whose pipeline deploys the uploaded source is used.
+var ErrS3Source = errors.New("The pipeline deploys the source uploaded by `herogate deploy`, so it has no branch")
+
+// GetBranch returns the branch name deployed by the application pipeline.
+// When the pipeline deploys the uploaded source, returns ErrS3Source.
+func (c *Client) GetBranch(appName string) (string, error) {
+	if _, err := c.GetApp(appName); err != nil {
+		return "", err
+	}
+
+	template := c.GetTemplate(appName)
+	if templateSource(template) == "S3" {
+		return "", ErrS3Source
+	}
+	return templateBranch(template), nil
+}
+
+// SetBranch updates CloudFormation stack with the new source branch of the pipeline.
+// When the branch did not change, it does not perform updates.
+// When the pipeline deploys the uploaded source, returns ErrS3Source.
+// When the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) SetBranch(appName string, branch string) error {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return err
+	}
+
+	base := c.GetTemplate(appName)
+	if templateSource(base) == "S3" {
+		return ErrS3Source
+	}
+	if err = checkStackIdle(app); err != nil {
+		return err
+	}
+	if templateBranch(base) == branch {
+		return nil
+	}
+
+	return c.updateStack(appName, generateUpdatedBranchTemplate(base, branch))
+}
+
+// templateBranch returns the branch of the pipeline source. When the source is S3, it returns an empty string.
+func templateBranch(template string) string {
+	if templateSource(template) == "S3" {
+		return ""
+	}
+
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	branch, err := cfg.String(branchPath)
+	if err != nil {
+		logrus.Debug("Failed to get branch name: " + err.Error())
+		return defaultBranch
+	}
+	return branch
+}
+
+func generateUpdatedBranchTemplate(base string, branch string) string {
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	err = cfg.Set(branchPath, branch)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"branch": branch,
+			"config": cfg,
+		}).Fatal("Failed to set branch name to template" + err.Error())
+	}
+
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template
+}
+
+// SetPipeline adds the application to the pipeline as the stage. The pipeline is saved as stack tags.
+// When the pipeline and the stage did not change, it does not perform updates.
+// When the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) SetPipeline(appName string, pipeline string, stage string) error {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return err
+	}
+	if err = checkStackIdle(app); err != nil {
+		return err
+	}
+	if app.Pipeline == pipeline && app.Stage == stage {
+		return nil
+	}
+
+	return c.updateStack(
+		appName,
+		c.GetTemplate(appName),
+		&cloudformation.Tag{
+			Key:   aws.String("herogate-pipeline"),
+			Value: aws.String(pipeline),
+		},
+		&cloudformation.Tag{
+			Key:   aws.String("herogate-pipeline-stage"),
+			Value: aws.String(stage),
+		},
+	)
+}
+
+// PromoteApp deploys the image running in the source application to the target application without rebuilding.
+// Processes are copied from the source, but environment variables of the target are kept.
+// The promotion is recorded in the release history of the target.
+// When the target stack is being changed by another operation, returns StackBusyError.
+func (c *Client) PromoteApp(sourceName string, targetName string) (*objects.Release, error) {
+	if _, err := c.GetApp(sourceName); err != nil {
+		return nil, err
+	}
+	target, err := c.GetApp(targetName)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkStackIdle(target); err != nil {
+		return nil, err
+	}
+
+	taskDefinition, err := c.describeRunningTaskDefinition(sourceName)
+	if err != nil {
+		return nil, err
+	}
+	image, processes := runningProcesses(taskDefinition)
+	if len(processes) == 0 {
+		return nil, errors.New("No containers are running in " + sourceName)
+	}
+
+	release := &objects.Release{
+		Description: "Promote from " + sourceName,
+		Image:       image,
+		CreatedAt:   time.Now(),
+	}
+	template := generateReleasedTemplate(c.GetTemplate(targetName), image, processes, release)
+	if err = c.updateStack(targetName, template); err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// runningProcesses returns the app image and commands of processes in the task definition.
+// Sidecars are not processes of the app, so the image is taken from the web process or another process.
+func runningProcesses(taskDefinition *ecs.TaskDefinition) (string, map[string][]string) {
+	image := ""
+	processes := map[string][]string{}
+	for _, definition := range taskDefinition.ContainerDefinitions {
+		if aws.StringValue(definition.DockerLabels[container.SidecarLabel]) == "true" {
+			continue
+		}
+		name := aws.StringValue(definition.Name)
+		if image == "" || name == "web" {
+			image = aws.StringValue(definition.Image)
+		}
+		processes[name] = aws.StringValueSlice(definition.Command)
+	}
+	return image, processes
+}
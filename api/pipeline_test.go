@@ -0,0 +1,185 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/wata727/herogate/container"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestSetBranch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to call GetApp and return App
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackStatus: aws.String("CREATE_COMPLETE"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.0"),
+					},
+				},
+			},
+		},
+	}, nil)
+	// Expect to get template
+	cfnMock.EXPECT().GetTemplate(&cloudformation.GetTemplateInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.GetTemplateOutput{
+		TemplateBody: aws.String(`Resources:
+  HerogatePipeline:
+    Properties:
+      Stages:
+      - Actions:
+        - Configuration:
+            BranchName: master
+          Name: ChangeSource
+        Name: Repository
+`),
+	}, nil)
+	// Expect to update stack with the new branch
+	cfnMock.EXPECT().UpdateStack(&cloudformation.UpdateStackInput{
+		StackName: aws.String("young-eyrie-24091"),
+		TemplateBody: aws.String(`Resources:
+  HerogatePipeline:
+    Properties:
+      Stages:
+      - Actions:
+        - Configuration:
+            BranchName: main
+          Name: ChangeSource
+        Name: Repository
+`),
+		Capabilities: []*string{aws.String("CAPABILITY_NAMED_IAM")},
+	}).Return(&cloudformation.UpdateStackOutput{}, nil)
+	// Expect to wait stack update
+	cfnMock.EXPECT().WaitUntilStackUpdateComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	if err := client.SetBranch("young-eyrie-24091", "main"); err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+}
+
+func TestSetBranch__s3Source(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to call GetApp and return App
+	cfnMock.EXPECT().DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackStatus: aws.String("UPDATE_COMPLETE"),
+				Tags: []*cloudformation.Tag{
+					{
+						Key:   aws.String("herogate-platform-version"),
+						Value: aws.String("1.1"),
+					},
+				},
+			},
+		},
+	}, nil).Times(2)
+	// Expect to get template
+	cfnMock.EXPECT().GetTemplate(&cloudformation.GetTemplateInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.GetTemplateOutput{
+		TemplateBody: aws.String(`Resources:
+  HerogatePipeline:
+    Properties:
+      Stages:
+      - Actions:
+        - ActionTypeId:
+            Provider: S3
+          Configuration:
+            S3Bucket:
+              Ref: HerogatePipelineArtifactStore
+          Name: ChangeSource
+        Name: Source
+`),
+	}, nil).Times(2)
+	// Expect not to update stack
+	cfnMock.EXPECT().UpdateStack(gomock.Any()).Times(0)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	if err := client.SetBranch("young-eyrie-24091", "main"); err != ErrS3Source {
+		t.Fatalf("Expected error is `%s`, but get `%v`", ErrS3Source, err)
+	}
+	if _, err := client.GetBranch("young-eyrie-24091"); err != ErrS3Source {
+		t.Fatalf("Expected error is `%s`, but get `%v`", ErrS3Source, err)
+	}
+}
+
+func TestTemplateBranch__default(t *testing.T) {
+	branch := templateBranch("Resources: {}")
+	if branch != "master" {
+		t.Fatalf("Expected branch is `master`, but get `%s`", branch)
+	}
+}
+
+func TestGenerateUpdatedBranchTemplate(t *testing.T) {
+	template := generateUpdatedBranchTemplate(`Resources:
+  HerogatePipeline:
+    Properties:
+      Stages:
+      - Actions:
+        - Configuration:
+            BranchName: master
+`, "release")
+	if !strings.Contains(template, "BranchName: release") {
+		t.Fatalf("Expected branch is `release`, but get `%s`", template)
+	}
+}
+
+func TestRunningProcesses(t *testing.T) {
+	image, processes := runningProcesses(&ecs.TaskDefinition{
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{
+				Name:         aws.String("envoy"),
+				Image:        aws.String("envoyproxy/envoy:v1.6.0"),
+				DockerLabels: map[string]*string{container.SidecarLabel: aws.String("true")},
+			},
+			{
+				Name:    aws.String("web"),
+				Image:   aws.String("staging:2"),
+				Command: aws.StringSlice([]string{"bundle", "exec", "rails", "server"}),
+			},
+			{
+				Name:    aws.String("worker"),
+				Image:   aws.String("staging:2"),
+				Command: aws.StringSlice([]string{"bundle", "exec", "sidekiq"}),
+			},
+		},
+	})
+
+	if image != "staging:2" {
+		t.Fatalf("Expected image is `staging:2`, but get `%s`", image)
+	}
+	expected := map[string][]string{
+		"web":    {"bundle", "exec", "rails", "server"},
+		"worker": {"bundle", "exec", "sidekiq"},
+	}
+	if !cmp.Equal(expected, processes) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, processes))
+	}
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/olebedev/config"
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api/assets"
+	"github.com/wata727/herogate/api/objects"
+)
+
+// PlatformVersion is the version of `assets/platform.yaml` built into this binary.
+// Bump it when changing the template, and add a migration if the existing state moves.
+const PlatformVersion = "1.5"
+
+const (
+	desiredCountPath = "Resources.HerogateApplicationService.Properties.DesiredCount"
+	taskCPUPath      = "Resources.HerogateApplicationContainer.Properties.Cpu"
+	taskMemoryPath   = "Resources.HerogateApplicationContainer.Properties.Memory"
+)
+
+// platformStatePaths are paths of the application state in the template.
+// They are carried over from the current template to the new one when upgrading.
+var platformStatePaths = []string{
+	// Environment variables, images and commands
+	"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions",
+	// Scale and task size
+	desiredCountPath,
+	taskCPUPath,
+	taskMemoryPath,
+	// Port of the web process
+	portPath,
+	// Source of the pipeline (CodeCommit or S3)
+	sourceActionPath,
+	// Deploy branch
+	branchPath,
+	// Review apps track the parent repository
+	repositoryNamePath,
+	"Outputs.Repository",
+	// Release history
+	releasesPath,
+	// Build settings
+	buildConfigPath,
+	// Health check settings
+	healthCheckPath,
+}
+
+// platformMigration moves the application state in the template of the previous version
+// so that it matches the layout of the version.
+type platformMigration struct {
+	version string
+	migrate func(cfg *config.Config) error
+}
+
+// platformMigrations are applied in order when upgrading.
+var platformMigrations = []*platformMigration{}
+
+// UpgradeApp updates the application stack to the latest platform template and the version tag.
+// The application state such as environment variables and container definitions are carried over.
+// When the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) UpgradeApp(appName string) error {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return err
+	}
+	if err = checkStackIdle(app); err != nil {
+		return err
+	}
+
+	template, err := generateUpgradedTemplate(c.GetTemplate(appName), app.PlatformVersion)
+	if err != nil {
+		return err
+	}
+
+	return c.updateStack(appName, template, &cloudformation.Tag{
+		Key:   aws.String("herogate-platform-version"),
+		Value: aws.String(PlatformVersion),
+	})
+}
+
+// PreviewUpgradeApp returns resource changes when upgrading the application to the latest platform version.
+// It creates a CloudFormation change set from the generated template and deletes it without executing.
+func (c *Client) PreviewUpgradeApp(appName string) ([]*objects.Change, error) {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := generateUpgradedTemplate(c.GetTemplate(appName), app.PlatformVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.previewStackUpdate(appName, template)
+}
+
+// ComparePlatformVersions returns -1 if a is older than b, 1 if a is newer than b, and 0 if they are same.
+// The version format is "<major>.<minor>".
+func ComparePlatformVersions(a string, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var av, bv int
+		if i < len(as) {
+			av, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			bv, _ = strconv.Atoi(bs[i])
+		}
+		if av < bv {
+			return -1
+		}
+		if av > bv {
+			return 1
+		}
+	}
+	return 0
+}
+
+// CausesDowntime returns whether or not the change replaces resources serving the application.
+func CausesDowntime(change *objects.Change) bool {
+	if change.Action == "Remove" {
+		return true
+	}
+	if change.Replacement != "True" {
+		return false
+	}
+
+	switch change.LogicalResourceID {
+	case "HerogateApplicationContainer":
+		// ECS service replaces tasks with rolling update
+		return false
+	}
+	return true
+}
+
+func generateUpgradedTemplate(base string, version string) (string, error) {
+	if ComparePlatformVersions(version, PlatformVersion) > 0 {
+		return "", fmt.Errorf("The platform version %s is newer than %s supported by this binary", version, PlatformVersion)
+	}
+
+	current, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	for _, migration := range platformMigrations {
+		if ComparePlatformVersions(migration.version, version) <= 0 || ComparePlatformVersions(migration.version, PlatformVersion) > 0 {
+			continue
+		}
+		logrus.WithFields(logrus.Fields{
+			"version": migration.version,
+		}).Debug("Migrate the template")
+		if err = migration.migrate(current); err != nil {
+			return "", fmt.Errorf("Failed to migrate the template to %s: %s", migration.version, err.Error())
+		}
+	}
+
+	yaml, err := assets.Asset("assets/platform.yaml")
+	if err != nil {
+		logrus.Fatal("Failed to load the template: " + err.Error())
+	}
+	latest, err := config.ParseYaml(string(yaml))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": string(yaml),
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	for _, path := range platformStatePaths {
+		state, err := current.Get(path)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"path": path,
+			}).Debug("The state is not found in the current template: " + err.Error())
+			continue
+		}
+		if err = latest.Set(path, state.Root); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"path":  path,
+				"state": state.Root,
+			}).Fatal("Failed to set the state to template" + err.Error())
+		}
+	}
+
+	// The approval stage is not a path in the template, so it is carried over separately.
+	if stageIndex(current, approvalStageName) >= 0 {
+		setApprovalStage(latest, true)
+	}
+
+	// Build settings are applied to the builder project of the latest template.
+	syncBuildArgs(latest)
+	// Health check settings are applied to the target group of the latest template.
+	applyHealthCheck(latest)
+
+	template, err := config.RenderYaml(latest.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": latest.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template, nil
+}
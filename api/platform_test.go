@@ -0,0 +1,161 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/wata727/herogate/api/objects"
+)
+
+func TestComparePlatformVersions(t *testing.T) {
+	cases := []struct {
+		A        string
+		B        string
+		Expected int
+	}{
+		{
+			A:        "1.0",
+			B:        "1.0",
+			Expected: 0,
+		},
+		{
+			A:        "1.0",
+			B:        "1.1",
+			Expected: -1,
+		},
+		{
+			A:        "1.10",
+			B:        "1.9",
+			Expected: 1,
+		},
+		{
+			A:        "2.0",
+			B:        "1.9",
+			Expected: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		result := ComparePlatformVersions(tc.A, tc.B)
+		if result != tc.Expected {
+			t.Fatalf("Expected result of `%s` and `%s` is `%d`, but get `%d`", tc.A, tc.B, tc.Expected, result)
+		}
+	}
+}
+
+func TestCausesDowntime(t *testing.T) {
+	cases := []struct {
+		Change   *objects.Change
+		Expected bool
+	}{
+		{
+			Change: &objects.Change{
+				Action:            "Modify",
+				LogicalResourceID: "HerogateApplicationContainer",
+				Replacement:       "True",
+			},
+			Expected: false,
+		},
+		{
+			Change: &objects.Change{
+				Action:            "Modify",
+				LogicalResourceID: "HerogateLoadBalancer",
+				Replacement:       "True",
+			},
+			Expected: true,
+		},
+		{
+			Change: &objects.Change{
+				Action:            "Modify",
+				LogicalResourceID: "HerogateLoadBalancer",
+				Replacement:       "False",
+			},
+			Expected: false,
+		},
+		{
+			Change: &objects.Change{
+				Action:            "Remove",
+				LogicalResourceID: "HerogateBuilder",
+			},
+			Expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		result := CausesDowntime(tc.Change)
+		if result != tc.Expected {
+			t.Fatalf("Expected result of `%#v` is `%t`, but get `%t`", tc.Change, tc.Expected, result)
+		}
+	}
+}
+
+func TestGenerateUpgradedTemplate(t *testing.T) {
+	defer func(migrations []*platformMigration) { platformMigrations = migrations }(platformMigrations)
+	platformMigrations = []*platformMigration{
+		{
+			version: "0.9",
+			migrate: func(cfg *config.Config) error {
+				t.Fatal("Expected the migration for the older version is not applied, but it is applied")
+				return nil
+			},
+		},
+		{
+			version: "1.0",
+			migrate: func(cfg *config.Config) error {
+				// Rename the old container resource
+				definitions, err := cfg.Get("Resources.HerogateContainer.Properties.ContainerDefinitions")
+				if err != nil {
+					return err
+				}
+				return cfg.Set("Resources.HerogateApplicationContainer.Properties.ContainerDefinitions", definitions.Root)
+			},
+		},
+	}
+
+	base := `
+Resources:
+  HerogateContainer:
+    Properties:
+      ContainerDefinitions:
+        - Name: web
+          Image: young-eyrie-24091:latest
+          Environment:
+            - Name: RAILS_ENV
+              Value: production
+  HerogateApplicationService:
+    Properties:
+      DesiredCount: 3
+`
+	template, err := generateUpgradedTemplate(base, "0.9")
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		t.Fatalf("Failed to parse the generated template: %s", err.Error())
+	}
+	image, err := cfg.String("Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Image")
+	if err != nil || image != "young-eyrie-24091:latest" {
+		t.Fatalf("Expected image is `young-eyrie-24091:latest`, but get `%s`", image)
+	}
+	env, err := cfg.String("Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Environment.0.Value")
+	if err != nil || env != "production" {
+		t.Fatalf("Expected environment variable is `production`, but get `%s`", env)
+	}
+	count, err := cfg.Int("Resources.HerogateApplicationService.Properties.DesiredCount")
+	if err != nil || count != 3 {
+		t.Fatalf("Expected desired count is `3`, but get `%d`", count)
+	}
+	// The latest template resources exist
+	if _, err = cfg.Get("Resources.HerogateLoadBalancer"); err != nil {
+		t.Fatalf("Expected load balancer exists, but get error `%s`", err.Error())
+	}
+}
+
+func TestGenerateUpgradedTemplate__newerVersion(t *testing.T) {
+	_, err := generateUpgradedTemplate("Resources: {}", "99.0")
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+}
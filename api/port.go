@@ -0,0 +1,147 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/olebedev/config"
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/container"
+)
+
+// portEnvName is the environment variable injected to containers like Heroku.
+const portEnvName = "PORT"
+
+// portPath is the path of the port in the template. The load balancer routes requests to the web process on it.
+const portPath = "Resources.HerogateApplicationService.Properties.LoadBalancers.0.ContainerPort"
+
+const containerDefinitions = "Resources.HerogateApplicationContainer.Properties.ContainerDefinitions"
+
+// GetPort returns the port which the web process of the application listens on.
+func (c *Client) GetPort(appName string) (int, error) {
+	if _, err := c.GetApp(appName); err != nil {
+		return 0, err
+	}
+
+	template := c.GetTemplate(appName)
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	return TemplatePort(cfg), nil
+}
+
+// SetPort updates CloudFormation stack with the new port.
+// The port mapping of the web process, the load balancer of the service and PORT of all processes follow it.
+// When the port did not change, it does not perform updates.
+// When the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) SetPort(appName string, port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("Invalid port %d, must be between 1 and 65535", port)
+	}
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return err
+	}
+	if err = checkStackIdle(app); err != nil {
+		return err
+	}
+
+	base := c.GetTemplate(appName)
+	template := generatePortTemplate(base, port)
+	if base == template {
+		return nil
+	}
+
+	return c.updateStack(appName, template)
+}
+
+// PreviewSetPort returns resource changes when setting the port.
+// It creates a CloudFormation change set from the generated template and deletes it without executing.
+func (c *Client) PreviewSetPort(appName string, port int) ([]*objects.Change, error) {
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("Invalid port %d, must be between 1 and 65535", port)
+	}
+	if _, err := c.GetApp(appName); err != nil {
+		return nil, err
+	}
+
+	base := c.GetTemplate(appName)
+	template := generatePortTemplate(base, port)
+	if base == template {
+		return []*objects.Change{}, nil
+	}
+
+	return c.previewStackUpdate(appName, template)
+}
+
+// TemplatePort returns the port in the template. If it is not found, returns the default port.
+func TemplatePort(cfg *config.Config) int {
+	port, err := cfg.Int(portPath)
+	if err != nil {
+		logrus.Debug("Failed to get port: " + err.Error())
+		return container.DefaultPort
+	}
+	return port
+}
+
+func generatePortTemplate(base string, port int) string {
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	ApplyPort(cfg, port)
+
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template
+}
+
+// ApplyPort sets the port to the load balancer of the service and the container definitions in the template.
+// ECS registers targets with the container port, so the target group keeps its port and is not replaced.
+func ApplyPort(cfg *config.Config, port int) {
+	if err := cfg.Set(portPath, port); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"port":   port,
+			"config": cfg,
+		}).Fatal("Failed to set port to template" + err.Error())
+	}
+
+	definitions, err := cfg.List(containerDefinitions)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg,
+		}).Fatal("Failed to get container definitions" + err.Error())
+	}
+	for i := range definitions {
+		environment, _ := cfg.List(fmt.Sprintf("%s.%d.Environment", containerDefinitions, i))
+		if err := cfg.Set(fmt.Sprintf("%s.%d.Environment", containerDefinitions, i), container.WithPort(environment, port)); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"port":   port,
+				"config": cfg,
+			}).Fatal("Failed to set PORT to template" + err.Error())
+		}
+
+		if name, _ := cfg.String(fmt.Sprintf("%s.%d.Name", containerDefinitions, i)); name != "web" {
+			continue
+		}
+		err := cfg.Set(fmt.Sprintf("%s.%d.PortMappings", containerDefinitions, i), []*container.PortMapping{{ContainerPort: port}})
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"port":   port,
+				"config": cfg,
+			}).Fatal("Failed to set port mappings to template" + err.Error())
+		}
+	}
+}
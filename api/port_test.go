@@ -0,0 +1,67 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestGeneratePortTemplate(t *testing.T) {
+	base := `Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Environment:
+        - Name: PORT
+          Value: "80"
+        - Name: RAILS_ENV
+          Value: production
+        Name: web
+        PortMappings:
+        - ContainerPort: 80
+      - Environment:
+        - Name: RAILS_ENV
+          Value: production
+        Name: worker
+        PortMappings: []
+  HerogateApplicationService:
+    Properties:
+      LoadBalancers:
+      - ContainerName: web
+        ContainerPort: 80
+  HerogateLoadBalancerTargetGroup:
+    Properties:
+      Port: 80
+`
+
+	template := generatePortTemplate(base, 5000)
+	expected := `Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Environment:
+        - Name: RAILS_ENV
+          Value: production
+        - Name: PORT
+          Value: "5000"
+        Name: web
+        PortMappings:
+        - ContainerPort: 5000
+      - Environment:
+        - Name: RAILS_ENV
+          Value: production
+        - Name: PORT
+          Value: "5000"
+        Name: worker
+        PortMappings: []
+  HerogateApplicationService:
+    Properties:
+      LoadBalancers:
+      - ContainerName: web
+        ContainerPort: 5000
+  HerogateLoadBalancerTargetGroup:
+    Properties:
+      Port: 80
+`
+	if template != expected {
+		t.Fatalf("Expected template is `%s`, but get `%s`", expected, template)
+	}
+}
@@ -0,0 +1,354 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/olebedev/config"
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/container"
+)
+
+// releasesPath is the path of the release history in the template.
+// CloudFormation ignores the content of the Metadata section, so it can keep the history with the stack.
+const releasesPath = "Metadata.HerogateReleases"
+
+// maxReleases is the number of releases kept in the template to avoid exceeding the template size limit.
+const maxReleases = 20
+
+// DescribeReleases returns the release history of the application in the order of newest first.
+func (c *Client) DescribeReleases(appName string) ([]*objects.Release, error) {
+	if _, err := c.GetApp(appName); err != nil {
+		return nil, err
+	}
+
+	template := c.GetTemplate(appName)
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName":  appName,
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	releases := templateReleases(cfg)
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Version > releases[j].Version
+	})
+	return releases, nil
+}
+
+func templateReleases(cfg *config.Config) []*objects.Release {
+	list, err := cfg.List(releasesPath)
+	if err != nil {
+		logrus.Debug("Failed to get releases: " + err.Error())
+		return []*objects.Release{}
+	}
+
+	releases := []*objects.Release{}
+	for i := range list {
+		item, err := cfg.Get(fmt.Sprintf("%s.%d", releasesPath, i))
+		if err != nil {
+			continue
+		}
+		version, _ := item.Int("Version")
+		description, _ := item.String("Description")
+		image, _ := item.String("Image")
+		createdAt, _ := item.String("CreatedAt")
+		created, _ := time.Parse(time.RFC3339, createdAt)
+
+		releases = append(releases, &objects.Release{
+			Version:     version,
+			Description: description,
+			Image:       image,
+			CreatedAt:   created,
+		})
+	}
+	return releases
+}
+
+// RecordRelease appends the release to the history in the template, and sets its version.
+func RecordRelease(cfg *config.Config, release *objects.Release) {
+	releases := templateReleases(cfg)
+	release.Version = 1
+	if len(releases) > 0 {
+		release.Version = releases[len(releases)-1].Version + 1
+	}
+	releases = append(releases, release)
+	if len(releases) > maxReleases {
+		releases = releases[len(releases)-maxReleases:]
+	}
+
+	list := []interface{}{}
+	for _, r := range releases {
+		list = append(list, map[string]interface{}{
+			"Version":     r.Version,
+			"Description": r.Description,
+			"Image":       r.Image,
+			"CreatedAt":   r.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	if err := cfg.Set(releasesPath, list); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"releases": list,
+			"config":   cfg,
+		}).Fatal("Failed to set releases to template" + err.Error())
+	}
+}
+
+// generateReleasedTemplate returns the template which runs the processes with the image, and records the release.
+// Existing definitions of processes keep their settings such as environment variables and settings of herogate.yml,
+// and only the image and the command are replaced. Sidecars keep their own images unless they run the app image.
+func generateReleasedTemplate(base string, image string, processes map[string][]string, release *objects.Release) string {
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	existing, err := cfg.List(containerDefinitions)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg,
+		}).Debug("Failed to get container definitions" + err.Error())
+	}
+
+	appImage := ""
+	var environment []interface{}
+	sidecars := []interface{}{}
+	current := map[string]map[string]interface{}{}
+	for _, item := range existing {
+		definition, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if sidecarDefinition(definition) {
+			sidecars = append(sidecars, definition)
+			continue
+		}
+		name := fmt.Sprint(definition["Name"])
+		if appImage == "" || name == "web" {
+			appImage = fmt.Sprint(definition["Image"])
+		}
+		if list, ok := definition["Environment"].([]interface{}); ok && environment == nil {
+			environment = list
+		}
+		current[name] = definition
+	}
+
+	definitions := []interface{}{}
+	for name, command := range processes {
+		if definition, ok := current[name]; ok {
+			definition["Image"] = image
+			definition["Command"] = command
+			definitions = append(definitions, definition)
+			continue
+		}
+		definition := container.New(name, image, command, environment, TemplatePort(cfg))
+		if name == "web" {
+			definition.HealthCheck = WebHealthCheck(cfg)
+		}
+		definitions = append(definitions, definition)
+	}
+	if len(definitions) > 0 {
+		for _, sidecar := range sidecars {
+			definition := sidecar.(map[string]interface{})
+			if appImage != "" && definition["Image"] == appImage {
+				definition["Image"] = image
+			}
+			definitions = append(definitions, definition)
+		}
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitionName(definitions[i]) < definitionName(definitions[j])
+	})
+
+	if len(definitions) > 0 {
+		err = cfg.Set(containerDefinitions, definitions)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"definitions": definitions,
+				"config":      cfg,
+			}).Fatal("Failed to set container definitions to template" + err.Error())
+		}
+	}
+	RecordRelease(cfg, release)
+
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template
+}
+
+// sidecarDefinition returns whether or not the container definition in the template is a sidecar.
+func sidecarDefinition(definition interface{}) bool {
+	item, ok := definition.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	labels, ok := item["DockerLabels"].(map[string]interface{})
+	return ok && fmt.Sprint(labels[container.SidecarLabel]) == "true"
+}
+
+func definitionName(definition interface{}) string {
+	switch d := definition.(type) {
+	case *container.Definition:
+		return d.Name
+	case map[string]interface{}:
+		return fmt.Sprint(d["Name"])
+	}
+	return ""
+}
+
+// describeRunningTaskDefinition returns the task definition currently used by the application service.
+func (c *Client) describeRunningTaskDefinition(appName string) (*ecs.TaskDefinition, error) {
+	serviceResp, err := c.ecs.DescribeServices(&ecs.DescribeServicesInput{
+		Cluster:  aws.String(appName),
+		Services: []*string{aws.String(appName)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(serviceResp.Services) == 0 {
+		return nil, fmt.Errorf("ECS service of %s is not found", appName)
+	}
+
+	taskResp, err := c.ecs.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: serviceResp.Services[0].TaskDefinition,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return taskResp.TaskDefinition, nil
+}
+
+// ReleaseImage deploys the image to the application without building it in the pipeline.
+// If processes are empty, the processes currently deployed are used.
+// The deployment is recorded in the release history.
+// When the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) ReleaseImage(appName string, image string, processes map[string][]string) (*objects.Release, error) {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkStackIdle(app); err != nil {
+		return nil, err
+	}
+
+	base := c.GetTemplate(appName)
+	if len(processes) == 0 {
+		processes = templateProcesses(base)
+	}
+
+	release := &objects.Release{
+		Description: "Deploy " + image,
+		Image:       image,
+		CreatedAt:   time.Now(),
+	}
+	if err = c.updateStack(appName, generateReleasedTemplate(base, image, processes, release)); err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// templateProcesses returns commands of the container definitions in the template by the process name.
+// Sidecars are not processes of the app, so they are excluded.
+func templateProcesses(template string) map[string][]string {
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	processes := map[string][]string{}
+	definitions, err := cfg.List("Resources.HerogateApplicationContainer.Properties.ContainerDefinitions")
+	if err != nil {
+		logrus.Debug("Failed to get container definitions: " + err.Error())
+		return processes
+	}
+	for i, definition := range definitions {
+		if sidecarDefinition(definition) {
+			continue
+		}
+		path := fmt.Sprintf("Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.%d", i)
+		name, err := cfg.String(path + ".Name")
+		if err != nil {
+			continue
+		}
+		command := []string{}
+		if list, err := cfg.List(path + ".Command"); err == nil {
+			for _, arg := range list {
+				command = append(command, fmt.Sprint(arg))
+			}
+		}
+		processes[name] = command
+	}
+	return processes
+}
+
+// GetRegistry returns the ECR repository of the application and credentials to push images to it.
+func (c *Client) GetRegistry(appName string) (*objects.Registry, error) {
+	ecrResource, err := c.cloudFormation.DescribeStackResource(&cloudformation.DescribeStackResourceInput{
+		StackName:         aws.String(appName),
+		LogicalResourceId: aws.String("HerogateRegistry"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repoResp, err := c.ecr.DescribeRepositories(&ecr.DescribeRepositoriesInput{
+		RepositoryNames: []*string{ecrResource.StackResourceDetail.PhysicalResourceId},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(repoResp.Repositories) == 0 {
+		return nil, errors.New("Expected ECR repository not found")
+	}
+
+	authResp, err := c.ecr.GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{
+		RegistryIds: []*string{repoResp.Repositories[0].RegistryId},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(authResp.AuthorizationData) == 0 {
+		return nil, errors.New("Expected ECR authorization data not found")
+	}
+	auth := authResp.AuthorizationData[0]
+
+	// The token is a base64 encoded "user:password" string.
+	token, err := base64.StdEncoding.DecodeString(aws.StringValue(auth.AuthorizationToken))
+	if err != nil {
+		return nil, err
+	}
+	credentials := strings.SplitN(string(token), ":", 2)
+	if len(credentials) != 2 {
+		return nil, errors.New("Invalid ECR authorization token")
+	}
+
+	return &objects.Registry{
+		URI:      aws.StringValue(repoResp.Repositories[0].RepositoryUri),
+		Endpoint: aws.StringValue(auth.ProxyEndpoint),
+		Username: credentials[0],
+		Password: credentials[1],
+	}, nil
+}
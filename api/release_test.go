@@ -0,0 +1,266 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/olebedev/config"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestGenerateReleasedTemplate(t *testing.T) {
+	base := `Metadata:
+  HerogateReleases:
+  - CreatedAt: "2018-01-01T00:00:00Z"
+    Description: Promote from staging
+    Image: staging:1
+    Version: 1
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Command:
+        - bundle
+        - exec
+        - rails
+        - server
+        Environment:
+        - Name: RAILS_ENV
+          Value: production
+        Image: staging:1
+        Name: web
+`
+	release := &objects.Release{
+		Description: "Promote from staging",
+		Image:       "staging:2",
+		CreatedAt:   time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	template := generateReleasedTemplate(
+		base,
+		"staging:2",
+		map[string][]string{
+			"worker": {"bundle", "exec", "sidekiq"},
+			"web":    {"bundle", "exec", "rails", "server"},
+		},
+		release,
+	)
+
+	if release.Version != 2 {
+		t.Fatalf("Expected version is 2, but get %d", release.Version)
+	}
+
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		t.Fatalf("Failed to parse the generated template: %s", err)
+	}
+	expected := map[string]string{
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Name":                "web",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Image":               "staging:2",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Environment.0.Value": "production",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.1.Name":                "worker",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.1.Command.2":           "sidekiq",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.1.Environment.0.Value": "production",
+		"Metadata.HerogateReleases.1.Image":                                                            "staging:2",
+		"Metadata.HerogateReleases.1.CreatedAt":                                                        "2018-01-02T00:00:00Z",
+	}
+	for path, value := range expected {
+		actual, err := cfg.String(path)
+		if err != nil {
+			t.Fatalf("Failed to get `%s`: %s", path, err)
+		}
+		if actual != value {
+			t.Fatalf("Expected `%s` is `%s`, but get `%s`", path, value, actual)
+		}
+	}
+}
+
+func TestGenerateReleasedTemplate__sidecar(t *testing.T) {
+	base := `Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Cpu: 64
+        DockerLabels:
+          herogate.sidecar: "true"
+        Essential: false
+        Image: envoyproxy/envoy:v1.6.0
+        Name: envoy
+      - Command:
+        - bin/log-shipper
+        DockerLabels:
+          herogate.sidecar: "true"
+        Image: staging:1
+        Name: log-shipper
+      - Command:
+        - bundle
+        - exec
+        - rails
+        - server
+        DependsOn:
+        - Condition: START
+          ContainerName: envoy
+        Environment:
+        - Name: RAILS_ENV
+          Value: production
+        Image: staging:1
+        Memory: 1024
+        Name: web
+`
+	template := generateReleasedTemplate(
+		base,
+		"staging:2",
+		map[string][]string{
+			"web": {"bundle", "exec", "puma"},
+		},
+		&objects.Release{CreatedAt: time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)},
+	)
+
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		t.Fatalf("Failed to parse the generated template: %s", err)
+	}
+	expected := map[string]string{
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Name":                      "envoy",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Image":                     "envoyproxy/envoy:v1.6.0",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Cpu":                       "64",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Essential":                 "false",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.1.Name":                      "log-shipper",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.1.Image":                     "staging:2",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.2.Name":                      "web",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.2.Image":                     "staging:2",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.2.Command.2":                 "puma",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.2.Memory":                    "1024",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.2.DependsOn.0.ContainerName": "envoy",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.2.Environment.0.Value":       "production",
+	}
+	for path, value := range expected {
+		actual, err := cfg.String(path)
+		if err != nil {
+			t.Fatalf("Failed to get `%s`: %s", path, err)
+		}
+		if actual != value {
+			t.Fatalf("Expected `%s` is `%s`, but get `%s`", path, value, actual)
+		}
+	}
+
+	processes := templateProcesses(template)
+	if !cmp.Equal(map[string][]string{"web": {"bundle", "exec", "puma"}}, processes) {
+		t.Fatalf("Expected processes do not include sidecars, but get %#v", processes)
+	}
+}
+
+func TestRecordRelease__maxReleases(t *testing.T) {
+	cfg, err := config.ParseYaml("Resources: {}\n")
+	if err != nil {
+		t.Fatalf("Failed to parse yaml: %s", err)
+	}
+
+	for i := 0; i < maxReleases+5; i++ {
+		RecordRelease(cfg, &objects.Release{
+			Description: fmt.Sprintf("Release %d", i+1),
+			CreatedAt:   time.Now(),
+		})
+	}
+
+	releases := templateReleases(cfg)
+	if len(releases) != maxReleases {
+		t.Fatalf("Expected the number of releases is %d, but get %d", maxReleases, len(releases))
+	}
+	if releases[0].Version != 6 {
+		t.Fatalf("Expected the oldest version is 6, but get %d", releases[0].Version)
+	}
+	if releases[len(releases)-1].Version != maxReleases+5 {
+		t.Fatalf("Expected the latest version is %d, but get %d", maxReleases+5, releases[len(releases)-1].Version)
+	}
+}
+
+func TestTemplateProcesses(t *testing.T) {
+	processes := templateProcesses(`Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Command:
+        - bundle
+        - exec
+        - rails
+        - server
+        Image: young-eyrie-24091:1
+        Name: web
+      - Image: young-eyrie-24091:1
+        Name: worker
+`)
+
+	expected := map[string][]string{
+		"web":    {"bundle", "exec", "rails", "server"},
+		"worker": {},
+	}
+	if !cmp.Equal(expected, processes) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, processes))
+	}
+}
+
+func TestGetRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to get the ECR repository
+	cfnMock.EXPECT().DescribeStackResource(&cloudformation.DescribeStackResourceInput{
+		StackName:         aws.String("young-eyrie-24091"),
+		LogicalResourceId: aws.String("HerogateRegistry"),
+	}).Return(&cloudformation.DescribeStackResourceOutput{
+		StackResourceDetail: &cloudformation.StackResourceDetail{
+			PhysicalResourceId: aws.String("young-eyrie-24091"),
+		},
+	}, nil)
+	ecrMock := mock.NewMockECRAPI(ctrl)
+	// Expect to describe the repository
+	ecrMock.EXPECT().DescribeRepositories(&ecr.DescribeRepositoriesInput{
+		RepositoryNames: []*string{aws.String("young-eyrie-24091")},
+	}).Return(&ecr.DescribeRepositoriesOutput{
+		Repositories: []*ecr.Repository{
+			{
+				RegistryId:    aws.String("123456789012"),
+				RepositoryUri: aws.String("123456789012.dkr.ecr.us-east-1.amazonaws.com/young-eyrie-24091"),
+			},
+		},
+	}, nil)
+	// Expect to get the authorization token
+	ecrMock.EXPECT().GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{
+		RegistryIds: []*string{aws.String("123456789012")},
+	}).Return(&ecr.GetAuthorizationTokenOutput{
+		AuthorizationData: []*ecr.AuthorizationData{
+			{
+				AuthorizationToken: aws.String(base64.StdEncoding.EncodeToString([]byte("AWS:secret"))),
+				ProxyEndpoint:      aws.String("https://123456789012.dkr.ecr.us-east-1.amazonaws.com"),
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+	client.ecr = ecrMock
+
+	registry, err := client.GetRegistry("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := &objects.Registry{
+		URI:      "123456789012.dkr.ecr.us-east-1.amazonaws.com/young-eyrie-24091",
+		Endpoint: "https://123456789012.dkr.ecr.us-east-1.amazonaws.com",
+		Username: "AWS",
+		Password: "secret",
+	}
+	if !cmp.Equal(expected, registry) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, registry))
+	}
+}
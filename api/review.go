@@ -0,0 +1,118 @@
+package api
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/sirupsen/logrus"
+)
+
+const repositoryNamePath = "Resources.HerogatePipeline.Properties.Stages.0.Actions.0.Configuration.RepositoryName"
+
+// MaxAppNameLength is the maximum length of the app name. Resource names are derived from the app name,
+// e.g. the S3 bucket `herogate-<account>-us-east-1-<name>` up to 63 characters and the load balancer up to 32 characters.
+const MaxAppNameLength = 31
+
+// ReviewAppName returns the name of the review app for the branch. e.g. "young-eyrie-24091-pr-fix-login"
+// If the name is longer than MaxAppNameLength, the branch is truncated and a short hash of it is appended
+// so that names of different branches stay unique. e.g. "young-eyrie-24091-pr-fe-0786db9"
+func ReviewAppName(parentName string, branch string) string {
+	prefix := parentName + "-pr-"
+	name := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(branch), "-"), "-")
+	if len(prefix+name) <= MaxAppNameLength {
+		return prefix + name
+	}
+
+	hash := fmt.Sprintf("%x", sha1.Sum([]byte(branch)))[:7]
+	room := MaxAppNameLength - len(prefix) - len(hash) - 1
+	if room <= 0 {
+		return prefix + hash
+	}
+	if len(name) > room {
+		name = strings.TrimRight(name[:room], "-")
+	}
+	if name == "" {
+		return prefix + hash
+	}
+	return prefix + name + "-" + hash
+}
+
+// generateReviewTemplate returns the template of the review app cloned from the parent app.
+// The pipeline of the review app tracks the parent repository, so its own repository is not used.
+// Environment variables, the port, the build settings and the health check settings are copied from the parent app.
+func (c *Client) generateReviewTemplate(base string, parentName string) (string, error) {
+	parent, err := c.GetApp(parentName)
+	if err != nil {
+		return "", err
+	}
+	parentCfg, err := config.ParseYaml(c.GetTemplate(parentName))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": parentName,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	// When the parent is also a review app, it tracks another repository.
+	repositoryName, err := parentCfg.String(repositoryNamePath)
+	if err != nil {
+		repositoryName = parentName
+	}
+	values := map[string]interface{}{
+		repositoryNamePath:         repositoryName,
+		"Outputs.Repository.Value": parent.Repository,
+	}
+	for path, value := range values {
+		if err = cfg.Set(path, value); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"path":  path,
+				"value": value,
+			}).Fatal("Failed to set value to template" + err.Error())
+		}
+	}
+	copyParentState(cfg, parentCfg)
+
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template, nil
+}
+
+// copyParentState copies the application state of the parent template to the review app template.
+// The settings kept in the Metadata section are applied to the resources in the same way as deployments.
+func copyParentState(cfg *config.Config, parentCfg *config.Config) {
+	previousArgs := templateBuildConfig(cfg).Args
+
+	for _, path := range []string{envVarsPath, buildConfigPath, healthCheckPath} {
+		state, err := parentCfg.Get(path)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"path": path,
+			}).Debug("The state is not found in the parent template: " + err.Error())
+			continue
+		}
+		if err = cfg.Set(path, state.Root); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"path":  path,
+				"state": state.Root,
+			}).Fatal("Failed to set the state to template" + err.Error())
+		}
+	}
+
+	ApplyPort(cfg, TemplatePort(parentCfg))
+	applyHealthCheck(cfg)
+	applyBuildConfig(cfg, previousArgs)
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/olebedev/config"
+	"github.com/wata727/herogate/api/assets"
+	"github.com/wata727/herogate/api/objects"
+)
+
+func TestReviewAppName(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Branch   string
+		Expected string
+	}{
+		{
+			Name:     "simple branch",
+			Branch:   "feature",
+			Expected: "young-eyrie-24091-pr-feature",
+		},
+		{
+			Name:     "branch with slashes",
+			Branch:   "fix/login",
+			Expected: "young-eyrie-24091-pr-fix-login",
+		},
+		{
+			Name:     "branch with uppercase and symbols",
+			Branch:   "_Fix_Bug#123_",
+			Expected: "young-eyrie-24091-pr-fi-85e061f",
+		},
+		{
+			Name:     "long branch",
+			Branch:   "feature/very-long-branch-name",
+			Expected: "young-eyrie-24091-pr-fe-0786db9",
+		},
+	}
+
+	for _, tc := range cases {
+		name := ReviewAppName("young-eyrie-24091", tc.Branch)
+		if name != tc.Expected {
+			t.Fatalf("Expected name is `%s`, but get `%s` in %s", tc.Expected, name, tc.Name)
+		}
+	}
+}
+
+func TestCopyParentState(t *testing.T) {
+	yaml, err := assets.Asset("assets/platform.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	base := string(yaml)
+
+	parent := generateUpdatedEnvVarsTemplate(base, map[string]string{"NPM_TOKEN": "secret"})
+	parent = generatePortTemplate(parent, 5000)
+	parent = generateHealthCheckTemplate(parent, &objects.HealthCheck{
+		Path:             "/healthz",
+		Interval:         15,
+		HealthyThreshold: 2,
+		Command:          "curl -f http://localhost:$PORT/healthz",
+	})
+	parent = generateBuildConfigTemplate(parent, &objects.BuildConfig{
+		Dockerfile: "api/Dockerfile",
+		Compute:    "large",
+		Args:       []string{"NPM_TOKEN"},
+	})
+	parentCfg, err := config.ParseYaml(parent)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	copyParentState(cfg, parentCfg)
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	cfg, err = config.ParseYaml(template)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	if port := TemplatePort(cfg); port != 5000 {
+		t.Fatalf("Expected port is 5000, but get %d", port)
+	}
+	if mapping, _ := cfg.Int(containerDefinitions + ".0.PortMappings.0.ContainerPort"); mapping != 5000 {
+		t.Fatalf("Expected port mapping is 5000, but get %d", mapping)
+	}
+	if !cmp.Equal(templateHealthCheck(cfg), templateHealthCheck(parentCfg)) {
+		t.Fatalf("Expected health check is not matched: Diff=%s", cmp.Diff(templateHealthCheck(cfg), templateHealthCheck(parentCfg)))
+	}
+	if path, _ := cfg.String(targetGroupHealthCheckPath); path != "/healthz" {
+		t.Fatalf("Expected health check path of the target group is /healthz, but get %s", path)
+	}
+	if _, err := cfg.Get(containerDefinitions + ".0.HealthCheck"); err != nil {
+		t.Fatalf("Expected health check of the web process is set, but get an error: %s", err)
+	}
+	if !cmp.Equal(templateBuildConfig(cfg), templateBuildConfig(parentCfg)) {
+		t.Fatalf("Expected build settings are not matched: Diff=%s", cmp.Diff(templateBuildConfig(cfg), templateBuildConfig(parentCfg)))
+	}
+	if computeType, _ := cfg.String(builderComputeTypePath); computeType != "BUILD_GENERAL1_LARGE" {
+		t.Fatalf("Expected compute type is BUILD_GENERAL1_LARGE, but get %s", computeType)
+	}
+	envVars, err := cfg.List(builderEnvVarsPath)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	var passed bool
+	for i := range envVars {
+		if name, _ := cfg.String(fmt.Sprintf("%s.%d.Name", builderEnvVarsPath, i)); name == "NPM_TOKEN" {
+			passed = true
+		}
+	}
+	if !passed {
+		t.Fatalf("Expected NPM_TOKEN is passed to the builder, but get `%#v`", envVars)
+	}
+}
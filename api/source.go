@@ -0,0 +1,144 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/codepipeline"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/olebedev/config"
+	"github.com/sirupsen/logrus"
+)
+
+// S3SourcePlatformVersion is the minimum platform version which supports S3 source.
+// S3 source action requires the versioned artifact store.
+const S3SourcePlatformVersion = "1.1"
+
+const sourceActionPath = "Resources.HerogatePipeline.Properties.Stages.0.Actions.0"
+
+// sourceObjectKey is the key of the source archive in the artifact store.
+const sourceObjectKey = "herogate-source.zip"
+
+// UseS3Source changes the source of the application pipeline from CodeCommit to S3.
+// It returns whether or not the source is changed. When the source is already S3, it does not perform updates.
+// When the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) UseS3Source(appName string) (bool, error) {
+	app, err := c.GetApp(appName)
+	if err != nil {
+		return false, err
+	}
+	if ComparePlatformVersions(app.PlatformVersion, S3SourcePlatformVersion) < 0 {
+		return false, fmt.Errorf("S3 source requires the platform version %s or later", S3SourcePlatformVersion)
+	}
+	if err = checkStackIdle(app); err != nil {
+		return false, err
+	}
+
+	base := c.GetTemplate(appName)
+	if templateSource(base) == "S3" {
+		return false, nil
+	}
+
+	if err = c.updateStack(appName, generateS3SourceTemplate(base)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeploySource uploads the source archive to the artifact store and starts the pipeline.
+// It returns the pipeline execution ID.
+func (c *Client) DeploySource(appName string, archive io.ReadSeeker) (string, error) {
+	s3Resource, err := c.cloudFormation.DescribeStackResource(&cloudformation.DescribeStackResourceInput{
+		StackName:         aws.String(appName),
+		LogicalResourceId: aws.String("HerogatePipelineArtifactStore"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	_, err = c.s3.PutObject(&s3.PutObjectInput{
+		Bucket: s3Resource.StackResourceDetail.PhysicalResourceId,
+		Key:    aws.String(sourceObjectKey),
+		Body:   archive,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// The S3 source action doesn't poll for changes, so the pipeline must be started explicitly.
+	resp, err := c.codePipeline.StartPipelineExecution(&codepipeline.StartPipelineExecutionInput{
+		Name: aws.String(appName),
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.PipelineExecutionId == nil {
+		return "", errors.New("Expected pipeline execution not found")
+	}
+
+	return aws.StringValue(resp.PipelineExecutionId), nil
+}
+
+// templateSource returns the provider of the source action. e.g. "CodeCommit", "S3"
+func templateSource(template string) string {
+	cfg, err := config.ParseYaml(template)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": template,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	provider, err := cfg.String(sourceActionPath + ".ActionTypeId.Provider")
+	if err != nil {
+		logrus.Debug("Failed to get source provider: " + err.Error())
+		return "CodeCommit"
+	}
+	return provider
+}
+
+func generateS3SourceTemplate(base string) string {
+	cfg, err := config.ParseYaml(base)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"template": base,
+		}).Fatal("Failed to parse yaml template" + err.Error())
+	}
+
+	action := map[string]interface{}{
+		"Name": "ChangeSource",
+		"ActionTypeId": map[string]interface{}{
+			"Category": "Source",
+			"Owner":    "AWS",
+			"Provider": "S3",
+			"Version":  1,
+		},
+		"Configuration": map[string]interface{}{
+			"S3Bucket": map[string]interface{}{
+				"Ref": "HerogatePipelineArtifactStore",
+			},
+			"S3ObjectKey":          sourceObjectKey,
+			"PollForSourceChanges": false,
+		},
+		"OutputArtifacts": []interface{}{
+			map[string]interface{}{"Name": "HerogateSource"},
+		},
+	}
+	if err = cfg.Set(sourceActionPath, action); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"action": action,
+			"config": cfg,
+		}).Fatal("Failed to set source action to template" + err.Error())
+	}
+
+	template, err := config.RenderYaml(cfg.Root)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"config": cfg.Root,
+		}).Fatal("Failed to render yaml template" + err.Error())
+	}
+
+	return template
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/codepipeline"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestGenerateS3SourceTemplate(t *testing.T) {
+	base := `Resources:
+  HerogatePipeline:
+    Properties:
+      Stages:
+      - Actions:
+        - ActionTypeId:
+            Category: Source
+            Owner: AWS
+            Provider: CodeCommit
+            Version: 1
+          Configuration:
+            BranchName: master
+            RepositoryName:
+              Fn::GetAtt:
+              - HerogateRepository
+              - Name
+          Name: ChangeSource
+          OutputArtifacts:
+          - Name: HerogateSource
+        Name: Repository
+`
+	if templateSource(base) != "CodeCommit" {
+		t.Fatalf("Expected source is CodeCommit, but get %s", templateSource(base))
+	}
+
+	template := generateS3SourceTemplate(base)
+	expected := `Resources:
+  HerogatePipeline:
+    Properties:
+      Stages:
+      - Actions:
+        - ActionTypeId:
+            Category: Source
+            Owner: AWS
+            Provider: S3
+            Version: 1
+          Configuration:
+            PollForSourceChanges: false
+            S3Bucket:
+              Ref: HerogatePipelineArtifactStore
+            S3ObjectKey: herogate-source.zip
+          Name: ChangeSource
+          OutputArtifacts:
+          - Name: HerogateSource
+        Name: Repository
+`
+	if template != expected {
+		t.Fatalf("Expected template is `%s`, but get `%s`", expected, template)
+	}
+	if templateSource(template) != "S3" {
+		t.Fatalf("Expected source is S3, but get %s", templateSource(template))
+	}
+}
+
+func TestDeploySource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to get the artifact store
+	cfnMock.EXPECT().DescribeStackResource(&cloudformation.DescribeStackResourceInput{
+		StackName:         aws.String("young-eyrie-24091"),
+		LogicalResourceId: aws.String("HerogatePipelineArtifactStore"),
+	}).Return(&cloudformation.DescribeStackResourceOutput{
+		StackResourceDetail: &cloudformation.StackResourceDetail{
+			PhysicalResourceId: aws.String("herogate-12345678-us-east-1-young-eyrie-24091"),
+		},
+	}, nil)
+	s3Mock := mock.NewMockS3API(ctrl)
+	archive := strings.NewReader("archive")
+	// Expect to upload the archive
+	s3Mock.EXPECT().PutObject(&s3.PutObjectInput{
+		Bucket: aws.String("herogate-12345678-us-east-1-young-eyrie-24091"),
+		Key:    aws.String("herogate-source.zip"),
+		Body:   archive,
+	}).Return(&s3.PutObjectOutput{}, nil)
+	pipelineMock := mock.NewMockCodePipelineAPI(ctrl)
+	// Expect to start the pipeline
+	pipelineMock.EXPECT().StartPipelineExecution(&codepipeline.StartPipelineExecutionInput{
+		Name: aws.String("young-eyrie-24091"),
+	}).Return(&codepipeline.StartPipelineExecutionOutput{
+		PipelineExecutionId: aws.String("8f6c3f2a-1234-5678-9abc-def012345678"),
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+	client.s3 = s3Mock
+	client.codePipeline = pipelineMock
+
+	executionID, err := client.DeploySource("young-eyrie-24091", archive)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if executionID != "8f6c3f2a-1234-5678-9abc-def012345678" {
+		t.Fatalf("Expected execution ID is `8f6c3f2a-1234-5678-9abc-def012345678`, but get `%s`", executionID)
+	}
+}
@@ -0,0 +1,346 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/sirupsen/logrus"
+	"github.com/wata727/herogate/api/objects"
+)
+
+var changeSetName = func() string {
+	return fmt.Sprintf("herogate-dry-run-%d", time.Now().Unix())
+}
+
+// StackBusyError is an error when the stack is already being changed by another operation.
+type StackBusyError struct {
+	AppName string
+	Status  string
+}
+
+func (e *StackBusyError) Error() string {
+	return fmt.Sprintf("%s is busy with a running %s (%s)", e.AppName, e.Operation(), e.Status)
+}
+
+// Operation returns the name of the running operation. e.g. "update rollback"
+func (e *StackBusyError) Operation() string {
+	return StackOperation(e.Status)
+}
+
+// StackFailureError is an error when the stack creation or deletion is failed.
+// Kept is whether or not the failed stack still exists.
+type StackFailureError struct {
+	AppName  string
+	Status   string
+	Failures []*objects.ResourceFailure
+	Kept     bool
+}
+
+func (e *StackFailureError) Error() string {
+	reasons := []string{}
+	for _, failure := range e.Failures {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", failure.LogicalResourceID, failure.Reason))
+	}
+	return fmt.Sprintf("%s failed with %s (%s)", e.AppName, e.Status, strings.Join(reasons, ", "))
+}
+
+// StackInProgress returns whether or not the stack status is `*_IN_PROGRESS`.
+func StackInProgress(status string) bool {
+	return strings.HasSuffix(status, "_IN_PROGRESS")
+}
+
+// StackOperation returns the operation name from the stack status.
+// For example, "UPDATE_COMPLETE_CLEANUP_IN_PROGRESS" returns "update cleanup".
+func StackOperation(status string) string {
+	operation := strings.TrimSuffix(status, "_IN_PROGRESS")
+	operation = strings.Replace(operation, "_COMPLETE", "", -1)
+	return strings.ToLower(strings.Replace(operation, "_", " ", -1))
+}
+
+// checkStackIdle returns StackBusyError if the stack is in progress by another operation.
+func checkStackIdle(app *objects.App) error {
+	if StackInProgress(app.Status) {
+		return &StackBusyError{AppName: app.Name, Status: app.Status}
+	}
+	return nil
+}
+
+// updateStack updates CloudFormation stack with the template and waits until stack update complete.
+// If tags are passed, they are merged into the existing stack tags.
+// If the stack is being changed by another operation, returns StackBusyError.
+func (c *Client) updateStack(appName string, template string, tags ...*cloudformation.Tag) error {
+	input := &cloudformation.UpdateStackInput{
+		StackName:    aws.String(appName),
+		TemplateBody: aws.String(template),
+		Capabilities: []*string{aws.String("CAPABILITY_NAMED_IAM")},
+	}
+	if len(tags) > 0 {
+		input.Tags = c.mergeStackTags(appName, tags)
+	}
+	_, err := c.cloudFormation.UpdateStack(input)
+	if err != nil {
+		// Another operation may start after checking the stack status.
+		if app, appErr := c.GetApp(appName); appErr == nil && StackInProgress(app.Status) {
+			return &StackBusyError{AppName: appName, Status: app.Status}
+		}
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to request for updating stack: " + err.Error())
+	}
+	err = c.cloudFormation.WaitUntilStackUpdateComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(appName),
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to wait stack update: " + err.Error())
+	}
+
+	return nil
+}
+
+// mergeStackTags returns the existing stack tags overridden by the tags.
+// UpdateStack replaces all tags when tags are passed, so other tags must be passed too.
+func (c *Client) mergeStackTags(appName string, tags []*cloudformation.Tag) []*cloudformation.Tag {
+	resp, err := c.cloudFormation.DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(appName),
+	})
+	if err != nil || len(resp.Stacks) == 0 {
+		logrus.WithFields(logrus.Fields{
+			"appName": appName,
+		}).Fatal("Failed to describe stack tags")
+	}
+
+	merged := []*cloudformation.Tag{}
+	overridden := map[string]bool{}
+	for _, tag := range tags {
+		overridden[aws.StringValue(tag.Key)] = true
+	}
+	for _, tag := range resp.Stacks[0].Tags {
+		if !overridden[aws.StringValue(tag.Key)] {
+			merged = append(merged, tag)
+		}
+	}
+
+	return append(merged, tags...)
+}
+
+// previewStackUpdate creates a change set from the template and returns resource changes of it.
+// The change set is always deleted without executing, so this function doesn't update the stack.
+func (c *Client) previewStackUpdate(appName string, template string) ([]*objects.Change, error) {
+	name := changeSetName()
+	_, err := c.cloudFormation.CreateChangeSet(&cloudformation.CreateChangeSetInput{
+		StackName:     aws.String(appName),
+		ChangeSetName: aws.String(name),
+		ChangeSetType: aws.String(cloudformation.ChangeSetTypeUpdate),
+		TemplateBody:  aws.String(template),
+		Capabilities:  []*string{aws.String("CAPABILITY_NAMED_IAM")},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer c.deleteChangeSet(appName, name)
+
+	waitErr := c.cloudFormation.WaitUntilChangeSetCreateComplete(&cloudformation.DescribeChangeSetInput{
+		StackName:     aws.String(appName),
+		ChangeSetName: aws.String(name),
+	})
+
+	changes := []*objects.Change{}
+	var nextToken *string
+	for {
+		resp, err := c.cloudFormation.DescribeChangeSet(&cloudformation.DescribeChangeSetInput{
+			StackName:     aws.String(appName),
+			ChangeSetName: aws.String(name),
+			NextToken:     nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if aws.StringValue(resp.Status) == cloudformation.ChangeSetStatusFailed {
+			// CloudFormation fails to create a change set which doesn't contain changes.
+			if strings.Contains(aws.StringValue(resp.StatusReason), "didn't contain changes") {
+				return []*objects.Change{}, nil
+			}
+			return nil, errors.New(aws.StringValue(resp.StatusReason))
+		}
+		if waitErr != nil {
+			return nil, waitErr
+		}
+
+		for _, change := range resp.Changes {
+			if change.ResourceChange == nil {
+				continue
+			}
+			changes = append(changes, &objects.Change{
+				Action:            aws.StringValue(change.ResourceChange.Action),
+				LogicalResourceID: aws.StringValue(change.ResourceChange.LogicalResourceId),
+				ResourceType:      aws.StringValue(change.ResourceChange.ResourceType),
+				Replacement:       aws.StringValue(change.ResourceChange.Replacement),
+			})
+		}
+
+		if resp.NextToken == nil {
+			break
+		}
+		nextToken = resp.NextToken
+	}
+
+	return changes, nil
+}
+
+// DescribeAppEvents returns the recent events of the application stack in chronological order.
+// It returns only the latest page of events, so callers should poll it to stream events.
+func (c *Client) DescribeAppEvents(appName string) ([]*objects.StackEvent, error) {
+	resp, err := c.cloudFormation.DescribeStackEvents(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(appName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*objects.StackEvent{}
+	// DescribeStackEvents returns events in reverse chronological order.
+	for i := len(resp.StackEvents) - 1; i >= 0; i-- {
+		event := resp.StackEvents[i]
+		events = append(events, &objects.StackEvent{
+			EventID:              aws.StringValue(event.EventId),
+			Timestamp:            aws.TimeValue(event.Timestamp),
+			LogicalResourceID:    aws.StringValue(event.LogicalResourceId),
+			ResourceType:         aws.StringValue(event.ResourceType),
+			ResourceStatus:       aws.StringValue(event.ResourceStatus),
+			ResourceStatusReason: aws.StringValue(event.ResourceStatusReason),
+		})
+	}
+
+	return events, nil
+}
+
+// describeFailures returns resources which failed in the current operation of the stack.
+// Events before the latest operation started are ignored, and the newest failure is used for each resource.
+// Resources cancelled by other failures are excluded because they are not the cause.
+func (c *Client) describeFailures(appName string) ([]*objects.ResourceFailure, error) {
+	events, err := c.DescribeAppEvents(appName)
+	if err != nil {
+		return nil, err
+	}
+	events = currentOperationEvents(appName, events)
+
+	failures := []*objects.ResourceFailure{}
+	seen := map[string]bool{}
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		if !strings.HasSuffix(event.ResourceStatus, "_FAILED") {
+			continue
+		}
+		// The stack itself reports a summary of resource failures.
+		if event.ResourceType == "AWS::CloudFormation::Stack" {
+			continue
+		}
+		if seen[event.LogicalResourceID] {
+			continue
+		}
+		seen[event.LogicalResourceID] = true
+		if strings.Contains(event.ResourceStatusReason, "cancelled") {
+			continue
+		}
+
+		failures = append([]*objects.ResourceFailure{{
+			LogicalResourceID: event.LogicalResourceID,
+			ResourceType:      event.ResourceType,
+			ResourceStatus:    event.ResourceStatus,
+			Reason:            event.ResourceStatusReason,
+			Hint:              failureHint(event.ResourceStatusReason),
+		}}, failures...)
+	}
+
+	return failures, nil
+}
+
+// currentOperationEvents returns events since the latest operation of the stack started.
+// Rollbacks and cleanups are parts of the operation, so they don't start a new operation.
+// If the start is not found in the events, all events are returned.
+func currentOperationEvents(appName string, events []*objects.StackEvent) []*objects.StackEvent {
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		if event.LogicalResourceID != appName || event.ResourceType != "AWS::CloudFormation::Stack" {
+			continue
+		}
+		switch event.ResourceStatus {
+		case "CREATE_IN_PROGRESS", "UPDATE_IN_PROGRESS", "DELETE_IN_PROGRESS":
+			return events[i:]
+		}
+	}
+	return events
+}
+
+// describeStuckResources returns resources whose latest event is the status. e.g. "DELETE_FAILED"
+// Unlike describeFailures, it ignores resources which recovered after the failure.
+func (c *Client) describeStuckResources(appName string, status string) ([]*objects.ResourceFailure, error) {
+	events, err := c.DescribeAppEvents(appName)
+	if err != nil {
+		return nil, err
+	}
+
+	order := []string{}
+	latest := map[string]*objects.StackEvent{}
+	for _, event := range events {
+		if event.ResourceType == "AWS::CloudFormation::Stack" {
+			continue
+		}
+		if _, ok := latest[event.LogicalResourceID]; !ok {
+			order = append(order, event.LogicalResourceID)
+		}
+		latest[event.LogicalResourceID] = event
+	}
+
+	failures := []*objects.ResourceFailure{}
+	for _, id := range order {
+		event := latest[id]
+		if event.ResourceStatus != status {
+			continue
+		}
+		failures = append(failures, &objects.ResourceFailure{
+			LogicalResourceID: event.LogicalResourceID,
+			ResourceType:      event.ResourceType,
+			ResourceStatus:    event.ResourceStatus,
+			Reason:            event.ResourceStatusReason,
+			Hint:              failureHint(event.ResourceStatusReason),
+		})
+	}
+
+	return failures, nil
+}
+
+// failureHint returns advice for known failure reasons. If the reason is unknown, returns empty string.
+func failureHint(reason string) string {
+	lower := strings.ToLower(reason)
+	switch {
+	case strings.Contains(lower, "limit") || strings.Contains(lower, "quota"):
+		return "You may have reached a service limit. Delete unused resources or request a limit increase to AWS support."
+	case strings.Contains(lower, "already exists"):
+		return "A resource with the same name already exists. Use another app name, or delete the conflicting resource."
+	case strings.Contains(lower, "not authorized") || strings.Contains(lower, "accessdenied") || strings.Contains(lower, "access denied"):
+		return "Your AWS credentials don't have enough permissions. Check the IAM policies of your user or role."
+	case strings.Contains(lower, "not empty") || strings.Contains(lower, "notempty") || strings.Contains(lower, "contains images"):
+		return "The resource still has contents. Empty it, and then retry."
+	}
+	return ""
+}
+
+func (c *Client) deleteChangeSet(appName string, name string) {
+	_, err := c.cloudFormation.DeleteChangeSet(&cloudformation.DeleteChangeSetInput{
+		StackName:     aws.String(appName),
+		ChangeSetName: aws.String(name),
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName":       appName,
+			"changeSetName": name,
+		}).Debug("Failed to delete change set: " + err.Error())
+	}
+}
@@ -0,0 +1,286 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestPreviewStackUpdate__noChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	changeSetName = func() string { return "herogate-dry-run-1521378000" }
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to create change set
+	cfnMock.EXPECT().CreateChangeSet(&cloudformation.CreateChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+		ChangeSetType: aws.String("UPDATE"),
+		TemplateBody:  aws.String("Resources: {}\n"),
+		Capabilities:  []*string{aws.String("CAPABILITY_NAMED_IAM")},
+	}).Return(&cloudformation.CreateChangeSetOutput{}, nil)
+	// Expect to wait change set creation and return error
+	cfnMock.EXPECT().WaitUntilChangeSetCreateComplete(&cloudformation.DescribeChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+	}).Return(errors.New("ResourceNotReady: failed waiting for successful resource state"))
+	// Expect to describe the failed change set
+	cfnMock.EXPECT().DescribeChangeSet(&cloudformation.DescribeChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+	}).Return(&cloudformation.DescribeChangeSetOutput{
+		Status:       aws.String("FAILED"),
+		StatusReason: aws.String("The submitted information didn't contain changes. Submit different information to create a change set."),
+	}, nil)
+	// Expect to delete change set
+	cfnMock.EXPECT().DeleteChangeSet(&cloudformation.DeleteChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+	}).Return(&cloudformation.DeleteChangeSetOutput{}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	changes, err := client.previewStackUpdate("young-eyrie-24091", "Resources: {}\n")
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Expected changes are empty, but get `%#v`", changes)
+	}
+}
+
+func TestPreviewStackUpdate__failed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	changeSetName = func() string { return "herogate-dry-run-1521378000" }
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	// Expect to create change set
+	cfnMock.EXPECT().CreateChangeSet(&cloudformation.CreateChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+		ChangeSetType: aws.String("UPDATE"),
+		TemplateBody:  aws.String("Resources: {}\n"),
+		Capabilities:  []*string{aws.String("CAPABILITY_NAMED_IAM")},
+	}).Return(&cloudformation.CreateChangeSetOutput{}, nil)
+	// Expect to wait change set creation and return error
+	cfnMock.EXPECT().WaitUntilChangeSetCreateComplete(&cloudformation.DescribeChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+	}).Return(errors.New("ResourceNotReady: failed waiting for successful resource state"))
+	// Expect to describe the failed change set
+	cfnMock.EXPECT().DescribeChangeSet(&cloudformation.DescribeChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+	}).Return(&cloudformation.DescribeChangeSetOutput{
+		Status:       aws.String("FAILED"),
+		StatusReason: aws.String("Template format error: At least one Resources member must be defined."),
+	}, nil)
+	// Expect to delete change set
+	cfnMock.EXPECT().DeleteChangeSet(&cloudformation.DeleteChangeSetInput{
+		StackName:     aws.String("young-eyrie-24091"),
+		ChangeSetName: aws.String("herogate-dry-run-1521378000"),
+	}).Return(&cloudformation.DeleteChangeSetOutput{}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	_, err := client.previewStackUpdate("young-eyrie-24091", "Resources: {}\n")
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil as error")
+	}
+	if err.Error() != "Template format error: At least one Resources member must be defined." {
+		t.Fatalf("Expected error is `Template format error: At least one Resources member must be defined.`, but get `%s`", err.Error())
+	}
+}
+
+func TestStackOperation(t *testing.T) {
+	cases := []struct {
+		Status   string
+		Expected string
+	}{
+		{
+			Status:   "UPDATE_IN_PROGRESS",
+			Expected: "update",
+		},
+		{
+			Status:   "UPDATE_ROLLBACK_IN_PROGRESS",
+			Expected: "update rollback",
+		},
+		{
+			Status:   "UPDATE_COMPLETE_CLEANUP_IN_PROGRESS",
+			Expected: "update cleanup",
+		},
+		{
+			Status:   "DELETE_IN_PROGRESS",
+			Expected: "delete",
+		},
+	}
+
+	for _, tc := range cases {
+		if !StackInProgress(tc.Status) {
+			t.Fatalf("Expected `%s` is in progress, but it is not", tc.Status)
+		}
+		operation := StackOperation(tc.Status)
+		if operation != tc.Expected {
+			t.Fatalf("Expected operation is `%s`, but get `%s`", tc.Expected, operation)
+		}
+	}
+}
+
+func TestDescribeAppEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	cfnMock.EXPECT().DescribeStackEvents(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStackEventsOutput{
+		StackEvents: []*cloudformation.StackEvent{
+			{
+				EventId:              aws.String("2"),
+				Timestamp:            aws.Time(time.Date(2017, time.December, 1, 0, 0, 10, 0, time.UTC)),
+				LogicalResourceId:    aws.String("HerogateApplicationService"),
+				ResourceType:         aws.String("AWS::ECS::Service"),
+				ResourceStatus:       aws.String("UPDATE_FAILED"),
+				ResourceStatusReason: aws.String("Resource timed out"),
+			},
+			{
+				EventId:           aws.String("1"),
+				Timestamp:         aws.Time(time.Date(2017, time.December, 1, 0, 0, 0, 0, time.UTC)),
+				LogicalResourceId: aws.String("HerogateApplicationService"),
+				ResourceType:      aws.String("AWS::ECS::Service"),
+				ResourceStatus:    aws.String("UPDATE_IN_PROGRESS"),
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	events, err := client.DescribeAppEvents("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := []*objects.StackEvent{
+		{
+			EventID:           "1",
+			Timestamp:         time.Date(2017, time.December, 1, 0, 0, 0, 0, time.UTC),
+			LogicalResourceID: "HerogateApplicationService",
+			ResourceType:      "AWS::ECS::Service",
+			ResourceStatus:    "UPDATE_IN_PROGRESS",
+		},
+		{
+			EventID:              "2",
+			Timestamp:            time.Date(2017, time.December, 1, 0, 0, 10, 0, time.UTC),
+			LogicalResourceID:    "HerogateApplicationService",
+			ResourceType:         "AWS::ECS::Service",
+			ResourceStatus:       "UPDATE_FAILED",
+			ResourceStatusReason: "Resource timed out",
+		},
+	}
+	if !cmp.Equal(expected, events) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, events))
+	}
+}
+
+func TestDescribeFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfnMock := mock.NewMockCloudFormationAPI(ctrl)
+	cfnMock.EXPECT().DescribeStackEvents(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String("young-eyrie-24091"),
+	}).Return(&cloudformation.DescribeStackEventsOutput{
+		StackEvents: []*cloudformation.StackEvent{
+			{
+				EventId:           aws.String("8"),
+				LogicalResourceId: aws.String("young-eyrie-24091"),
+				ResourceType:      aws.String("AWS::CloudFormation::Stack"),
+				ResourceStatus:    aws.String("UPDATE_ROLLBACK_IN_PROGRESS"),
+			},
+			{
+				EventId:              aws.String("7"),
+				LogicalResourceId:    aws.String("HerogateApplicationService"),
+				ResourceType:         aws.String("AWS::ECS::Service"),
+				ResourceStatus:       aws.String("UPDATE_FAILED"),
+				ResourceStatusReason: aws.String("Service arn:aws:ecs:us-east-1:123456789012:service/young-eyrie-24091 did not stabilize."),
+			},
+			{
+				EventId:              aws.String("6"),
+				LogicalResourceId:    aws.String("HerogateApplicationService"),
+				ResourceType:         aws.String("AWS::ECS::Service"),
+				ResourceStatus:       aws.String("UPDATE_FAILED"),
+				ResourceStatusReason: aws.String("Invalid request"),
+			},
+			{
+				EventId:              aws.String("5"),
+				LogicalResourceId:    aws.String("HerogateApplicationContainer"),
+				ResourceType:         aws.String("AWS::ECS::TaskDefinition"),
+				ResourceStatus:       aws.String("UPDATE_FAILED"),
+				ResourceStatusReason: aws.String("Resource update cancelled"),
+			},
+			{
+				EventId:           aws.String("4"),
+				LogicalResourceId: aws.String("young-eyrie-24091"),
+				ResourceType:      aws.String("AWS::CloudFormation::Stack"),
+				ResourceStatus:    aws.String("UPDATE_IN_PROGRESS"),
+			},
+			{
+				EventId:              aws.String("3"),
+				LogicalResourceId:    aws.String("young-eyrie-24091"),
+				ResourceType:         aws.String("AWS::CloudFormation::Stack"),
+				ResourceStatus:       aws.String("UPDATE_ROLLBACK_COMPLETE"),
+				ResourceStatusReason: aws.String("The following resource(s) failed to update: [HerogateLoadBalancer]."),
+			},
+			{
+				EventId:              aws.String("2"),
+				LogicalResourceId:    aws.String("HerogateLoadBalancer"),
+				ResourceType:         aws.String("AWS::ElasticLoadBalancingV2::LoadBalancer"),
+				ResourceStatus:       aws.String("UPDATE_FAILED"),
+				ResourceStatusReason: aws.String("You have reached the limit on the number of load balancers for your account"),
+			},
+			{
+				EventId:           aws.String("1"),
+				LogicalResourceId: aws.String("young-eyrie-24091"),
+				ResourceType:      aws.String("AWS::CloudFormation::Stack"),
+				ResourceStatus:    aws.String("UPDATE_IN_PROGRESS"),
+			},
+		},
+	}, nil)
+
+	client := NewClient(&ClientOption{})
+	client.cloudFormation = cfnMock
+
+	failures, err := client.describeFailures("young-eyrie-24091")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	// Failures of the previous update and older failures of the same resource are ignored
+	reason := "Service arn:aws:ecs:us-east-1:123456789012:service/young-eyrie-24091 did not stabilize."
+	expected := []*objects.ResourceFailure{
+		{
+			LogicalResourceID: "HerogateApplicationService",
+			ResourceType:      "AWS::ECS::Service",
+			ResourceStatus:    "UPDATE_FAILED",
+			Reason:            reason,
+			Hint:              failureHint(reason),
+		},
+	}
+	if !cmp.Equal(expected, failures) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, failures))
+	}
+}
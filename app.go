@@ -25,12 +25,43 @@ func NewApp() *cli.App {
 		command.AppsInfoCommand(),
 		command.AppsOpenCommand(),
 		command.AppsDestroyCommand(),
+		command.AppsRepairCommand(),
+		command.AppsUpgradeCommand(),
+		command.ApprovalsCommand(),
+		command.ApproveCommand(),
+		command.RejectCommand(),
+		command.BuildCommand(),
+		command.BuildSetCommand(),
+		command.BuildDetectCommand(),
+		command.BuildsCommand(),
+		command.BuildsInfoCommand(),
+		command.BuildsOutputCommand(),
+		command.BuildsCancelCommand(),
+		command.CiCommand(),
 		command.ConfigCommand(),
 		command.ConfigGetCommand(),
 		command.ConfigSetCommand(),
 		command.ConfigUnsetCommand(),
+		command.ContainerReleaseCommand(),
+		command.DeployCommand(),
+		command.HealthCheckCommand(),
+		command.HealthCheckSetCommand(),
+		command.ImportComposeCommand(),
+		command.ImportHerokuCommand(),
+		command.LocalCommand(),
+		command.ManifestValidateCommand(),
+		command.PipelineBranchCommand(),
+		command.PipelineApprovalCommand(),
+		command.PipelinesAddCommand(),
+		command.PipelinesPromoteCommand(),
+		command.PortCommand(),
+		command.PortSetCommand(),
+		command.ReleasesCommand(),
+		command.ReviewCreateCommand(),
+		command.ReviewDestroyCommand(),
 		command.PsCommand(),
 		command.LogsCommand(),
+		command.InitCommand(),
 		command.InternalCommand(),
 	}
 
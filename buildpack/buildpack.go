@@ -0,0 +1,229 @@
+package buildpack
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrNotDetected is returned when no buildpacks detect the source.
+var ErrNotDetected = errors.New("No buildpacks detected the app")
+
+// Buildpack detects a language stack from files in the source, and generates a Dockerfile for it.
+// It is a simplified version of Heroku and Cloud Native Buildpacks, and builds the app with the official image.
+type Buildpack struct {
+	Name  string
+	Image string
+	// Files are marker files of the stack. The buildpack detects the source which has any of them.
+	Files []string
+	// version returns the runtime version specified in the source, or an empty string.
+	version func(dir string) string
+	// install returns Dockerfile instructions to install dependencies and build the app.
+	install func(dir string) []string
+	// web returns the command of the web process. It must listen on $PORT.
+	web func(dir string) string
+	// ignore are patterns of files which are not needed in the image.
+	ignore []string
+}
+
+// Buildpacks are supported buildpacks in the order of detection.
+var Buildpacks = []*Buildpack{
+	{
+		Name:  "Ruby",
+		Image: "ruby",
+		Files: []string{"Gemfile"},
+		version: func(dir string) string {
+			return strings.TrimPrefix(readFirstLine(filepath.Join(dir, ".ruby-version")), "ruby-")
+		},
+		install: func(dir string) []string {
+			return []string{
+				"COPY Gemfile* ./",
+				"RUN bundle install --jobs 4 --without development test",
+				"COPY . .",
+			}
+		},
+		web: func(dir string) string {
+			if exists(filepath.Join(dir, "config", "application.rb")) {
+				return "bundle exec rails server -b 0.0.0.0"
+			}
+			return "bundle exec puma"
+		},
+		ignore: []string{".bundle", "log", "tmp"},
+	},
+	{
+		Name:  "Node.js",
+		Image: "node",
+		Files: []string{"package.json"},
+		version: func(dir string) string {
+			return strings.TrimPrefix(readFirstLine(filepath.Join(dir, ".nvmrc")), "v")
+		},
+		install: func(dir string) []string {
+			if exists(filepath.Join(dir, "yarn.lock")) {
+				return []string{
+					"COPY package.json yarn.lock ./",
+					"RUN yarn install --production --frozen-lockfile",
+					"COPY . .",
+				}
+			}
+			return []string{
+				"COPY package*.json ./",
+				"RUN npm install --production",
+				"COPY . .",
+			}
+		},
+		web: func(dir string) string {
+			return "npm start"
+		},
+		ignore: []string{"node_modules", "npm-debug.log", "yarn-error.log"},
+	},
+	{
+		Name:  "Python",
+		Image: "python",
+		Files: []string{"requirements.txt", "Pipfile", "setup.py"},
+		version: func(dir string) string {
+			// runtime.txt is written as "python-3.6.4" like Heroku
+			return strings.TrimPrefix(readFirstLine(filepath.Join(dir, "runtime.txt")), "python-")
+		},
+		install: func(dir string) []string {
+			switch {
+			case exists(filepath.Join(dir, "requirements.txt")):
+				return []string{
+					"COPY requirements.txt ./",
+					"RUN pip install --no-cache-dir -r requirements.txt",
+					"COPY . .",
+				}
+			case exists(filepath.Join(dir, "Pipfile")):
+				return []string{
+					"RUN pip install --no-cache-dir pipenv",
+					"COPY Pipfile* ./",
+					"RUN pipenv install --system --deploy",
+					"COPY . .",
+				}
+			default:
+				return []string{
+					"COPY . .",
+					"RUN pip install --no-cache-dir .",
+				}
+			}
+		},
+		web: func(dir string) string {
+			// gunicorn binds $PORT by default. Django projects have the WSGI module in the project package.
+			if matches, _ := filepath.Glob(filepath.Join(dir, "*", "wsgi.py")); len(matches) > 0 {
+				return fmt.Sprintf("gunicorn %s.wsgi", filepath.Base(filepath.Dir(matches[0])))
+			}
+			return "gunicorn app:app"
+		},
+		ignore: []string{"__pycache__", "*.pyc", ".venv"},
+	},
+	{
+		Name:  "Go",
+		Image: "golang",
+		Files: []string{"go.mod", "Gopkg.toml", "glide.yaml", "*.go"},
+		version: func(dir string) string {
+			file, err := ioutil.ReadFile(filepath.Join(dir, "go.mod"))
+			if err != nil {
+				return ""
+			}
+			if match := goVersionPattern.FindSubmatch(file); match != nil {
+				return string(match[1])
+			}
+			return ""
+		},
+		install: func(dir string) []string {
+			// The main package in the root directory is built as `app` in PATH
+			if exists(filepath.Join(dir, "go.mod")) {
+				return []string{
+					"COPY go.* ./",
+					"RUN go mod download",
+					"COPY . .",
+					"RUN go build -o /usr/local/bin/app .",
+				}
+			}
+			// Without Go modules, dependencies are fetched into GOPATH
+			return []string{
+				"ENV GO111MODULE off",
+				"COPY . .",
+				"RUN go get -d -v ./... && go build -o /usr/local/bin/app .",
+			}
+		},
+		web: func(dir string) string {
+			return "app"
+		},
+		ignore: []string{"*.test"},
+	},
+}
+
+var goVersionPattern = regexp.MustCompile(`(?m)^go (\d+\.\d+)`)
+
+// Detect returns the first buildpack which detects the source in the directory.
+// If no buildpacks detect it, returns ErrNotDetected.
+func Detect(dir string) (*Buildpack, error) {
+	for _, buildpack := range Buildpacks {
+		for _, pattern := range buildpack.Files {
+			matches, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) > 0 {
+				return buildpack, nil
+			}
+		}
+	}
+	return nil, ErrNotDetected
+}
+
+// Version returns the runtime version specified in the source. If it is not specified, returns "latest".
+func (b *Buildpack) Version(dir string) string {
+	if version := b.version(dir); version != "" {
+		return version
+	}
+	return "latest"
+}
+
+// Dockerfile generates a Dockerfile which builds the source in the directory.
+// The command is not specified because processes are defined by Procfile.
+// PORT is set to the port which Herogate routes requests to the web process.
+func (b *Buildpack) Dockerfile(dir string) string {
+	workdir := "/app"
+	if b.Image == "golang" {
+		// Without Go modules, the source must be in GOPATH
+		workdir = "/go/src/app"
+	}
+
+	lines := []string{
+		fmt.Sprintf("# Generated by Herogate for %s app", b.Name),
+		fmt.Sprintf("FROM %s:%s", b.Image, b.Version(dir)),
+		"WORKDIR " + workdir,
+		"ENV PORT 80",
+	}
+	lines = append(lines, b.install(dir)...)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// WebCommand returns the command of the web process for Procfile. The command listens on $PORT.
+func (b *Buildpack) WebCommand(dir string) string {
+	return b.web(dir)
+}
+
+// DockerIgnore returns the content of `.dockerignore` for the stack.
+func (b *Buildpack) DockerIgnore() string {
+	patterns := append([]string{".git", ".env", "Dockerfile.herogate"}, b.ignore...)
+	return strings.Join(patterns, "\n") + "\n"
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func readFirstLine(path string) string {
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.SplitN(string(file), "\n", 2)[0])
+}
@@ -0,0 +1,184 @@
+package buildpack
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Files      map[string]string
+		Expected   string
+		Dockerfile string
+	}{
+		{
+			Name: "Ruby",
+			Files: map[string]string{
+				"Gemfile":       "source 'https://rubygems.org'\n",
+				"package.json":  "{}\n",
+				".ruby-version": "2.5.0\n",
+			},
+			Expected: "Ruby",
+			Dockerfile: `# Generated by Herogate for Ruby app
+FROM ruby:2.5.0
+WORKDIR /app
+ENV PORT 80
+COPY Gemfile* ./
+RUN bundle install --jobs 4 --without development test
+COPY . .
+`,
+		},
+		{
+			Name: "Node.js with yarn",
+			Files: map[string]string{
+				"package.json": "{}\n",
+				"yarn.lock":    "\n",
+			},
+			Expected: "Node.js",
+			Dockerfile: `# Generated by Herogate for Node.js app
+FROM node:latest
+WORKDIR /app
+ENV PORT 80
+COPY package.json yarn.lock ./
+RUN yarn install --production --frozen-lockfile
+COPY . .
+`,
+		},
+		{
+			Name: "Python",
+			Files: map[string]string{
+				"requirements.txt": "flask\n",
+				"runtime.txt":      "python-3.6.4\n",
+			},
+			Expected: "Python",
+			Dockerfile: `# Generated by Herogate for Python app
+FROM python:3.6.4
+WORKDIR /app
+ENV PORT 80
+COPY requirements.txt ./
+RUN pip install --no-cache-dir -r requirements.txt
+COPY . .
+`,
+		},
+		{
+			Name: "Go",
+			Files: map[string]string{
+				"main.go": "package main\n",
+				"go.mod":  "module example.com/app\n\ngo 1.11\n",
+			},
+			Expected: "Go",
+			Dockerfile: `# Generated by Herogate for Go app
+FROM golang:1.11
+WORKDIR /go/src/app
+ENV PORT 80
+COPY go.* ./
+RUN go mod download
+COPY . .
+RUN go build -o /usr/local/bin/app .
+`,
+		},
+		{
+			Name: "Go without modules",
+			Files: map[string]string{
+				"main.go":    "package main\n",
+				"Gopkg.toml": "\n",
+			},
+			Expected: "Go",
+			Dockerfile: `# Generated by Herogate for Go app
+FROM golang:latest
+WORKDIR /go/src/app
+ENV PORT 80
+ENV GO111MODULE off
+COPY . .
+RUN go get -d -v ./... && go build -o /usr/local/bin/app .
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		dir, err := ioutil.TempDir("", "buildpack")
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		defer os.RemoveAll(dir)
+		for name, content := range tc.Files {
+			if err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+		}
+
+		buildpack, err := Detect(dir)
+		if err != nil {
+			t.Fatalf("Unexpected error occurred in %s: %s", tc.Name, err)
+		}
+		if buildpack.Name != tc.Expected {
+			t.Fatalf("Expected buildpack is `%s`, but get `%s` in %s", tc.Expected, buildpack.Name, tc.Name)
+		}
+		if buildpack.Dockerfile(dir) != tc.Dockerfile {
+			t.Fatalf("\nExpected: %s\nActual: %s in %s", tc.Dockerfile, buildpack.Dockerfile(dir), tc.Name)
+		}
+	}
+}
+
+func TestDetect__notDetected(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buildpack")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err = Detect(dir); err != ErrNotDetected {
+		t.Fatalf("Expected error is ErrNotDetected, but get `%v`", err)
+	}
+}
+
+func TestWebCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buildpack")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = os.Mkdir(filepath.Join(dir, "mysite"), 0755); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	for _, name := range []string{"requirements.txt", "manage.py", filepath.Join("mysite", "wsgi.py")} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte("\n"), 0644); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	buildpack, err := Detect(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if buildpack.WebCommand(dir) != "gunicorn mysite.wsgi" {
+		t.Fatalf("Expected web command is `gunicorn mysite.wsgi`, but get `%s`", buildpack.WebCommand(dir))
+	}
+}
+
+func TestWebCommand__go(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buildpack")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	buildpack, err := Detect(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	// The Dockerfile builds the binary into PATH as `app`
+	if buildpack.WebCommand(dir) != "app" {
+		t.Fatalf("Expected web command is `app`, but get `%s`", buildpack.WebCommand(dir))
+	}
+	if !strings.Contains(buildpack.Dockerfile(dir), "go build -o /usr/local/bin/app .") {
+		t.Fatalf("Expected the Dockerfile builds `app`, but get `%s`", buildpack.Dockerfile(dir))
+	}
+}
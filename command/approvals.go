@@ -0,0 +1,45 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// ApprovalsCommand is a command for listing pending approvals.
+func ApprovalsCommand() cli.Command {
+	return cli.Command{
+		Name:   "approvals",
+		Usage:  "display pending approvals in the pipeline",
+		Flags:  sharedFlags(),
+		Action: herogate.Approvals,
+	}
+}
+
+// ApproveCommand is a command for approving the pending approval.
+func ApproveCommand() cli.Command {
+	return cli.Command{
+		Name:   "approve",
+		Usage:  "approve the pending build to deploy",
+		Flags:  append(sharedFlags(), approvalFlags()...),
+		Action: herogate.Approve,
+	}
+}
+
+// RejectCommand is a command for rejecting the pending approval.
+func RejectCommand() cli.Command {
+	return cli.Command{
+		Name:   "reject",
+		Usage:  "reject the pending build not to deploy",
+		Flags:  append(sharedFlags(), approvalFlags()...),
+		Action: herogate.Reject,
+	}
+}
+
+func approvalFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "message, m",
+			Usage: "comment for the approval",
+		},
+	}
+}
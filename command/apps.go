@@ -20,10 +20,29 @@ func AppsCreateCommand() cli.Command {
 		Name:      "apps:create",
 		ShortName: "create",
 		Usage:     "creates a new app",
+		Flags:     appsCreateFlags(),
 		Action:    herogate.AppsCreate,
 	}
 }
 
+func appsCreateFlags() []cli.Flag {
+	return append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "branch",
+			Value: "master",
+			Usage: "branch name deployed by the pipeline",
+		},
+		cli.BoolFlag{
+			Name:  "keep-on-failure",
+			Usage: "keep the stack for debugging when the creation is failed",
+		},
+		cli.BoolFlag{
+			Name:  "from-app-json",
+			Usage: "apply config vars and formation in app.json",
+		},
+	}, verboseFlags()...)
+}
+
 // AppsInfoCommand is a command for showing the app's details.
 func AppsInfoCommand() cli.Command {
 	return cli.Command{
@@ -58,10 +77,56 @@ func AppsDestroyCommand() cli.Command {
 }
 
 func appsDestroyFlags() []cli.Flag {
-	return []cli.Flag{
+	flags := append([]cli.Flag{
 		cli.StringFlag{
 			Name:  "confirm",
 			Usage: "destroy an app without the app name re-typing",
 		},
+	}, waitFlags()...)
+	return append(flags, verboseFlags()...)
+}
+
+// AppsRepairCommand is a command for recovering the app stuck in a failed state.
+func AppsRepairCommand() cli.Command {
+	return cli.Command{
+		Name:   "apps:repair",
+		Usage:  "recover an app stuck in a failed state",
+		Flags:  append(sharedFlags(), waitFlags()...),
+		Action: herogate.AppsRepair,
+	}
+}
+
+// AppsUpgradeCommand is a command for upgrading the app's platform version.
+func AppsUpgradeCommand() cli.Command {
+	return cli.Command{
+		Name:   "apps:upgrade",
+		Usage:  "upgrade an app to the latest platform version",
+		Flags:  append(sharedFlags(), appsUpgradeFlags()...),
+		Action: herogate.AppsUpgrade,
+	}
+}
+
+func appsUpgradeFlags() []cli.Flag {
+	return append([]cli.Flag{
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "upgrade even if it may cause downtime",
+		},
+	}, mutatingFlags()...)
+}
+
+// InitCommand is a command for scaffolding a new project.
+func InitCommand() cli.Command {
+	return cli.Command{
+		Name:      "init",
+		Usage:     "generate Dockerfile, Procfile and .dockerignore for a new project",
+		ArgsUsage: "[name]",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "create",
+				Usage: "create the app without confirmation",
+			},
+		},
+		Action: herogate.Init,
 	}
 }
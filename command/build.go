@@ -0,0 +1,37 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// BuildCommand is a command for displaying build settings.
+func BuildCommand() cli.Command {
+	return cli.Command{
+		Name:   "build",
+		Usage:  "display the build settings for an app",
+		Flags:  sharedFlags(),
+		Action: herogate.Build,
+	}
+}
+
+// BuildSetCommand is a command for changing build settings.
+func BuildSetCommand() cli.Command {
+	return cli.Command{
+		Name:      "build:set",
+		Usage:     "set one or more build settings",
+		ArgsUsage: "[dockerfile=PATH] [target=STAGE] [compute=small|medium|large] [args=NAME,...]",
+		Flags:     append(sharedFlags(), mutatingFlags()...),
+		Action:    herogate.BuildSet,
+	}
+}
+
+// BuildDetectCommand is a command for detecting the buildpack locally.
+func BuildDetectCommand() cli.Command {
+	return cli.Command{
+		Name:      "build:detect",
+		Usage:     "display the buildpack and the Dockerfile generated for an app without Dockerfile",
+		ArgsUsage: "[dir]",
+		Action:    herogate.BuildDetect,
+	}
+}
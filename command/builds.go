@@ -0,0 +1,49 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// BuildsCommand is a command for listing recent builds.
+func BuildsCommand() cli.Command {
+	return cli.Command{
+		Name:   "builds",
+		Usage:  "display recent builds of the pipeline",
+		Flags:  sharedFlags(),
+		Action: herogate.Builds,
+	}
+}
+
+// BuildsInfoCommand is a command for displaying the build details.
+func BuildsInfoCommand() cli.Command {
+	return cli.Command{
+		Name:      "builds:info",
+		Usage:     "display details of a build",
+		ArgsUsage: "<id>",
+		Flags:     sharedFlags(),
+		Action:    herogate.BuildsInfo,
+	}
+}
+
+// BuildsOutputCommand is a command for displaying the complete build log.
+func BuildsOutputCommand() cli.Command {
+	return cli.Command{
+		Name:      "builds:output",
+		Usage:     "display the complete log of a build",
+		ArgsUsage: "<id>",
+		Flags:     sharedFlags(),
+		Action:    herogate.BuildsOutput,
+	}
+}
+
+// BuildsCancelCommand is a command for stopping the running build.
+func BuildsCancelCommand() cli.Command {
+	return cli.Command{
+		Name:      "builds:cancel",
+		Usage:     "stop a running build",
+		ArgsUsage: "<id>",
+		Flags:     sharedFlags(),
+		Action:    herogate.BuildsCancel,
+	}
+}
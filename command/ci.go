@@ -0,0 +1,16 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// CiCommand is a command for listing test runs.
+func CiCommand() cli.Command {
+	return cli.Command{
+		Name:   "ci",
+		Usage:  "display recent test runs in the pipeline",
+		Flags:  sharedFlags(),
+		Action: herogate.Ci,
+	}
+}
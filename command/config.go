@@ -30,7 +30,7 @@ func ConfigSetCommand() cli.Command {
 	return cli.Command{
 		Name:   "config:set",
 		Usage:  "set one or more config vars",
-		Flags:  sharedFlags(),
+		Flags:  append(sharedFlags(), mutatingFlags()...),
 		Action: herogate.ConfigSet,
 	}
 }
@@ -40,7 +40,7 @@ func ConfigUnsetCommand() cli.Command {
 	return cli.Command{
 		Name:   "config:unset",
 		Usage:  "unset one or more config vars",
-		Flags:  sharedFlags(),
+		Flags:  append(sharedFlags(), mutatingFlags()...),
 		Action: herogate.ConfigUnset,
 	}
 }
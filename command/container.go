@@ -0,0 +1,23 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// ContainerReleaseCommand is a command for deploying a pre-built image.
+func ContainerReleaseCommand() cli.Command {
+	return cli.Command{
+		Name:      "container:release",
+		Usage:     "deploy a pre-built Docker image without building",
+		ArgsUsage: "IMAGE",
+		Flags: append(
+			append(append(sharedFlags(), waitFlags()...), verboseFlags()...),
+			cli.BoolFlag{
+				Name:  "push",
+				Usage: "push the local image to the app's registry before deploying",
+			},
+		),
+		Action: herogate.ContainerRelease,
+	}
+}
@@ -0,0 +1,17 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// DeployCommand is a command for deploying the source without Git.
+func DeployCommand() cli.Command {
+	return cli.Command{
+		Name:      "deploy",
+		Usage:     "deploy the directory without Git",
+		ArgsUsage: "[DIR]",
+		Flags:     append(append(sharedFlags(), waitFlags()...), verboseFlags()...),
+		Action:    herogate.Deploy,
+	}
+}
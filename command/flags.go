@@ -1,6 +1,10 @@
 package command
 
-import "github.com/urfave/cli"
+import (
+	"time"
+
+	"github.com/urfave/cli"
+)
 
 func sharedFlags() []cli.Flag {
 	return []cli.Flag{
@@ -10,3 +14,45 @@ func sharedFlags() []cli.Flag {
 		},
 	}
 }
+
+func dryRunFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "preview changes without updating the app",
+		},
+	}
+}
+
+func waitFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:  "wait",
+			Usage: "wait when the app is busy with another operation (default)",
+		},
+		cli.DurationFlag{
+			Name:  "wait-timeout",
+			Value: 10 * time.Minute,
+			Usage: "maximum time to wait when the app is busy with another operation",
+		},
+		cli.BoolFlag{
+			Name:  "no-wait",
+			Usage: "refuse immediately when the app is busy with another operation",
+		},
+	}
+}
+
+func verboseFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:  "verbose",
+			Usage: "stream stack events while updating the app",
+		},
+	}
+}
+
+// mutatingFlags are flags for commands which update the app stack.
+func mutatingFlags() []cli.Flag {
+	flags := append(dryRunFlags(), waitFlags()...)
+	return append(flags, verboseFlags()...)
+}
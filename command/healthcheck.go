@@ -0,0 +1,44 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// HealthCheckCommand is a command for displaying health check settings.
+func HealthCheckCommand() cli.Command {
+	return cli.Command{
+		Name:   "healthcheck",
+		Usage:  "display the health check settings of the web process",
+		Flags:  sharedFlags(),
+		Action: herogate.HealthCheck,
+	}
+}
+
+// HealthCheckSetCommand is a command for changing health check settings.
+func HealthCheckSetCommand() cli.Command {
+	return cli.Command{
+		Name:  "healthcheck:set",
+		Usage: "change the health check settings of the web process",
+		Flags: append(
+			append(sharedFlags(), mutatingFlags()...),
+			cli.StringFlag{
+				Name:  "path",
+				Usage: "path requested by the load balancer (e.g. /healthz)",
+			},
+			cli.IntFlag{
+				Name:  "interval",
+				Usage: "seconds between health checks (6-300)",
+			},
+			cli.IntFlag{
+				Name:  "healthy-threshold",
+				Usage: "consecutive successes required to be healthy (2-10)",
+			},
+			cli.StringFlag{
+				Name:  "command",
+				Usage: "shell command run in the web container as a health check. An empty value removes it",
+			},
+		),
+		Action: herogate.HealthCheckSet,
+	}
+}
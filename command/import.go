@@ -0,0 +1,62 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// ImportHerokuCommand is a command for creating a new app from the configuration of a Heroku app.
+func ImportHerokuCommand() cli.Command {
+	return cli.Command{
+		Name:      "import:heroku",
+		Usage:     "creates a new app from the configuration exported from Heroku",
+		ArgsUsage: "[name]",
+		Flags:     importHerokuFlags(),
+		Action:    herogate.ImportHeroku,
+	}
+}
+
+func importHerokuFlags() []cli.Flag {
+	return append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "config",
+			Usage: "path to the output of `heroku config --json`",
+		},
+		cli.StringFlag{
+			Name:  "formation",
+			Usage: "path to the formation JSON of the Heroku Platform API",
+		},
+		cli.StringFlag{
+			Name:  "domains",
+			Usage: "path to the output of `heroku domains --json`, or a list of hostnames",
+		},
+		cli.StringFlag{
+			Name:  "addons",
+			Usage: "path to the output of `heroku addons --json`",
+		},
+		cli.StringFlag{
+			Name:  "branch",
+			Value: "master",
+			Usage: "branch name deployed by the pipeline",
+		},
+		cli.BoolFlag{
+			Name:  "keep-on-failure",
+			Usage: "keep the stack for debugging when the creation is failed",
+		},
+	}, verboseFlags()...)
+}
+
+// ImportComposeCommand is a command for translating docker-compose.yml to Procfile and config vars.
+func ImportComposeCommand() cli.Command {
+	return cli.Command{
+		Name:  "import:compose",
+		Usage: "translate docker-compose.yml to Procfile and config vars",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "write",
+				Usage: "write Procfile unless it exists",
+			},
+		},
+		Action: herogate.ImportCompose,
+	}
+}
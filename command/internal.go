@@ -12,6 +12,8 @@ func InternalCommand() cli.Command {
 		Hidden: true,
 		Subcommands: []cli.Command{
 			generateTemplateCommand(),
+			testCommandCommand(),
+			buildCommand(),
 		},
 	}
 }
@@ -22,3 +24,17 @@ func generateTemplateCommand() cli.Command {
 		Action: herogate.InternalGenerateTemplate,
 	}
 }
+
+func testCommandCommand() cli.Command {
+	return cli.Command{
+		Name:   "test-command",
+		Action: herogate.InternalTestCommand,
+	}
+}
+
+func buildCommand() cli.Command {
+	return cli.Command{
+		Name:   "build",
+		Action: herogate.InternalBuild,
+	}
+}
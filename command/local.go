@@ -0,0 +1,49 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// LocalCommand is a command for running the app locally.
+func LocalCommand() cli.Command {
+	return cli.Command{
+		Name:      "local",
+		Usage:     "run the app locally from Procfile",
+		ArgsUsage: "[process...]",
+		Flags:     append(sharedFlags(), localFlags()...),
+		Action:    herogate.Local,
+	}
+}
+
+func localFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "procfile, f",
+			Value: "Procfile",
+			Usage: "path to Procfile",
+		},
+		cli.StringFlag{
+			Name:  "env, e",
+			Value: ".env",
+			Usage: "path to the env file",
+		},
+		cli.BoolFlag{
+			Name:  "remote-config",
+			Usage: "use config vars of the app instead of the env file",
+		},
+		cli.IntFlag{
+			Name:  "port, p",
+			Value: 5000,
+			Usage: "port which the web process listens on",
+		},
+		cli.BoolFlag{
+			Name:  "docker",
+			Usage: "run processes in the image built from Dockerfile",
+		},
+		cli.StringFlag{
+			Name:  "image",
+			Usage: "run processes in the image instead of building it",
+		},
+	}
+}
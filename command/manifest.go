@@ -0,0 +1,16 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// ManifestValidateCommand is a command for validating herogate.yml locally.
+func ManifestValidateCommand() cli.Command {
+	return cli.Command{
+		Name:      "manifest:validate",
+		Usage:     "validate herogate.yml against the schema and Procfile",
+		ArgsUsage: "[dir]",
+		Action:    herogate.ManifestValidate,
+	}
+}
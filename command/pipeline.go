@@ -0,0 +1,56 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// PipelineBranchCommand is a command for displaying or changing the deploy branch.
+func PipelineBranchCommand() cli.Command {
+	return cli.Command{
+		Name:      "pipeline:branch",
+		Usage:     "display or change the branch deployed by the pipeline",
+		ArgsUsage: "[BRANCH]",
+		Flags:     append(append(sharedFlags(), waitFlags()...), verboseFlags()...),
+		Action:    herogate.PipelineBranch,
+	}
+}
+
+// PipelinesAddCommand is a command for adding the app to a pipeline.
+func PipelinesAddCommand() cli.Command {
+	return cli.Command{
+		Name:      "pipelines:add",
+		Usage:     "add the app to a pipeline",
+		ArgsUsage: "PIPELINE",
+		Flags: append(
+			append(append(sharedFlags(), waitFlags()...), verboseFlags()...),
+			cli.StringFlag{
+				Name:  "stage, s",
+				Usage: "stage of the app in the pipeline (e.g. staging, production)",
+			},
+		),
+		Action: herogate.PipelinesAdd,
+	}
+}
+
+// PipelinesPromoteCommand is a command for promoting the image to the next stage.
+func PipelinesPromoteCommand() cli.Command {
+	return cli.Command{
+		Name:      "pipelines:promote",
+		Usage:     "deploy the image running in SOURCE app to TARGET app without rebuilding",
+		ArgsUsage: "SOURCE TARGET",
+		Flags:     append(waitFlags(), verboseFlags()...),
+		Action:    herogate.PipelinesPromote,
+	}
+}
+
+// PipelineApprovalCommand is a command for displaying or changing manual approval.
+func PipelineApprovalCommand() cli.Command {
+	return cli.Command{
+		Name:      "pipeline:approval",
+		Usage:     "display or change whether the pipeline requires manual approval before deploying",
+		ArgsUsage: "[on|off]",
+		Flags:     append(append(sharedFlags(), waitFlags()...), verboseFlags()...),
+		Action:    herogate.PipelineApproval,
+	}
+}
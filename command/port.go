@@ -0,0 +1,27 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// PortCommand is a command for displaying the port of the web process.
+func PortCommand() cli.Command {
+	return cli.Command{
+		Name:   "port",
+		Usage:  "display the port which the web process listens on",
+		Flags:  sharedFlags(),
+		Action: herogate.Port,
+	}
+}
+
+// PortSetCommand is a command for changing the port of the web process.
+func PortSetCommand() cli.Command {
+	return cli.Command{
+		Name:      "port:set",
+		Usage:     "change the port which the web process listens on and restart the app",
+		ArgsUsage: "PORT",
+		Flags:     append(sharedFlags(), mutatingFlags()...),
+		Action:    herogate.PortSet,
+	}
+}
@@ -0,0 +1,16 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// ReleasesCommand is a command for displaying the release history.
+func ReleasesCommand() cli.Command {
+	return cli.Command{
+		Name:   "releases",
+		Usage:  "display the releases of an app",
+		Flags:  sharedFlags(),
+		Action: herogate.Releases,
+	}
+}
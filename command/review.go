@@ -0,0 +1,28 @@
+package command
+
+import (
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/herogate"
+)
+
+// ReviewCreateCommand is a command for creating a review app.
+func ReviewCreateCommand() cli.Command {
+	return cli.Command{
+		Name:      "review:create",
+		Usage:     "create a review app which deploys the branch",
+		ArgsUsage: "BRANCH",
+		Flags:     append(sharedFlags(), verboseFlags()...),
+		Action:    herogate.ReviewCreate,
+	}
+}
+
+// ReviewDestroyCommand is a command for destroying a review app.
+func ReviewDestroyCommand() cli.Command {
+	return cli.Command{
+		Name:      "review:destroy",
+		Usage:     "destroy the review app of the branch",
+		ArgsUsage: "BRANCH",
+		Flags:     append(sharedFlags(), verboseFlags()...),
+		Action:    herogate.ReviewDestroy,
+	}
+}
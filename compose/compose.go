@@ -0,0 +1,197 @@
+package compose
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/olebedev/config"
+)
+
+// FileName is the file name of Docker Compose in the root of the repository.
+const FileName = "docker-compose.yml"
+
+// Compose is a subset of `docker-compose.yml` which Herogate understands.
+type Compose struct {
+	Services map[string]*Service
+}
+
+// Service is a service in `docker-compose.yml`.
+// Environment is merged from `env_file` and `environment`, and Inherited are variables whose values are taken from the host.
+type Service struct {
+	Build       bool
+	Image       string
+	Command     string
+	Environment map[string]string
+	Inherited   []string
+	EnvFiles    []string
+	Ports       []int
+}
+
+// Load reads `docker-compose.yml` and env files of services in the directory.
+func Load(dir string) (*Compose, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not found", FileName)
+	}
+	compose, err := Parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range compose.serviceNames() {
+		service := compose.Services[name]
+		// Variables in `environment` take precedence over env files
+		environment := map[string]string{}
+		for _, path := range service.EnvFiles {
+			file, err := ioutil.ReadFile(filepath.Join(dir, path))
+			if err != nil {
+				return nil, fmt.Errorf("Failed to read env_file of %s: %s", name, err.Error())
+			}
+			for key, value := range ParseEnvFile(string(file)) {
+				environment[key] = value
+			}
+		}
+		for key, value := range service.Environment {
+			environment[key] = value
+		}
+		service.Environment = environment
+	}
+	return compose, nil
+}
+
+// Parse parses `docker-compose.yml`. Env files are not read.
+func Parse(data string) (*Compose, error) {
+	cfg, err := config.ParseYaml(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %s", FileName, err.Error())
+	}
+	services, err := cfg.Map("services")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: services are not found", FileName)
+	}
+
+	compose := &Compose{Services: map[string]*Service{}}
+	for name, value := range services {
+		settings, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Failed to parse %s: services.%s must be a mapping", FileName, name)
+		}
+		service := &Service{Environment: map[string]string{}}
+		_, service.Build = settings["build"]
+		service.Image, _ = settings["image"].(string)
+
+		switch command := settings["command"].(type) {
+		case nil:
+		case string:
+			service.Command = command
+		case []interface{}:
+			service.Command = joinCommand(command)
+		default:
+			return nil, fmt.Errorf("Failed to parse %s: services.%s.command must be a string or a list", FileName, name)
+		}
+
+		switch environment := settings["environment"].(type) {
+		case nil:
+		case []interface{}:
+			for _, item := range environment {
+				pair := strings.SplitN(fmt.Sprint(item), "=", 2)
+				if len(pair) == 1 {
+					service.Inherited = append(service.Inherited, pair[0])
+					continue
+				}
+				service.Environment[pair[0]] = pair[1]
+			}
+		case map[string]interface{}:
+			for key, value := range environment {
+				if value == nil {
+					service.Inherited = append(service.Inherited, key)
+					continue
+				}
+				service.Environment[key] = fmt.Sprint(value)
+			}
+		default:
+			return nil, fmt.Errorf("Failed to parse %s: services.%s.environment must be a list or a mapping", FileName, name)
+		}
+		sort.Strings(service.Inherited)
+
+		switch envFile := settings["env_file"].(type) {
+		case nil:
+		case string:
+			service.EnvFiles = []string{envFile}
+		case []interface{}:
+			for _, path := range envFile {
+				service.EnvFiles = append(service.EnvFiles, fmt.Sprint(path))
+			}
+		default:
+			return nil, fmt.Errorf("Failed to parse %s: services.%s.env_file must be a string or a list", FileName, name)
+		}
+
+		if ports, ok := settings["ports"].([]interface{}); ok {
+			for _, port := range ports {
+				if containerPort := parseContainerPort(fmt.Sprint(port)); containerPort != 0 {
+					service.Ports = append(service.Ports, containerPort)
+				}
+			}
+		}
+
+		compose.Services[name] = service
+	}
+	return compose, nil
+}
+
+// ParseEnvFile parses env files like `.env`. Each line is `KEY=VALUE`, and empty lines and comments are ignored.
+// Quotes surrounding the value are removed.
+func ParseEnvFile(data string) map[string]string {
+	env := map[string]string{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		pair := strings.SplitN(line, "=", 2)
+		if len(pair) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(pair[1])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		env[strings.TrimSpace(pair[0])] = value
+	}
+	return env
+}
+
+func (c *Compose) serviceNames() []string {
+	names := []string{}
+	for name := range c.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseContainerPort returns the container port of the port mapping like `8080:3000/tcp`. It returns 0 for a port range.
+func parseContainerPort(mapping string) int {
+	parts := strings.Split(strings.Split(mapping, "/")[0], ":")
+	var port int
+	if _, err := fmt.Sscanf(parts[len(parts)-1], "%d", &port); err != nil || strings.Contains(parts[len(parts)-1], "-") {
+		return 0
+	}
+	return port
+}
+
+func joinCommand(command []interface{}) string {
+	parts := []string{}
+	for _, part := range command {
+		part := fmt.Sprint(part)
+		if strings.ContainsAny(part, " \t\"'") {
+			part = fmt.Sprintf("%q", part)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,161 @@
+package compose
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		FileName: `version: "3"
+services:
+  app:
+    build: .
+    command: bundle exec rails server -b 0.0.0.0
+    ports:
+      - "3000:3000"
+    env_file: .env
+    environment:
+      - RAILS_ENV=development
+      - SECRET_KEY_BASE
+  db:
+    image: postgres:10
+`,
+		".env": "# Comment\nRAILS_ENV=production\nexport API_KEY=\"secret value\"\n",
+	}
+	for name, content := range files {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	compose, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := &Compose{
+		Services: map[string]*Service{
+			"app": {
+				Build:   true,
+				Command: "bundle exec rails server -b 0.0.0.0",
+				Environment: map[string]string{
+					"RAILS_ENV": "development",
+					"API_KEY":   "secret value",
+				},
+				Inherited: []string{"SECRET_KEY_BASE"},
+				EnvFiles:  []string{".env"},
+				Ports:     []int{3000},
+			},
+			"db": {
+				Image:       "postgres:10",
+				Environment: map[string]string{},
+			},
+		},
+	}
+	if !cmp.Equal(expected, compose) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, compose))
+	}
+}
+
+func TestPlan(t *testing.T) {
+	compose, err := Parse(`services:
+  app:
+    build: .
+    command: ["bundle", "exec", "puma", "-C", "config/puma.rb"]
+    ports: ["8080:3000/tcp"]
+    environment:
+      RAILS_ENV: production
+      PORT: 3000
+      DATABASE_URL: postgres://postgres@db:5432/app
+      REDIS_HOST: cache
+  worker:
+    build:
+      context: .
+    command: bundle exec sidekiq
+    environment:
+      RAILS_ENV: development
+      QUEUE: default
+  assets:
+    build: .
+  cache:
+    image: bitnami/redis:5.0
+  db:
+    image: postgres
+  mail:
+    image: mailhog/mailhog
+`)
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := &Plan{
+		Procfile: map[string]string{
+			"web":    "bundle exec puma -C config/puma.rb",
+			"worker": "bundle exec sidekiq",
+		},
+		Addons: []*Addon{
+			{Service: "cache", Image: "bitnami/redis:5.0", Name: "Redis", EnvVar: "REDIS_URL"},
+			{Service: "db", Image: "postgres", Name: "PostgreSQL", EnvVar: "DATABASE_URL"},
+		},
+		EnvVars: map[string]string{
+			"QUEUE": "default",
+		},
+		Port: 3000,
+		Skipped: []string{
+			"service mail: image `mailhog/mailhog` is not built from the app, declare it in herogate.yml as a sidecar",
+			"config var DATABASE_URL: it points to the service db, set the URL of the add-on instead",
+			"config var PORT: it is set by Herogate, run `herogate port:set PORT` instead",
+			"config var REDIS_HOST: it points to the service cache, set the URL of the add-on instead",
+			"service assets: command is not declared, add the assets process to Procfile",
+			"config var RAILS_ENV: services have different values, run `herogate config:set RAILS_ENV=...`",
+		},
+	}
+	plan := compose.Plan()
+	if !cmp.Equal(expected, plan) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, plan))
+	}
+}
+
+func TestParse__invalid(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Compose  string
+		Expected string
+	}{
+		{
+			Name:     "no services",
+			Compose:  "version: \"3\"\n",
+			Expected: "Failed to parse docker-compose.yml: services are not found",
+		},
+		{
+			Name:     "invalid command",
+			Compose:  "services:\n  app:\n    command:\n      sh: -c\n",
+			Expected: "Failed to parse docker-compose.yml: services.app.command must be a string or a list",
+		},
+		{
+			Name:     "invalid environment",
+			Compose:  "services:\n  app:\n    environment: RAILS_ENV\n",
+			Expected: "Failed to parse docker-compose.yml: services.app.environment must be a list or a mapping",
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := Parse(tc.Compose)
+		if err == nil {
+			t.Fatalf("Expected error is not nil, but get nil in %s", tc.Name)
+		}
+		if err.Error() != tc.Expected {
+			t.Fatalf("Expected error is `%s`, but get `%s` in %s", tc.Expected, err.Error(), tc.Name)
+		}
+	}
+}
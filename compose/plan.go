@@ -0,0 +1,175 @@
+package compose
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Addon is a backing service which runs as a container in Docker Compose, and must be provisioned outside Herogate.
+type Addon struct {
+	Service string
+	Image   string
+	Name    string
+	EnvVar  string
+}
+
+// knownAddons map images to backing services and the config var which apps usually read the URL from.
+var knownAddons = []*Addon{
+	{Image: "postgres", Name: "PostgreSQL", EnvVar: "DATABASE_URL"},
+	{Image: "redis", Name: "Redis", EnvVar: "REDIS_URL"},
+}
+
+// Plan is the translation of `docker-compose.yml` to Herogate.
+// Procfile maps process names to commands, and Port is the port of the web process.
+// Skipped are messages about settings which cannot be translated.
+type Plan struct {
+	Procfile map[string]string
+	Addons   []*Addon
+	EnvVars  map[string]string
+	Port     int
+	Skipped  []string
+}
+
+// Plan translates services in `docker-compose.yml` to Herogate.
+// Services with a build context become processes, and services of known images become add-ons.
+// If there is no `web` service, the only process which publishes ports becomes the web process.
+// Config vars of processes are merged, except for ones which point to other services.
+func (c *Compose) Plan() *Plan {
+	plan := &Plan{
+		Procfile: map[string]string{},
+		Addons:   []*Addon{},
+		EnvVars:  map[string]string{},
+		Skipped:  []string{},
+	}
+
+	processes := []string{}
+	published := []string{}
+	for _, name := range c.serviceNames() {
+		service := c.Services[name]
+		if addon := findAddon(service.Image); addon != nil && !service.Build {
+			plan.Addons = append(plan.Addons, &Addon{Service: name, Image: service.Image, Name: addon.Name, EnvVar: addon.EnvVar})
+			continue
+		}
+		if !service.Build {
+			plan.Skipped = append(plan.Skipped, fmt.Sprintf("service %s: image `%s` is not built from the app, declare it in herogate.yml as a sidecar", name, service.Image))
+			continue
+		}
+		processes = append(processes, name)
+		if len(service.Ports) > 0 {
+			published = append(published, name)
+		}
+	}
+
+	web := ""
+	if contains(processes, "web") {
+		web = "web"
+	} else if len(published) == 1 {
+		web = published[0]
+	}
+	if web != "" && len(c.Services[web].Ports) > 0 {
+		plan.Port = c.Services[web].Ports[0]
+	}
+
+	skippedEnvVars := map[string]bool{}
+	skipEnvVar := func(name string, format string, args ...interface{}) {
+		if !skippedEnvVars[name] {
+			skippedEnvVars[name] = true
+			plan.Skipped = append(plan.Skipped, fmt.Sprintf("config var %s: ", name)+fmt.Sprintf(format, args...))
+		}
+		delete(plan.EnvVars, name)
+	}
+	for _, name := range processes {
+		service := c.Services[name]
+		process := name
+		if name == web {
+			process = "web"
+		}
+		if service.Command == "" {
+			plan.Skipped = append(plan.Skipped, fmt.Sprintf("service %s: command is not declared, add the %s process to Procfile", name, process))
+		} else {
+			plan.Procfile[process] = service.Command
+		}
+
+		for _, key := range sortedKeys(service.Environment) {
+			value := service.Environment[key]
+			if skippedEnvVars[key] {
+				continue
+			}
+			if key == "PORT" {
+				skipEnvVar(key, "it is set by Herogate, run `herogate port:set PORT` instead")
+				continue
+			}
+			if target := c.referencedService(value); target != "" {
+				skipEnvVar(key, "it points to the service %s, set the URL of the add-on instead", target)
+				continue
+			}
+			if current, ok := plan.EnvVars[key]; ok && current != value {
+				skipEnvVar(key, "services have different values, run `herogate config:set %s=...`", key)
+				continue
+			}
+			plan.EnvVars[key] = value
+		}
+		for _, key := range service.Inherited {
+			if _, ok := plan.EnvVars[key]; !ok {
+				skipEnvVar(key, "the value is taken from the host, run `herogate config:set %s=...`", key)
+			}
+		}
+	}
+
+	return plan
+}
+
+// ProcfileContent returns the processes in the format of Procfile.
+func (p *Plan) ProcfileContent() string {
+	content := ""
+	for _, name := range sortedKeys(p.Procfile) {
+		content += fmt.Sprintf("%s: %s\n", name, p.Procfile[name])
+	}
+	return content
+}
+
+// referencedService returns the name of the service which the value points to, like `postgres://db:5432/app` or `db:5432`.
+func (c *Compose) referencedService(value string) string {
+	host := value
+	if u, err := url.Parse(value); err == nil && u.Host != "" {
+		host = u.Hostname()
+	} else {
+		host = strings.Split(value, ":")[0]
+	}
+	if _, ok := c.Services[host]; ok {
+		return host
+	}
+	return ""
+}
+
+// findAddon returns the known add-on of the image like `postgres:10` or `bitnami/redis`.
+func findAddon(image string) *Addon {
+	name := image[strings.LastIndex(image, "/")+1:]
+	name = strings.Split(name, ":")[0]
+	for _, addon := range knownAddons {
+		if addon.Image == name {
+			return addon
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
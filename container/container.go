@@ -1,5 +1,7 @@
 package container
 
+import "strconv"
+
 // Definition is a CFn resource type in Herogate.
 // See https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-properties-ecs-taskdefinition-containerdefinitions.html
 type Definition struct {
@@ -9,6 +11,13 @@ type Definition struct {
 	Environment      []interface{}     `yaml:"Environment"` // Use `config.List()` value directly
 	PortMappings     []*PortMapping    `yaml:"PortMappings"`
 	LogConfiguration *LogConfiguration `yaml:"LogConfiguration"`
+	HealthCheck      *HealthCheck      `yaml:"HealthCheck,omitempty"`
+	CPU              int               `yaml:"Cpu,omitempty"`
+	Memory           int               `yaml:"Memory,omitempty"`
+	Essential        *bool             `yaml:"Essential,omitempty"`
+	StopTimeout      int               `yaml:"StopTimeout,omitempty"`
+	DependsOn        []*Dependency     `yaml:"DependsOn,omitempty"`
+	DockerLabels     map[string]string `yaml:"DockerLabels,omitempty"`
 }
 
 // LogConfiguration is a CFn resource type in Herogate.
@@ -31,19 +40,53 @@ type PortMapping struct {
 	ContainerPort int `yaml:"ContainerPort"`
 }
 
+// HealthCheck is a CFn resource type in Herogate.
+// See https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-properties-ecs-taskdefinition-healthcheck.html
+type HealthCheck struct {
+	Command     []string `yaml:"Command"`
+	Interval    int      `yaml:"Interval,omitempty"`
+	Timeout     int      `yaml:"Timeout,omitempty"`
+	Retries     int      `yaml:"Retries,omitempty"`
+	StartPeriod int      `yaml:"StartPeriod,omitempty"`
+}
+
+// NewHealthCheck initializes health check resource type for CFn by the shell command.
+// The command is run with the shell in the container, so it can refer to PORT.
+func NewHealthCheck(command string, interval int) *HealthCheck {
+	return &HealthCheck{
+		Command:  []string{"CMD-SHELL", command},
+		Interval: interval,
+	}
+}
+
+// Dependency is a CFn resource type in Herogate.
+// See https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-properties-ecs-taskdefinition-containerdependency.html
+type Dependency struct {
+	ContainerName string `yaml:"ContainerName"`
+	Condition     string `yaml:"Condition"`
+}
+
 // RefObject is a meta object for CFn template.
 type RefObject struct {
 	Ref string `yaml:"Ref"`
 }
 
+// SidecarLabel is the Docker label which marks sidecars. Sidecars are declared only in herogate.yml,
+// so they are not processes of the app and keep their own images across releases.
+const SidecarLabel = "herogate.sidecar"
+
+// DefaultPort is the port which the web process listens on unless the app sets it.
+const DefaultPort = 80
+
 // New initializes container definition resource type for CFn by attributes.
 // You can generate CFn template using `config.Set()`.
-func New(name string, image string, command []string, environment []interface{}) *Definition {
+// PORT is injected to the environment like Heroku, and the web process receives requests on the port.
+func New(name string, image string, command []string, environment []interface{}, port int) *Definition {
 	definition := &Definition{
 		Name:        name,
 		Image:       image,
 		Command:     command,
-		Environment: environment,
+		Environment: WithPort(environment, port),
 		LogConfiguration: &LogConfiguration{
 			LogDriver: "awslogs",
 			Options: &LogConfigurationOptions{
@@ -56,9 +99,33 @@ func New(name string, image string, command []string, environment []interface{})
 
 	if name == "web" {
 		definition.PortMappings = append(definition.PortMappings, &PortMapping{
-			ContainerPort: 80,
+			ContainerPort: port,
 		})
 	}
 
 	return definition
 }
+
+// WithPort returns a copy of the environment whose PORT is replaced with the port.
+func WithPort(environment []interface{}, port int) []interface{} {
+	result := []interface{}{}
+	for _, env := range environment {
+		var name interface{}
+		switch e := env.(type) {
+		case map[string]string:
+			name = e["Name"]
+		case map[string]interface{}:
+			name = e["Name"]
+		case map[interface{}]interface{}:
+			name = e["Name"]
+		}
+		if name == "PORT" {
+			continue
+		}
+		result = append(result, env)
+	}
+	return append(result, map[string]string{
+		"Name":  "PORT",
+		"Value": strconv.Itoa(port),
+	})
+}
@@ -21,6 +21,7 @@ func TestNew(t *testing.T) {
 				"Value": "production",
 			},
 		},
+		80,
 	)
 
 	cfg, err := config.ParseYaml("ContainerDefinitions: []")
@@ -50,6 +51,8 @@ func TestNew(t *testing.T) {
     Value: production
   - Name: RACK_ENV
     Value: production
+  - Name: PORT
+    Value: "80"
   PortMappings: []
   LogConfiguration:
     LogDriver: awslogs
@@ -80,7 +83,12 @@ func TestNew__web(t *testing.T) {
 				"Name":  "RACK_ENV",
 				"Value": "production",
 			},
+			map[string]string{
+				"Name":  "PORT",
+				"Value": "80",
+			},
 		},
+		5000,
 	)
 
 	cfg, err := config.ParseYaml("ContainerDefinitions: []")
@@ -110,8 +118,10 @@ func TestNew__web(t *testing.T) {
     Value: production
   - Name: RACK_ENV
     Value: production
+  - Name: PORT
+    Value: "5000"
   PortMappings:
-  - ContainerPort: 80
+  - ContainerPort: 5000
   LogConfiguration:
     LogDriver: awslogs
     Options:
@@ -0,0 +1,134 @@
+package herogate
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+)
+
+type approvalsContext struct {
+	name   string
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// Approvals displays pending approvals in the pipeline of the app.
+func Approvals(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processApprovals(&approvalsContext{
+		name: name,
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processApprovals(ctx *approvalsContext) error {
+	approvals, err := ctx.client.DescribeApprovals(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	if len(approvals) == 0 {
+		fmt.Fprintf(ctx.app.Writer, "%s has no pending approvals.\n", appStr)
+		return nil
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "=== %s Pending Approvals\n", appStr)
+	for _, approval := range approvals {
+		fmt.Fprintf(ctx.app.Writer, "Revision:     %s\n", approval.Revision)
+		if approval.Summary != "" {
+			fmt.Fprintf(ctx.app.Writer, "Summary:      %s\n", approval.Summary)
+		}
+		fmt.Fprintf(ctx.app.Writer, "Image Tag:    %s\n", approval.ImageTag)
+		fmt.Fprintf(ctx.app.Writer, "Requested At: %s\n", approval.RequestedAt.Local().Format("2006/01/02 15:04:05"))
+		fmt.Fprint(ctx.app.Writer, "\n")
+	}
+	fmt.Fprintf(
+		ctx.app.Writer,
+		"Run %s or %s to resolve it\n",
+		color.New(color.FgCyan).Sprint("herogate approve"),
+		color.New(color.FgCyan).Sprint("herogate reject"),
+	)
+
+	return nil
+}
+
+type resolveApprovalContext struct {
+	name     string
+	approved bool
+	message  string
+	app      *cli.App
+	client   iface.ClientInterface
+}
+
+// Approve approves the pending approval, and the pipeline deploys the build.
+func Approve(ctx *cli.Context) error {
+	return resolveApproval(ctx, true)
+}
+
+// Reject rejects the pending approval, and the pipeline stops.
+func Reject(ctx *cli.Context) error {
+	return resolveApproval(ctx, false)
+}
+
+func resolveApproval(ctx *cli.Context, approved bool) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processResolveApproval(&resolveApprovalContext{
+		name:     name,
+		approved: approved,
+		message:  ctx.String("message"),
+		app:      ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processResolveApproval(ctx *resolveApprovalContext) error {
+	if _, err := ctx.client.GetApp(ctx.name); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	action := "Rejecting"
+	if ctx.approved {
+		action = "Approving"
+	}
+	fmt.Fprintf(ctx.app.Writer, "%s the pending build of %s...\r", action, appStr)
+
+	approval, err := ctx.client.ResolveApproval(ctx.name, ctx.approved, ctx.message)
+	if err == api.ErrNoPendingApproval {
+		fmt.Fprintf(ctx.app.Writer, "%s the pending build of %s... skipped\n", action, appStr)
+		return cli.NewExitError(fmt.Sprintf("%s    %s has no pending approvals.", color.New(color.FgRed).Sprint("▸"), appStr), 1)
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to resolve the approval: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "%s the pending build of %s... done, %s\n", action, appStr, approval.ImageTag)
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessApprovals__noPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to describe approvals
+	client.EXPECT().DescribeApprovals("young-eyrie-24091").Return([]*objects.Approval{}, nil)
+
+	err := processApprovals(&approvalsContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := fmt.Sprintf("%s has no pending approvals.\n", color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"))
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessResolveApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to approve
+	client.EXPECT().ResolveApproval("young-eyrie-24091", true, "LGTM").Return(&objects.Approval{
+		Revision: "c9f1a2b3d4e5f60718293a4b5c6d7e8f90a1b2c3",
+		ImageTag: "c9f1a2b3",
+	}, nil)
+
+	err := processResolveApproval(&resolveApprovalContext{
+		name:     "young-eyrie-24091",
+		approved: true,
+		message:  "LGTM",
+		app:      app,
+		client:   client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf(
+		"Approving the pending build of %s...\rApproving the pending build of %s... done, c9f1a2b3\n",
+		appStr, appStr,
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessResolveApproval__noPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to reject, but no approvals are pending
+	client.EXPECT().ResolveApproval("young-eyrie-24091", false, "").Return(nil, api.ErrNoPendingApproval)
+
+	err := processResolveApproval(&resolveApprovalContext{
+		name:     "young-eyrie-24091",
+		approved: false,
+		app:      app,
+		client:   client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf("%s    %s has no pending approvals.", color.New(color.FgRed).Sprint("▸"), appStr)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
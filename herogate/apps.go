@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"regexp"
@@ -17,6 +18,8 @@ import (
 	"github.com/urfave/cli"
 	"github.com/wata727/herogate/api"
 	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/api/options"
+	"github.com/wata727/herogate/heroku"
 	git "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/config"
 )
@@ -30,7 +33,7 @@ type appsContext struct {
 func Apps(ctx *cli.Context) {
 	processApps(&appsContext{
 		app: ctx.App,
-		client: api.NewClient(&api.ClientOption{
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
@@ -41,22 +44,45 @@ func processApps(ctx *appsContext) {
 
 	fmt.Fprintln(ctx.app.Writer, "=== Apps")
 
+	names := map[string]bool{}
+	reviewApps := map[string][]string{}
 	for _, app := range apps {
+		names[app.Name] = true
+		if app.Parent != "" {
+			reviewApps[app.Parent] = append(reviewApps[app.Parent], app.Name)
+		}
+	}
+
+	for _, app := range apps {
+		// Review apps are displayed under the parent
+		if app.Parent != "" && names[app.Parent] {
+			continue
+		}
 		fmt.Fprintln(ctx.app.Writer, app.Name)
+		for _, name := range reviewApps[app.Name] {
+			fmt.Fprintf(ctx.app.Writer, "  %s %s\n", name, color.New(color.FgCyan).Sprint("(review)"))
+		}
 	}
 
 	fmt.Fprint(ctx.app.Writer, "\n")
 }
 
 type appsCreateContext struct {
-	name   string
-	app    *cli.App
-	client iface.ClientInterface
+	name          string
+	branch        string
+	parent        string
+	verbose       bool
+	keepOnFailure bool
+	plan          *heroku.Plan
+	planSource    string
+	app           *cli.App
+	client        iface.ClientInterface
 }
 
 type appsCreateOutput struct {
 	repository string
 	endpoint   string
+	err        error
 }
 
 var progressCheckInterval = 10 * time.Second
@@ -64,6 +90,7 @@ var progressCheckInterval = 10 * time.Second
 // AppsCreate creates a new app with application name provided from CLI.
 // If application name is not provided, This action creates Heroku-like
 // random application name.
+// When `--from-app-json` is specified, config vars and formation in `app.json` are applied at creation.
 func AppsCreate(ctx *cli.Context) error {
 	name := ctx.Args().First()
 	if name == "" {
@@ -71,10 +98,28 @@ func AppsCreate(ctx *cli.Context) error {
 		name = haikunator.Haikunate()
 	}
 
+	var plan *heroku.Plan
+	if ctx.Bool("from-app-json") {
+		file, err := ioutil.ReadFile(heroku.AppJSONFileName)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    %s is not found.", color.New(color.FgRed).Sprint("▸"), heroku.AppJSONFileName), 1)
+		}
+		appJSON, err := heroku.ParseAppJSON(file)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+		plan = appJSON.Plan()
+	}
+
 	return processAppsCreate(&appsCreateContext{
-		name: name,
-		app:  ctx.App,
-		client: api.NewClient(&api.ClientOption{
+		name:          name,
+		branch:        ctx.String("branch"),
+		verbose:       ctx.Bool("verbose"),
+		keepOnFailure: ctx.Bool("keep-on-failure"),
+		plan:          plan,
+		planSource:    heroku.AppJSONFileName,
+		app:           ctx.App,
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
@@ -85,25 +130,50 @@ func processAppsCreate(ctx *appsCreateContext) error {
 		return cli.NewExitError(err.Error(), 1)
 	}
 
+	createOptions := &options.CreateApp{
+		KeepOnFailure: ctx.keepOnFailure,
+		Branch:        ctx.branch,
+		Parent:        ctx.parent,
+	}
+	if ctx.plan != nil {
+		putsPlan(ctx.plan, ctx.planSource, ctx.app.Writer)
+		createOptions.EnvVars = ctx.plan.EnvVars
+		createOptions.DesiredCount = ctx.plan.DesiredCount
+		if ctx.plan.Size != nil {
+			createOptions.CPU = ctx.plan.Size.CPU
+			createOptions.Memory = ctx.plan.Size.Memory
+		}
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
 	ch := make(chan appsCreateOutput, 1)
 	go func() {
-		app := ctx.client.CreateApp(ctx.name)
+		app, err := ctx.client.CreateApp(ctx.name, createOptions)
+		if err != nil {
+			ch <- appsCreateOutput{err: err}
+			return
+		}
 		ch <- appsCreateOutput{
 			repository: app.Repository,
 			endpoint:   app.Endpoint,
 		}
 	}()
 
+	var err error
 	r, w := io.Pipe()
 	go func() {
 		defer w.Close()
 		fmt.Fprintf(w, "Creating app... %d%%\r", 0)
-		waitCreationAndWriteProgress(ctx, w, ch)
+		err = waitCreationAndWriteProgress(ctx, w, ch, events)
 	}()
 
 	io.Copy(ctx.app.Writer, r)
 
-	return nil
+	return err
 }
 
 func validateAppName(ctx *appsCreateContext) error {
@@ -126,17 +196,47 @@ func validateAppName(ctx *appsCreateContext) error {
 	return nil
 }
 
-func waitCreationAndWriteProgress(ctx *appsCreateContext, w io.Writer, ch chan appsCreateOutput) {
+func waitCreationAndWriteProgress(ctx *appsCreateContext, w io.Writer, ch chan appsCreateOutput, events *stackEventStreamer) error {
 	select {
 	case v := <-ch:
-		writeCreatedRepository(v.repository)
+		events.flush(w)
+		if v.err != nil {
+			fmt.Fprintln(w, "Creating app... failed")
+			return creationFailureError(ctx.name, v.err)
+		}
+		// Review apps track the parent repository, so the local repository doesn't need a new remote.
+		if ctx.parent == "" {
+			writeCreatedRepository(v.repository)
+		}
 		writeCreationResult(ctx.name, v, w)
+		return nil
 	default:
 		time.Sleep(progressCheckInterval)
+		events.flush(w)
 		percent := ctx.client.GetAppCreationProgress(ctx.name)
 		fmt.Fprintf(w, "Creating app... %d%%\r", percent)
-		waitCreationAndWriteProgress(ctx, w, ch)
+		return waitCreationAndWriteProgress(ctx, w, ch, events)
+	}
+}
+
+func creationFailureError(appName string, err error) error {
+	failureErr, ok := err.(*api.StackFailureError)
+	if !ok {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to create the app: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	if failureErr.Kept {
+		return stackFailureError(
+			failureErr,
+			"create",
+			fmt.Sprintf("The failed stack is kept for debugging. After that, run %s.", color.New(color.FgCyan).Sprintf("herogate apps:destroy %s", appName)),
+		)
 	}
+	return stackFailureError(
+		failureErr,
+		"create",
+		fmt.Sprintf("The failed stack was deleted. To keep it for debugging, re-run this command with %s.", color.New(color.FgCyan).Sprint("--keep-on-failure")),
+	)
 }
 
 func writeCreatedRepository(repositoryURL string) {
@@ -196,7 +296,7 @@ func AppsInfo(ctx *cli.Context) error {
 	return processAppsInfo(&appsInfoContext{
 		name: name,
 		app:  ctx.App,
-		client: api.NewClient(&api.ClientOption{
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
@@ -222,6 +322,15 @@ func processAppsInfo(ctx *appsInfoContext) error {
 	if app.Repository != "" {
 		fmt.Fprintln(ctx.app.Writer, fmt.Sprintf("Git URL:          %s", app.Repository))
 	}
+	if app.Branch != "" {
+		fmt.Fprintln(ctx.app.Writer, fmt.Sprintf("Branch:           %s", app.Branch))
+	}
+	if app.HealthCheck != nil {
+		fmt.Fprintln(ctx.app.Writer, fmt.Sprintf("Health Check:     %s", healthCheckSummary(app.HealthCheck)))
+		if app.HealthCheck.Command != "" {
+			fmt.Fprintln(ctx.app.Writer, fmt.Sprintf("                  web: %s", app.HealthCheck.Command))
+		}
+	}
 	fmt.Fprintln(ctx.app.Writer, fmt.Sprintf("Status:           %s", app.Status))
 	fmt.Fprintln(ctx.app.Writer, fmt.Sprintf("Region:           %s", app.Region))
 	fmt.Fprintln(ctx.app.Writer, fmt.Sprintf("Platform Version: %s", app.PlatformVersion))
@@ -253,7 +362,7 @@ func AppsOpen(ctx *cli.Context) error {
 	return processAppsOpen(&appsOpenContext{
 		name: name,
 		path: path,
-		client: api.NewClient(&api.ClientOption{
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
@@ -295,8 +404,11 @@ func processAppsOpen(ctx *appsOpenContext) error {
 
 type appsDestroyContext struct {
 	name    string
+	parent  string
 	app     *cli.App
 	confirm string
+	wait    time.Duration
+	verbose bool
 	client  iface.ClientInterface
 }
 
@@ -329,21 +441,40 @@ func AppsDestroy(ctx *cli.Context) error {
 		name:    name,
 		app:     ctx.App,
 		confirm: ctx.String("confirm"),
-		client: api.NewClient(&api.ClientOption{
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
 }
 
 func processAppsDestroy(ctx *appsDestroyContext) error {
-	_, err := ctx.client.GetApp(ctx.name)
+	app, err := ctx.client.GetApp(ctx.name)
 	if err != nil {
 		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
 	}
+	// The remote of review apps is the parent's one, even if it is destroyed by `apps:destroy`.
+	if ctx.parent == "" {
+		ctx.parent = app.Parent
+	}
+
+	// Review apps track the repository of the app, so they are destroyed first.
+	if reviewApps := api.ReviewApps(ctx.client.ListApps(), ctx.name); len(reviewApps) > 0 {
+		return reviewAppsExistError(&api.ReviewAppsExistError{AppName: ctx.name, ReviewApps: reviewApps})
+	}
 
 	if err = confirmAppDeletion(ctx); err != nil {
 		return err
 	}
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
 
 	ch := make(chan error, 1)
 	go func() {
@@ -354,12 +485,12 @@ func processAppsDestroy(ctx *appsDestroyContext) error {
 	go func() {
 		defer w.Close()
 		fmt.Fprintf(w, "Destroying %s... %d%%\r", color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name), 0)
-		waitDeletionAndWriteProgress(ctx, w, ch)
+		err = waitDeletionAndWriteProgress(ctx, w, ch, events)
 	}()
 
 	io.Copy(ctx.app.Writer, r)
 
-	return nil
+	return err
 }
 
 func confirmAppDeletion(ctx *appsDestroyContext) error {
@@ -387,22 +518,254 @@ func confirmAppDeletion(ctx *appsDestroyContext) error {
 	return nil
 }
 
-func waitDeletionAndWriteProgress(ctx *appsDestroyContext, w io.Writer, ch chan error) {
+func waitDeletionAndWriteProgress(ctx *appsDestroyContext, w io.Writer, ch chan error, events *stackEventStreamer) error {
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
 	select {
 	case err := <-ch:
+		events.flush(w)
+		if busyErr, ok := err.(*api.StackBusyError); ok {
+			fmt.Fprintf(w, "Destroying %s... failed\n", appStr)
+			return busyAppError(busyErr, ctx.wait)
+		}
+		if reviewAppsErr, ok := err.(*api.ReviewAppsExistError); ok {
+			fmt.Fprintf(w, "Destroying %s... failed\n", appStr)
+			return reviewAppsExistError(reviewAppsErr)
+		}
+		if failureErr, ok := err.(*api.StackFailureError); ok {
+			fmt.Fprintf(w, "Destroying %s... failed\n", appStr)
+			return stackFailureError(failureErr, "destroy", fmt.Sprintf("To retry the deletion, run %s.", color.New(color.FgCyan).Sprintf("herogate apps:repair %s", ctx.name)))
+		}
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"appName": ctx.name,
 			}).Fatal("Failed to destroy the app: " + err.Error())
 		}
-		deleteLocalRepository()
-		fmt.Fprintf(w, "Destroying %s... done\n", color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name))
+		// The remote of review apps is the parent's one.
+		if ctx.parent == "" {
+			deleteLocalRepository()
+		}
+		fmt.Fprintf(w, "Destroying %s... done\n", appStr)
+		return nil
 	default:
 		time.Sleep(progressCheckInterval)
+		events.flush(w)
 		percent := ctx.client.GetAppDeletionProgress(ctx.name)
-		fmt.Fprintf(w, "Destroying %s... %d%%\r", color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name), percent)
-		waitDeletionAndWriteProgress(ctx, w, ch)
+		fmt.Fprintf(w, "Destroying %s... %d%%\r", appStr, percent)
+		return waitDeletionAndWriteProgress(ctx, w, ch, events)
+	}
+}
+
+// reviewAppsExistError returns the error listing review apps which must be destroyed before the app.
+func reviewAppsExistError(err *api.ReviewAppsExistError) error {
+	errorColor := color.New(color.FgRed)
+	appColor := color.New(color.FgMagenta)
+
+	lines := []string{fmt.Sprintf("%s    %s has review apps. Destroy them first:", errorColor.Sprint("▸"), appColor.Sprintf("⬢ %s", err.AppName))}
+	for _, name := range err.ReviewApps {
+		lines = append(lines, fmt.Sprintf("%s        %s", errorColor.Sprint("▸"), color.New(color.FgCyan).Sprintf("herogate apps:destroy %s", name)))
+	}
+	return cli.NewExitError(strings.Join(lines, "\n"), 1)
+}
+
+type appsRepairContext struct {
+	name   string
+	app    *cli.App
+	wait   time.Duration
+	client iface.ClientInterface
+}
+
+// AppsRepair recovers the application stuck in a failed state.
+// For example, `UPDATE_ROLLBACK_FAILED` or `DELETE_FAILED`.
+func AppsRepair(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if ctx.Args().First() != "" {
+		logrus.Debug("Override application name: " + ctx.Args().First())
+		name = ctx.Args().First()
+	}
+	if name == "" {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    No app specified.\n%s    USAGE: herogate apps:repair APPNAME",
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgRed).Sprint("▸"),
+			),
+			1,
+		)
+	}
+
+	return processAppsRepair(&appsRepairContext{
+		name: name,
+		app:  ctx.App,
+		wait: waitTimeout(ctx),
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processAppsRepair(ctx *appsRepairContext) error {
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	fmt.Fprintf(ctx.app.Writer, "Repairing %s...\r", appStr)
+
+	repair, err := ctx.client.RepairApp(ctx.name)
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		fmt.Fprintf(ctx.app.Writer, "Repairing %s... failed\n", appStr)
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if failureErr, ok := err.(*api.StackFailureError); ok {
+		fmt.Fprintf(ctx.app.Writer, "Repairing %s... failed\n", appStr)
+		return stackFailureError(failureErr, "repair", "Fix the failed resources on AWS Management Console, and then re-run this command.")
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+		}).Fatal("Failed to repair the app: " + err.Error())
+	}
+
+	if len(repair.Actions) == 0 {
+		fmt.Fprintf(ctx.app.Writer, "Repairing %s... done, nothing to repair (%s)\n", appStr, repair.Status)
+		return nil
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Repairing %s... done (%s)\n", appStr, repair.Status)
+	for _, action := range repair.Actions {
+		fmt.Fprintln(ctx.app.Writer, action)
+	}
+	if repair.Status == "DELETE_FAILED" {
+		deleteLocalRepository()
+	}
+
+	return nil
+}
+
+type appsUpgradeContext struct {
+	name    string
+	dryRun  bool
+	force   bool
+	wait    time.Duration
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
+}
+
+// AppsUpgrade upgrades the application to the platform version built into this binary.
+// The application state such as environment variables and container definitions are carried over.
+func AppsUpgrade(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processAppsUpgrade(&appsUpgradeContext{
+		name:    name,
+		dryRun:  ctx.Bool("dry-run"),
+		force:   ctx.Bool("force"),
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processAppsUpgrade(ctx *appsUpgradeContext) error {
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	switch api.ComparePlatformVersions(app.PlatformVersion, api.PlatformVersion) {
+	case 0:
+		fmt.Fprintf(ctx.app.Writer, "%s is already on the latest platform version %s\n", appStr, api.PlatformVersion)
+		return nil
+	case 1:
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    %s is on the platform version %s, which is newer than %s supported by this herogate.\n%s    Update herogate, and then re-run this command.",
+				color.New(color.FgRed).Sprint("▸"),
+				appStr,
+				app.PlatformVersion,
+				api.PlatformVersion,
+				color.New(color.FgRed).Sprint("▸"),
+			),
+			1,
+		)
+	}
+
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Previewing upgrade of %s from %s to %s...\r", appStr, app.PlatformVersion, api.PlatformVersion)
+	changes, err := ctx.client.PreviewUpgradeApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to preview changes: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+	fmt.Fprintf(ctx.app.Writer, "Previewing upgrade of %s from %s to %s... done\n", appStr, app.PlatformVersion, api.PlatformVersion)
+	putsChanges(changes, ctx.app.Writer)
+	if ctx.dryRun {
+		return nil
 	}
+
+	downtimeResources := []string{}
+	for _, change := range changes {
+		if api.CausesDowntime(change) {
+			downtimeResources = append(downtimeResources, change.LogicalResourceID)
+		}
+	}
+	if len(downtimeResources) > 0 && !ctx.force {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    This upgrade replaces or removes %s, which may cause downtime of %s.\n%s    To proceed anyway, re-run this command with %s.",
+				color.New(color.FgRed).Sprint("▸"),
+				strings.Join(downtimeResources, ", "),
+				appStr,
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgCyan).Sprint("--force"),
+			),
+			1,
+		)
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	progress := fmt.Sprintf("Upgrading %s to the platform version %s...\r", appStr, api.PlatformVersion)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		return ctx.client.UpgradeApp(ctx.name)
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to upgrade the app: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Upgrading %s to the platform version %s... done\n", appStr, api.PlatformVersion)
+
+	return nil
 }
 
 func deleteLocalRepository() {
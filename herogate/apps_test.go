@@ -19,6 +19,8 @@ import (
 	"github.com/urfave/cli"
 	"github.com/wata727/herogate/api"
 	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/api/options"
+	"github.com/wata727/herogate/heroku"
 	"github.com/wata727/herogate/mock"
 )
 
@@ -107,13 +109,13 @@ func TestProcessAppsCreate(t *testing.T) {
 	// Expect to check stack
 	client.EXPECT().StackExists("young-eyrie-24091").Return(false)
 	// Expect to create application
-	client.EXPECT().CreateApp("young-eyrie-24091").Return(&objects.App{
+	client.EXPECT().CreateApp("young-eyrie-24091", &options.CreateApp{}).Return(&objects.App{
 		Name:            "young-eyrie-24091",
 		Status:          "CREATE_COMPLETE",
 		Repository:      "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/young-eyrie-24091",
 		Endpoint:        "http://young-eyrie-24091-123456789.us-east-1.elb.amazonaws.com",
 		PlatformVersion: "1.0",
-	})
+	}, nil)
 	// Allow to get progress rate
 	client.EXPECT().GetAppCreationProgress("young-eyrie-24091").Return(100).AnyTimes()
 
@@ -155,6 +157,71 @@ func TestProcessAppsCreate(t *testing.T) {
 	}
 }
 
+func TestProcessAppsCreate__failed(t *testing.T) {
+	// Wait only 1 second
+	progressCheckInterval = 1 * time.Second
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(nil, errors.New("Stack not found"))
+	// Expect to check stack
+	client.EXPECT().StackExists("young-eyrie-24091").Return(false)
+	// Expect to fail to create application
+	client.EXPECT().CreateApp("young-eyrie-24091", &options.CreateApp{}).Return(nil, &api.StackFailureError{
+		AppName: "young-eyrie-24091",
+		Status:  "ROLLBACK_COMPLETE",
+		Failures: []*objects.ResourceFailure{
+			{
+				LogicalResourceID: "HerogateLoadBalancer",
+				ResourceType:      "AWS::ElasticLoadBalancingV2::LoadBalancer",
+				ResourceStatus:    "CREATE_FAILED",
+				Reason:            "You have reached the limit on the number of load balancers for your account",
+				Hint:              "You may have reached a service limit.",
+			},
+		},
+	})
+	// Allow to get progress rate
+	client.EXPECT().GetAppCreationProgress("young-eyrie-24091").Return(50).AnyTimes()
+
+	err := processAppsCreate(&appsCreateContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	errorColor := color.New(color.FgRed)
+	expected := fmt.Sprintf(
+		"%s    Failed to create %s (ROLLBACK_COMPLETE).\n"+
+			"%s    HerogateLoadBalancer (AWS::ElasticLoadBalancingV2::LoadBalancer) %s\n"+
+			"%s        Reason: You have reached the limit on the number of load balancers for your account\n"+
+			"%s        Hint: You may have reached a service limit.\n"+
+			"%s    The failed stack was deleted. To keep it for debugging, re-run this command with %s.",
+		errorColor.Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		errorColor.Sprint("▸"),
+		errorColor.Sprint("CREATE_FAILED"),
+		errorColor.Sprint("▸"),
+		errorColor.Sprint("▸"),
+		errorColor.Sprint("▸"),
+		color.New(color.FgCyan).Sprint("--keep-on-failure"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+	if !strings.Contains(writer.String(), "Creating app... failed\n") {
+		t.Fatalf("Expected failure output is not contained: %s", writer.String())
+	}
+}
+
 func TestProcessAppsCreate__invalidName(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -226,6 +293,7 @@ func TestProcessAppsInfo(t *testing.T) {
 			Endpoint:        "http://young-eyrie-24091-123456789.us-east-1.elb.amazonaws.com",
 			PlatformVersion: "1.0",
 		},
+		Branch: "master",
 		Containers: []*objects.Container{
 			{
 				Name:  "web",
@@ -236,6 +304,12 @@ func TestProcessAppsInfo(t *testing.T) {
 				Count: 1,
 			},
 		},
+		HealthCheck: &objects.HealthCheck{
+			Path:             "/healthz",
+			Interval:         15,
+			HealthyThreshold: 2,
+			Command:          "curl -f http://localhost:$PORT/healthz",
+		},
 		Region: "us-east-1",
 	}, nil)
 
@@ -257,6 +331,9 @@ Containers:       web: 1
                   worker: 1
 Web URL:          http://young-eyrie-24091-123456789.us-east-1.elb.amazonaws.com
 Git URL:          ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/young-eyrie-24091
+Branch:           master
+Health Check:     GET /healthz every 15s, healthy after 2 checks
+                  web: curl -f http://localhost:$PORT/healthz
 Status:           CREATE_COMPLETE
 Region:           us-east-1
 Platform Version: 1.0
@@ -487,6 +564,8 @@ func TestProcessAppsDestroy(t *testing.T) {
 		Endpoint:        "http://young-eyrie-24091-123456789.us-east-1.elb.amazonaws.com/",
 		PlatformVersion: "1.0",
 	}, nil)
+	// Expect to list apps to find review apps
+	client.EXPECT().ListApps().Return([]*objects.App{})
 	// Expect to destroy application
 	client.EXPECT().DestroyApp("young-eyrie-24091").Return(nil)
 	// Allow to get progress rate
@@ -544,6 +623,54 @@ func TestProcessAppsDestroy__notFound(t *testing.T) {
 	}
 }
 
+func TestProcessAppsDestroy__reviewApps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:            "young-eyrie-24091",
+		Status:          "CREATE_COMPLETE",
+		PlatformVersion: "1.0",
+	}, nil)
+	// Expect to list apps, and review apps are found
+	client.EXPECT().ListApps().Return([]*objects.App{
+		{Name: "young-eyrie-24091"},
+		{Name: "young-eyrie-24091-pr-feature", Parent: "young-eyrie-24091"},
+		{Name: "young-eyrie-24091-pr-fix-login", Parent: "young-eyrie-24091"},
+		{Name: "proud-lab-1661"},
+	})
+
+	err := processAppsDestroy(&appsDestroyContext{
+		name:    "young-eyrie-24091",
+		app:     app,
+		confirm: "young-eyrie-24091",
+		client:  client,
+	})
+
+	errorColor := color.New(color.FgRed)
+	expected := fmt.Sprintf(
+		"%s    %s has review apps. Destroy them first:\n%s        %s\n%s        %s",
+		errorColor.Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		errorColor.Sprint("▸"),
+		color.New(color.FgCyan).Sprint("herogate apps:destroy young-eyrie-24091-pr-feature"),
+		errorColor.Sprint("▸"),
+		color.New(color.FgCyan).Sprint("herogate apps:destroy young-eyrie-24091-pr-fix-login"),
+	)
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%v`", expected, err)
+	}
+	if writer.String() != "" {
+		t.Fatalf("Expected to output nothing, but get `%s`", writer.String())
+	}
+}
+
 func TestProcessAppsDestroy__confirmationFailed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -561,6 +688,8 @@ func TestProcessAppsDestroy__confirmationFailed(t *testing.T) {
 		Endpoint:        "http://young-eyrie-24091-123456789.us-east-1.elb.amazonaws.com/",
 		PlatformVersion: "1.0",
 	}, nil)
+	// Expect to list apps to find review apps
+	client.EXPECT().ListApps().Return([]*objects.App{})
 
 	err := processAppsDestroy(&appsDestroyContext{
 		name:    "young-eyrie-24091",
@@ -624,6 +753,8 @@ func TestProcessAppsDestroy__withConfirmation(t *testing.T) {
 		Endpoint:        "http://young-eyrie-24091-123456789.us-east-1.elb.amazonaws.com/",
 		PlatformVersion: "1.0",
 	}, nil)
+	// Expect to list apps to find review apps
+	client.EXPECT().ListApps().Return([]*objects.App{})
 	// Expect to destroy application
 	client.EXPECT().DestroyApp("young-eyrie-24091").Return(nil)
 	// Allow to get progress rate
@@ -663,3 +794,212 @@ func TestProcessAppsDestroy__withConfirmation(t *testing.T) {
 		}
 	}
 }
+
+func TestProcessAppsRepair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_ROLLBACK_FAILED",
+	}, nil)
+	// Expect to repair application
+	client.EXPECT().RepairApp("young-eyrie-24091").Return(&objects.Repair{
+		Status: "UPDATE_ROLLBACK_FAILED",
+		Actions: []string{
+			"Skipped HerogateApplicationService (AWS::ECS::Service) which failed to roll back: Service was not ACTIVE",
+			"Continued the update rollback",
+		},
+	}, nil)
+
+	err := processAppsRepair(&appsRepairContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf(`Repairing %s...`+"\r"+`Repairing %s... done (UPDATE_ROLLBACK_FAILED)
+Skipped HerogateApplicationService (AWS::ECS::Service) which failed to roll back: Service was not ACTIVE
+Continued the update rollback
+`, appStr, appStr)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessAppsRepair__nothingToRepair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to repair application
+	client.EXPECT().RepairApp("young-eyrie-24091").Return(&objects.Repair{
+		Status:  "UPDATE_COMPLETE",
+		Actions: []string{},
+	}, nil)
+
+	err := processAppsRepair(&appsRepairContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf("Repairing %s...\rRepairing %s... done, nothing to repair (UPDATE_COMPLETE)\n", appStr, appStr)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessAppsUpgrade__latest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:            "young-eyrie-24091",
+		Status:          "UPDATE_COMPLETE",
+		PlatformVersion: api.PlatformVersion,
+	}, nil)
+
+	err := processAppsUpgrade(&appsUpgradeContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := fmt.Sprintf("%s is already on the latest platform version %s\n", color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"), api.PlatformVersion)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessAppsUpgrade__downtime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	app.Writer = new(bytes.Buffer)
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application on the old platform
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:            "young-eyrie-24091",
+		Status:          "UPDATE_COMPLETE",
+		PlatformVersion: "0.9",
+	}, nil)
+	// Expect to preview the upgrade
+	client.EXPECT().PreviewUpgradeApp("young-eyrie-24091").Return([]*objects.Change{
+		{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateLoadBalancer",
+			ResourceType:      "AWS::ElasticLoadBalancingV2::LoadBalancer",
+			Replacement:       "True",
+		},
+	}, nil)
+	// Expect not to upgrade application
+	client.EXPECT().UpgradeApp("young-eyrie-24091").Times(0)
+
+	err := processAppsUpgrade(&appsUpgradeContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    This upgrade replaces or removes HerogateLoadBalancer, which may cause downtime of %s.\n%s    To proceed anyway, re-run this command with %s.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgCyan).Sprint("--force"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
+func TestProcessAppsCreate__plan(t *testing.T) {
+	// Wait only 1 second
+	progressCheckInterval = 1 * time.Second
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(nil, errors.New("Stack not found"))
+	// Expect to check stack
+	client.EXPECT().StackExists("young-eyrie-24091").Return(false)
+	// Expect to create application with the plan
+	client.EXPECT().CreateApp("young-eyrie-24091", &options.CreateApp{
+		EnvVars:      map[string]string{"RAILS_ENV": "production"},
+		DesiredCount: 2,
+		CPU:          "512",
+		Memory:       "1024",
+	}).Return(nil, &api.StackFailureError{
+		AppName: "young-eyrie-24091",
+		Status:  "ROLLBACK_COMPLETE",
+	})
+	// Allow to get progress rate
+	client.EXPECT().GetAppCreationProgress("young-eyrie-24091").Return(50).AnyTimes()
+
+	processAppsCreate(&appsCreateContext{
+		name: "young-eyrie-24091",
+		plan: &heroku.Plan{
+			EnvVars:      map[string]string{"RAILS_ENV": "production"},
+			DesiredCount: 2,
+			Size:         &heroku.TaskSize{CPU: "512", Memory: "1024"},
+			Skipped:      []string{"add-on heroku-postgresql: add-ons are not supported, attach the service with config vars"},
+		},
+		planSource: "app.json",
+		app:        app,
+		client:     client,
+	})
+
+	expected := fmt.Sprintf(`=== Settings from app.json
+Config Vars: RAILS_ENV
+Scale:       2
+Size:        512 CPU units, 1024 MiB
+%s    Skipped add-on heroku-postgresql: add-ons are not supported, attach the service with config vars
+
+`, color.New(color.FgYellow).Sprint("▸"))
+	if !strings.HasPrefix(writer.String(), expected) {
+		t.Fatalf("Expected plan outputs are not contained:\nExpected: %s\nActual: %s", expected, writer.String())
+	}
+}
@@ -0,0 +1,283 @@
+package herogate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/buildpack"
+)
+
+type buildContext struct {
+	name   string
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// Build displays the build settings of the application.
+func Build(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processBuild(&buildContext{
+		name: name,
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processBuild(ctx *buildContext) error {
+	build, err := ctx.client.GetBuildConfig(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	fmt.Fprintln(ctx.app.Writer, fmt.Sprintf("=== %s Build Settings", ctx.name))
+	putsBuildConfig(build, ctx.app.Writer)
+
+	return nil
+}
+
+func putsBuildConfig(build *objects.BuildConfig, writer io.Writer) {
+	settings := map[string]string{
+		"dockerfile": "Dockerfile",
+		"target":     "(last stage)",
+		"compute":    "small",
+		"args":       "(none)",
+	}
+	if build.Dockerfile != "" {
+		settings["dockerfile"] = build.Dockerfile
+	}
+	if build.Target != "" {
+		settings["target"] = build.Target
+	}
+	if build.Compute != "" {
+		settings["compute"] = build.Compute
+	}
+	if len(build.Args) > 0 {
+		settings["args"] = strings.Join(build.Args, ",")
+	}
+	putsEnvVars(settings, writer)
+}
+
+type buildSetContext struct {
+	name    string
+	args    []string
+	dryRun  bool
+	wait    time.Duration
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
+}
+
+// BuildSet changes the build settings of the application.
+func BuildSet(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if !ctx.Args().Present() {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require argument, You must specify key value pairs of build settings", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processBuildSet(&buildSetContext{
+		name:    name,
+		args:    ctx.Args(),
+		dryRun:  ctx.Bool("dry-run"),
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processBuildSet(ctx *buildSetContext) error {
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	if api.ComparePlatformVersions(app.PlatformVersion, api.BuildConfigPlatformVersion) < 0 {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    %s is on the platform version %s, but build settings require %s or later.\n%s    Run %s first.",
+				color.New(color.FgRed).Sprint("▸"),
+				appStr,
+				app.PlatformVersion,
+				api.BuildConfigPlatformVersion,
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgCyan).Sprint("herogate apps:upgrade"),
+			),
+			1,
+		)
+	}
+
+	build, err := ctx.client.GetBuildConfig(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	for _, arg := range ctx.args {
+		setting := strings.SplitN(arg, "=", 2)
+		if len(setting) == 1 {
+			return cli.NewExitError(
+				fmt.Sprintf(
+					"%s    %s is invalid. Must be in the format %s.",
+					color.New(color.FgRed).Sprint("▸"),
+					color.New(color.FgCyan).Sprint(setting[0]),
+					color.New(color.FgCyan).Sprint("dockerfile=api/Dockerfile"),
+				),
+				1)
+		}
+
+		switch setting[0] {
+		case "dockerfile":
+			build.Dockerfile = setting[1]
+		case "target":
+			build.Target = setting[1]
+		case "compute":
+			build.Compute = setting[1]
+		case "args":
+			build.Args = []string{}
+			for _, name := range strings.Split(setting[1], ",") {
+				if name != "" {
+					build.Args = append(build.Args, name)
+				}
+			}
+		default:
+			return cli.NewExitError(
+				fmt.Sprintf(
+					"%s    Unknown build setting %s. Must be one of dockerfile, target, compute and args.",
+					color.New(color.FgRed).Sprint("▸"),
+					color.New(color.FgCyan).Sprint(setting[0]),
+				),
+				1)
+		}
+	}
+	if err = api.ValidateBuildConfig(build); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	if len(build.Args) > 0 {
+		envVars, err := ctx.client.DescribeEnvVars(ctx.name)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+		}
+		for _, name := range build.Args {
+			if _, ok := envVars[name]; !ok {
+				return cli.NewExitError(
+					fmt.Sprintf(
+						"%s    Config var %s is not set. Run %s first.",
+						color.New(color.FgRed).Sprint("▸"),
+						color.New(color.FgGreen).Sprint(name),
+						color.New(color.FgCyan).Sprintf("herogate config:set %s=...", name),
+					),
+					1)
+			}
+		}
+	}
+
+	if ctx.dryRun {
+		fmt.Fprintf(ctx.app.Writer, "Previewing setting build settings of %s...\r", appStr)
+		changes, err := ctx.client.PreviewSetBuildConfig(ctx.name, build)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Failed to preview changes: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+		fmt.Fprintf(ctx.app.Writer, "Previewing setting build settings of %s... done\n", appStr)
+		putsChanges(changes, ctx.app.Writer)
+		return nil
+	}
+
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	progress := fmt.Sprintf("Setting build settings of %s...\r", appStr)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		return ctx.client.SetBuildConfig(ctx.name, build)
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+		}).Fatal("Failed to set build settings: " + err.Error())
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Setting build settings of %s... done\n", appStr)
+	putsBuildConfig(build, ctx.app.Writer)
+
+	return nil
+}
+
+type buildDetectContext struct {
+	dir string
+	app *cli.App
+}
+
+// BuildDetect displays the buildpack which the builder chooses for the directory, and the generated Dockerfile.
+// It runs locally, so you can check it before pushing.
+func BuildDetect(ctx *cli.Context) error {
+	dir := ctx.Args().First()
+	if dir == "" {
+		dir = "."
+	}
+
+	return processBuildDetect(&buildDetectContext{
+		dir: dir,
+		app: ctx.App,
+	})
+}
+
+func processBuildDetect(ctx *buildDetectContext) error {
+	if _, err := os.Stat(filepath.Join(ctx.dir, "Dockerfile")); err == nil {
+		fmt.Fprintf(ctx.app.Writer, "Dockerfile is found, the builder uses it as is.\n")
+		return nil
+	}
+
+	pack, err := buildpack.Detect(ctx.dir)
+	if err == buildpack.ErrNotDetected {
+		return cli.NewExitError(buildpackNotDetectedMessage(), 1)
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to detect the buildpack: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "=== %s app detected\n", color.New(color.FgGreen).Sprint(pack.Name))
+	fmt.Fprint(ctx.app.Writer, pack.Dockerfile(ctx.dir))
+	fmt.Fprintf(ctx.app.Writer, "\nTo customize the build, save it as %s and edit it.\n", color.New(color.FgCyan).Sprint("Dockerfile"))
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessBuildSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:            "young-eyrie-24091",
+		Status:          "UPDATE_COMPLETE",
+		PlatformVersion: "1.3",
+	}, nil)
+	// Expect to get the current build settings
+	client.EXPECT().GetBuildConfig("young-eyrie-24091").Return(&objects.BuildConfig{
+		Target: "prod",
+		Args:   []string{},
+	}, nil)
+	// Expect to check config vars of build args
+	client.EXPECT().DescribeEnvVars("young-eyrie-24091").Return(map[string]string{
+		"NPM_TOKEN": "secret",
+	}, nil)
+	// Expect to set build settings merged with the current ones
+	client.EXPECT().SetBuildConfig("young-eyrie-24091", &objects.BuildConfig{
+		Dockerfile: "api/Dockerfile",
+		Target:     "prod",
+		Compute:    "large",
+		Args:       []string{"NPM_TOKEN"},
+	}).Return(nil)
+
+	err := processBuildSet(&buildSetContext{
+		name:   "young-eyrie-24091",
+		args:   []string{"dockerfile=api/Dockerfile", "compute=large", "args=NPM_TOKEN"},
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf(
+		"Setting build settings of %s...\rSetting build settings of %s... done\n%s:       NPM_TOKEN\n%s:    large\n%s: api/Dockerfile\n%s:     prod\n",
+		appStr,
+		appStr,
+		color.New(color.FgGreen).Sprint("args"),
+		color.New(color.FgGreen).Sprint("compute"),
+		color.New(color.FgGreen).Sprint("dockerfile"),
+		color.New(color.FgGreen).Sprint("target"),
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessBuildSet__dryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:            "young-eyrie-24091",
+		Status:          "UPDATE_COMPLETE",
+		PlatformVersion: "1.3",
+	}, nil)
+	// Expect to get the current build settings
+	client.EXPECT().GetBuildConfig("young-eyrie-24091").Return(&objects.BuildConfig{
+		Args: []string{},
+	}, nil)
+	// Expect to preview build settings merged with the current ones
+	client.EXPECT().PreviewSetBuildConfig("young-eyrie-24091", &objects.BuildConfig{
+		Compute: "large",
+		Args:    []string{},
+	}).Return([]*objects.Change{
+		{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateBuilder",
+			ResourceType:      "AWS::CodeBuild::Project",
+			Replacement:       "False",
+		},
+	}, nil)
+
+	err := processBuildSet(&buildSetContext{
+		name:   "young-eyrie-24091",
+		args:   []string{"compute=large"},
+		dryRun: true,
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf(
+		"Previewing setting build settings of %s...\rPreviewing setting build settings of %s... done\n%s HerogateBuilder AWS::CodeBuild::Project\n",
+		appStr,
+		appStr,
+		color.New(color.FgYellow).Sprint("Modify"),
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessBuildSet__unsetConfigVar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:            "young-eyrie-24091",
+		Status:          "UPDATE_COMPLETE",
+		PlatformVersion: "1.3",
+	}, nil)
+	// Expect to get the current build settings
+	client.EXPECT().GetBuildConfig("young-eyrie-24091").Return(&objects.BuildConfig{Args: []string{}}, nil)
+	// Expect to check config vars of build args
+	client.EXPECT().DescribeEnvVars("young-eyrie-24091").Return(map[string]string{}, nil)
+
+	err := processBuildSet(&buildSetContext{
+		name:   "young-eyrie-24091",
+		args:   []string{"args=NPM_TOKEN"},
+		app:    app,
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    Config var %s is not set. Run %s first.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgGreen).Sprint("NPM_TOKEN"),
+		color.New(color.FgCyan).Sprint("herogate config:set NPM_TOKEN=..."),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
@@ -0,0 +1,297 @@
+package herogate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+)
+
+type buildsContext struct {
+	name   string
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// Builds displays recent builds of the application.
+func Builds(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processBuilds(&buildsContext{
+		name: name,
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processBuilds(ctx *buildsContext) error {
+	if _, err := ctx.client.GetApp(ctx.name); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	builds, err := ctx.client.DescribeBuilds(ctx.name)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+		}).Fatal("Failed to describe builds: " + err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	if len(builds) == 0 {
+		fmt.Fprintf(ctx.app.Writer, "%s has no builds.\n", appStr)
+		return nil
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "=== %s Builds\n", appStr)
+	for _, build := range builds {
+		fmt.Fprintf(
+			ctx.app.Writer,
+			"%s  %s  %s  %s  %s  %s\n",
+			shortBuildID(build.ID),
+			buildStatusColor(build.Status).Sprintf("%-11s", build.Status),
+			fmt.Sprintf("%-8s", orNone(build.ImageTag)),
+			build.StartTime.Local().Format("2006/01/02 15:04:05"),
+			fmt.Sprintf("%-6s", buildDuration(build.StartTime, build.EndTime)),
+			orNone(build.Revision),
+		)
+	}
+	fmt.Fprintf(ctx.app.Writer, "\nRun %s to see the output of the build\n", color.New(color.FgCyan).Sprint("herogate builds:output <id>"))
+
+	return nil
+}
+
+type buildsInfoContext struct {
+	name   string
+	id     string
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// BuildsInfo displays the details of the build.
+func BuildsInfo(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if !ctx.Args().Present() {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require argument, You must specify a build ID", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processBuildsInfo(&buildsInfoContext{
+		name: name,
+		id:   ctx.Args().First(),
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processBuildsInfo(ctx *buildsInfoContext) error {
+	if _, err := ctx.client.GetApp(ctx.name); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	build, err := ctx.client.GetBuild(ctx.name, ctx.id)
+	if err == api.ErrBuildNotFound {
+		return buildNotFoundError(ctx.id)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+			"id":      ctx.id,
+		}).Fatal("Failed to get the build: " + err.Error())
+	}
+
+	endTime := "-"
+	if !build.EndTime.IsZero() {
+		endTime = build.EndTime.Local().Format("2006/01/02 15:04:05")
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "=== %s\n", build.ID)
+	fmt.Fprintf(ctx.app.Writer, "Status:     %s\n", buildStatusColor(build.Status).Sprint(build.Status))
+	fmt.Fprintf(ctx.app.Writer, "Phase:      %s\n", build.Phase)
+	fmt.Fprintf(ctx.app.Writer, "Revision:   %s\n", orNone(build.Revision))
+	fmt.Fprintf(ctx.app.Writer, "Image Tag:  %s\n", orNone(build.ImageTag))
+	fmt.Fprintf(ctx.app.Writer, "Start Time: %s\n", build.StartTime.Local().Format("2006/01/02 15:04:05"))
+	fmt.Fprintf(ctx.app.Writer, "End Time:   %s\n", endTime)
+	fmt.Fprintf(ctx.app.Writer, "Duration:   %s\n", buildDuration(build.StartTime, build.EndTime))
+
+	return nil
+}
+
+type buildsOutputContext struct {
+	name   string
+	id     string
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// BuildsOutput displays the complete log of the build.
+func BuildsOutput(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if !ctx.Args().Present() {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require argument, You must specify a build ID", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processBuildsOutput(&buildsOutputContext{
+		name: name,
+		id:   ctx.Args().First(),
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processBuildsOutput(ctx *buildsOutputContext) error {
+	if _, err := ctx.client.GetApp(ctx.name); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	logs, err := ctx.client.DescribeBuildOutput(ctx.name, ctx.id)
+	if err == api.ErrBuildNotFound {
+		return buildNotFoundError(ctx.id)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+			"id":      ctx.id,
+		}).Fatal("Failed to get the build output: " + err.Error())
+	}
+
+	for _, eventLog := range logs {
+		fmt.Fprintln(ctx.app.Writer, eventLog.Format())
+	}
+
+	return nil
+}
+
+type buildsCancelContext struct {
+	name   string
+	id     string
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// BuildsCancel stops the running build.
+func BuildsCancel(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if !ctx.Args().Present() {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require argument, You must specify a build ID", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processBuildsCancel(&buildsCancelContext{
+		name: name,
+		id:   ctx.Args().First(),
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processBuildsCancel(ctx *buildsCancelContext) error {
+	if _, err := ctx.client.GetApp(ctx.name); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Cancelling the build %s...\r", ctx.id)
+	build, err := ctx.client.CancelBuild(ctx.name, ctx.id)
+	switch err {
+	case nil:
+	case api.ErrBuildNotFound:
+		fmt.Fprintf(ctx.app.Writer, "Cancelling the build %s... skipped\n", ctx.id)
+		return buildNotFoundError(ctx.id)
+	case api.ErrBuildNotRunning:
+		fmt.Fprintf(ctx.app.Writer, "Cancelling the build %s... skipped\n", ctx.id)
+		return cli.NewExitError(fmt.Sprintf("%s    The build %s has already finished.", color.New(color.FgRed).Sprint("▸"), ctx.id), 1)
+	default:
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+			"id":      ctx.id,
+		}).Fatal("Failed to cancel the build: " + err.Error())
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Cancelling the build %s... done\n", shortBuildID(build.ID))
+
+	return nil
+}
+
+func buildNotFoundError(id string) error {
+	return cli.NewExitError(
+		fmt.Sprintf(
+			"%s    Couldn't find the build %s. Run %s to see recent builds.",
+			color.New(color.FgRed).Sprint("▸"),
+			id,
+			color.New(color.FgCyan).Sprint("herogate builds"),
+		),
+		1,
+	)
+}
+
+// shortBuildID returns the short UUID part of the build ID like Git commits. Build ID is "project:uuid".
+func shortBuildID(id string) string {
+	short := id[strings.LastIndex(id, ":")+1:]
+	if len(short) > 8 {
+		short = short[:8]
+	}
+	return short
+}
+
+func buildStatusColor(status string) *color.Color {
+	switch status {
+	case "SUCCEEDED":
+		return color.New(color.FgGreen)
+	case "IN_PROGRESS":
+		return color.New(color.FgYellow)
+	default:
+		return color.New(color.FgRed)
+	}
+}
+
+func buildDuration(start time.Time, end time.Time) string {
+	if end.IsZero() {
+		return "-"
+	}
+	return end.Sub(start).Round(time.Second).String()
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package herogate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessBuildsCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to cancel the build
+	client.EXPECT().CancelBuild("young-eyrie-24091", "0b1c").Return(&objects.Build{
+		ID:     "young-eyrie-24091:0b1c2d3e-4f5a-6b7c-8d9e-0f1a2b3c4d5e",
+		Status: "IN_PROGRESS",
+	}, nil)
+
+	err := processBuildsCancel(&buildsCancelContext{
+		name:   "young-eyrie-24091",
+		id:     "0b1c",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := "Cancelling the build 0b1c...\rCancelling the build 0b1c2d3e... done\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessBuildsCancel__notRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to cancel the build, but it has already finished
+	client.EXPECT().CancelBuild("young-eyrie-24091", "0b1c").Return(nil, api.ErrBuildNotRunning)
+
+	err := processBuildsCancel(&buildsCancelContext{
+		name:   "young-eyrie-24091",
+		id:     "0b1c",
+		app:    app,
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := "Cancelling the build 0b1c...\rCancelling the build 0b1c... skipped\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
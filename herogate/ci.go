@@ -0,0 +1,86 @@
+package herogate
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+)
+
+type ciContext struct {
+	name   string
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// Ci displays recent test runs in the pipeline of the app.
+func Ci(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processCi(&ciContext{
+		name: name,
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processCi(ctx *ciContext) error {
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	if api.ComparePlatformVersions(app.PlatformVersion, api.CIPlatformVersion) < 0 {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    %s is on the platform version %s, but the test stage requires %s or later.\n%s    Run %s first.",
+				color.New(color.FgRed).Sprint("▸"),
+				appStr,
+				app.PlatformVersion,
+				api.CIPlatformVersion,
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgCyan).Sprint("herogate apps:upgrade"),
+			),
+			1,
+		)
+	}
+
+	runs, err := ctx.client.DescribeTestRuns(ctx.name)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+		}).Fatal("Failed to describe test runs: " + err.Error())
+	}
+	if len(runs) == 0 {
+		fmt.Fprintf(ctx.app.Writer, "%s has no test runs.\n", appStr)
+		return nil
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "=== %s Test Runs\n", appStr)
+	for _, run := range runs {
+		fmt.Fprintf(
+			ctx.app.Writer,
+			"%s  %s  %s  %s\n",
+			shortBuildID(run.ID),
+			buildStatusColor(run.Status).Sprintf("%-11s", run.Status),
+			run.StartTime.Local().Format("2006/01/02 15:04:05"),
+			buildDuration(run.StartTime, run.EndTime),
+		)
+	}
+	fmt.Fprintf(ctx.app.Writer, "\nRun %s to see the output of the latest test run\n", color.New(color.FgCyan).Sprint("herogate logs --ps tester"))
+
+	return nil
+}
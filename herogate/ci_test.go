@@ -0,0 +1,68 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessCi(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	start := time.Date(2018, time.February, 3, 1, 30, 0, 0, time.UTC)
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:            "young-eyrie-24091",
+		Status:          "UPDATE_COMPLETE",
+		PlatformVersion: "1.2",
+	}, nil)
+	// Expect to describe test runs
+	client.EXPECT().DescribeTestRuns("young-eyrie-24091").Return([]*objects.TestRun{
+		{
+			ID:        "young-eyrie-24091-tester:0b1c2d3e-4f5a-6b7c-8d9e-0f1a2b3c4d5e",
+			Status:    "IN_PROGRESS",
+			StartTime: start.Add(10 * time.Minute),
+		},
+		{
+			ID:        "young-eyrie-24091-tester:9a8b7c6d-4f5a-6b7c-8d9e-0f1a2b3c4d5e",
+			Status:    "FAILED",
+			StartTime: start,
+			EndTime:   start.Add(80 * time.Second),
+		},
+	}, nil)
+
+	err := processCi(&ciContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := fmt.Sprintf(
+		"=== %s Test Runs\n0b1c2d3e  %s  %s  -\n9a8b7c6d  %s  %s  1m20s\n\nRun %s to see the output of the latest test run\n",
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		color.New(color.FgYellow).Sprint("IN_PROGRESS"),
+		start.Add(10*time.Minute).Local().Format("2006/01/02 15:04:05"),
+		color.New(color.FgRed).Sprint("FAILED     "),
+		start.Local().Format("2006/01/02 15:04:05"),
+		color.New(color.FgCyan).Sprint("herogate logs --ps tester"),
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
@@ -0,0 +1,102 @@
+package herogate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/compose"
+	"github.com/wata727/herogate/container"
+)
+
+type importComposeContext struct {
+	dir   string
+	write bool
+	app   *cli.App
+}
+
+// ImportCompose translates `docker-compose.yml` in the current directory to Procfile and config vars.
+// It puts the plan, and writes Procfile when `--write` is specified.
+func ImportCompose(ctx *cli.Context) error {
+	return processImportCompose(&importComposeContext{
+		dir:   ".",
+		write: ctx.Bool("write"),
+		app:   ctx.App,
+	})
+}
+
+func processImportCompose(ctx *importComposeContext) error {
+	project, err := compose.Load(ctx.dir)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+	plan := project.Plan()
+
+	fmt.Fprintln(ctx.app.Writer, "=== Procfile")
+	fmt.Fprint(ctx.app.Writer, plan.ProcfileContent())
+	fmt.Fprint(ctx.app.Writer, "\n")
+
+	if len(plan.Addons) > 0 {
+		fmt.Fprintln(ctx.app.Writer, "=== Add-ons")
+		for _, addon := range plan.Addons {
+			fmt.Fprintf(ctx.app.Writer, "%s (%s): provision %s and set %s\n", addon.Service, addon.Image, addon.Name, color.New(color.FgGreen).Sprint(addon.EnvVar))
+		}
+		fmt.Fprint(ctx.app.Writer, "\n")
+	}
+
+	fmt.Fprintln(ctx.app.Writer, "=== Commands")
+	if len(plan.EnvVars) > 0 {
+		args := []string{}
+		for _, name := range sortedEnvVarNames(plan.EnvVars) {
+			args = append(args, shellQuote(name+"="+plan.EnvVars[name]))
+		}
+		fmt.Fprintf(ctx.app.Writer, "herogate config:set %s\n", strings.Join(args, " "))
+	}
+	if plan.Port != 0 && plan.Port != container.DefaultPort {
+		fmt.Fprintf(ctx.app.Writer, "herogate port:set %d\n", plan.Port)
+	}
+	for _, skipped := range plan.Skipped {
+		fmt.Fprintf(ctx.app.Writer, "%s    Skipped %s\n", color.New(color.FgYellow).Sprint("▸"), skipped)
+	}
+
+	if !ctx.write {
+		return nil
+	}
+	fmt.Fprint(ctx.app.Writer, "\n")
+	path := filepath.Join(ctx.dir, "Procfile")
+	if _, err := os.Stat(path); err == nil {
+		fmt.Fprintf(ctx.app.Writer, "Skipping %s, it already exists\n", color.New(color.FgCyan).Sprint("Procfile"))
+		return nil
+	}
+	if err := ioutil.WriteFile(path, []byte(plan.ProcfileContent()), 0644); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to write Procfile: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+	fmt.Fprintf(ctx.app.Writer, "Writing %s... done\n", color.New(color.FgCyan).Sprint("Procfile"))
+
+	return nil
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes the argument with single quotes if it contains characters which the shell interprets.
+func shellQuote(arg string) string {
+	if shellSafe.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
+
+func sortedEnvVarNames(envVars map[string]string) []string {
+	names := []string{}
+	for name := range envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,95 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+func TestProcessImportCompose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	compose := `services:
+  web:
+    build: .
+    command: npm start
+    ports: ["3000:3000"]
+    environment:
+      NODE_ENV: production
+      GREETING: Hello world
+      DATABASE_URL: postgres://postgres@db/app
+  db:
+    image: postgres:10
+`
+	if err = ioutil.WriteFile(filepath.Join(dir, "docker-compose.yml"), []byte(compose), 0644); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	err = processImportCompose(&importComposeContext{
+		dir:   dir,
+		write: true,
+		app:   app,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := fmt.Sprintf(`=== Procfile
+web: npm start
+
+=== Add-ons
+db (postgres:10): provision PostgreSQL and set %s
+
+=== Commands
+herogate config:set 'GREETING=Hello world' NODE_ENV=production
+herogate port:set 3000
+%s    Skipped config var DATABASE_URL: it points to the service db, set the URL of the add-on instead
+
+Writing %s... done
+`, color.New(color.FgGreen).Sprint("DATABASE_URL"), color.New(color.FgYellow).Sprint("▸"), color.New(color.FgCyan).Sprint("Procfile"))
+	if writer.String() != expected {
+		t.Fatalf("Expected outputs are `%s`, but get `%s`", expected, writer.String())
+	}
+
+	procfile, err := ioutil.ReadFile(filepath.Join(dir, "Procfile"))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if string(procfile) != "web: npm start\n" {
+		t.Fatalf("Expected Procfile is `web: npm start`, but get `%s`", string(procfile))
+	}
+}
+
+func TestProcessImportCompose__notFound(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = processImportCompose(&importComposeContext{
+		dir: dir,
+		app: cli.NewApp(),
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf("%s    docker-compose.yml is not found", color.New(color.FgRed).Sprint("▸"))
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
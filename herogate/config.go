@@ -5,6 +5,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/rhymond/gopad"
@@ -12,6 +13,7 @@ import (
 	"github.com/urfave/cli"
 	"github.com/wata727/herogate/api"
 	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/api/objects"
 )
 
 type configContext struct {
@@ -34,7 +36,7 @@ func Config(ctx *cli.Context) error {
 	return processConfig(&configContext{
 		name: name,
 		app:  ctx.App,
-		client: api.NewClient(&api.ClientOption{
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
@@ -74,6 +76,46 @@ func putsEnvVars(envVars map[string]string, writer io.Writer) {
 	}
 }
 
+func putsChanges(changes []*objects.Change, writer io.Writer) {
+	if len(changes) == 0 {
+		fmt.Fprintln(writer, "No changes")
+		return
+	}
+
+	var actionLength, resourceLength int
+	for _, change := range changes {
+		if actionLength < len(change.Action) {
+			actionLength = len(change.Action)
+		}
+		if resourceLength < len(change.LogicalResourceID) {
+			resourceLength = len(change.LogicalResourceID)
+		}
+	}
+
+	for _, change := range changes {
+		var actionColor *color.Color
+		switch change.Action {
+		case "Add":
+			actionColor = color.New(color.FgGreen)
+		case "Remove":
+			actionColor = color.New(color.FgRed)
+		default:
+			actionColor = color.New(color.FgYellow)
+		}
+
+		// 1 = space
+		action := strings.Replace(gopad.Right(change.Action, actionLength+1), change.Action, actionColor.Sprint(change.Action), 1)
+		line := action + gopad.Right(change.LogicalResourceID, resourceLength+1) + change.ResourceType
+		switch change.Replacement {
+		case "True":
+			line += color.New(color.FgRed).Sprint(" (replacement)")
+		case "Conditional":
+			line += color.New(color.FgYellow).Sprint(" (may require replacement)")
+		}
+		fmt.Fprintln(writer, line)
+	}
+}
+
 type configGetContext struct {
 	name   string
 	env    string
@@ -101,7 +143,7 @@ func ConfigGet(ctx *cli.Context) error {
 		name: name,
 		env:  env,
 		app:  ctx.App,
-		client: api.NewClient(&api.ClientOption{
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
@@ -126,10 +168,13 @@ func processConfigGet(ctx *configGetContext) error {
 }
 
 type configSetContext struct {
-	name   string
-	args   []string
-	app    *cli.App
-	client iface.ClientInterface
+	name    string
+	args    []string
+	dryRun  bool
+	wait    time.Duration
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
 }
 
 // ConfigSet injects environment variables to application containers.
@@ -147,10 +192,13 @@ func ConfigSet(ctx *cli.Context) error {
 	}
 
 	return processConfigSet(&configSetContext{
-		name: name,
-		args: ctx.Args(),
-		app:  ctx.App,
-		client: api.NewClient(&api.ClientOption{
+		name:    name,
+		args:    ctx.Args(),
+		dryRun:  ctx.Bool("dry-run"),
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
@@ -171,19 +219,48 @@ func processConfigSet(ctx *configSetContext) error {
 				),
 				1)
 		}
+		if env[0] == portEnvName {
+			return portConfigVarError()
+		}
 		envVars[env[0]] = env[1]
 		envList = append(envList, color.New(color.FgGreen).Sprint(env[0]))
 	}
 
-	_, err := ctx.client.GetApp(ctx.name)
+	app, err := ctx.client.GetApp(ctx.name)
 	if err != nil {
 		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
 	}
 
 	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
-	fmt.Fprintf(ctx.app.Writer, "Setting %s and restarting %s...\r", strings.Join(envList, ", "), appStr)
+	if ctx.dryRun {
+		fmt.Fprintf(ctx.app.Writer, "Previewing setting %s on %s...\r", strings.Join(envList, ", "), appStr)
+		changes, err := ctx.client.PreviewSetEnvVars(ctx.name, envVars)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Failed to preview changes: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+		fmt.Fprintf(ctx.app.Writer, "Previewing setting %s on %s... done\n", strings.Join(envList, ", "), appStr)
+		putsChanges(changes, ctx.app.Writer)
+		return nil
+	}
+
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
 
-	err = ctx.client.SetEnvVars(ctx.name, envVars)
+	progress := fmt.Sprintf("Setting %s and restarting %s...\r", strings.Join(envList, ", "), appStr)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		return ctx.client.SetEnvVars(ctx.name, envVars)
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"appName": ctx.name,
@@ -199,6 +276,9 @@ func processConfigSet(ctx *configSetContext) error {
 type configUnsetContext struct {
 	name    string
 	envList []string
+	dryRun  bool
+	wait    time.Duration
+	verbose bool
 	app     *cli.App
 	client  iface.ClientInterface
 }
@@ -220,15 +300,24 @@ func ConfigUnset(ctx *cli.Context) error {
 	return processConfigUnset(&configUnsetContext{
 		name:    name,
 		envList: ctx.Args(),
+		dryRun:  ctx.Bool("dry-run"),
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
 		app:     ctx.App,
-		client: api.NewClient(&api.ClientOption{
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
 }
 
 func processConfigUnset(ctx *configUnsetContext) error {
-	_, err := ctx.client.GetApp(ctx.name)
+	for _, env := range ctx.envList {
+		if env == portEnvName {
+			return portConfigVarError()
+		}
+	}
+
+	app, err := ctx.client.GetApp(ctx.name)
 	if err != nil {
 		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
 	}
@@ -239,9 +328,35 @@ func processConfigUnset(ctx *configUnsetContext) error {
 	}
 
 	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
-	fmt.Fprintf(ctx.app.Writer, "Unsetting %s and restarting %s...\r", strings.Join(coloredEnvList, ", "), appStr)
+	if ctx.dryRun {
+		fmt.Fprintf(ctx.app.Writer, "Previewing unsetting %s on %s...\r", strings.Join(coloredEnvList, ", "), appStr)
+		changes, err := ctx.client.PreviewUnsetEnvVars(ctx.name, ctx.envList)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Failed to preview changes: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+		fmt.Fprintf(ctx.app.Writer, "Previewing unsetting %s on %s... done\n", strings.Join(coloredEnvList, ", "), appStr)
+		putsChanges(changes, ctx.app.Writer)
+		return nil
+	}
+
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
 
-	err = ctx.client.UnsetEnvVars(ctx.name, ctx.envList)
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	progress := fmt.Sprintf("Unsetting %s and restarting %s...\r", strings.Join(coloredEnvList, ", "), appStr)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		return ctx.client.UnsetEnvVars(ctx.name, ctx.envList)
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"appName": ctx.name,
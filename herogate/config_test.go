@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/golang/mock/gomock"
@@ -187,6 +188,28 @@ func TestProcessConfigSet__invalidEnvFormat(t *testing.T) {
 	}
 }
 
+func TestProcessConfigSet__port(t *testing.T) {
+	err := processConfigSet(&configSetContext{
+		name:   "young-eyrie-24091",
+		args:   []string{"PORT=5000"},
+		app:    cli.NewApp(),
+		client: api.NewClient(&api.ClientOption{}),
+	})
+
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+	expected := fmt.Sprintf(
+		"%s    %s is set by Herogate. Run %s to change the port.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgGreen).Sprint("PORT"),
+		color.New(color.FgCyan).Sprint("herogate port:set PORT"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
 func TestProcessConfigSet__invalidAppName(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -271,3 +294,167 @@ func TestProcessConfigUnset__invalidAppName(t *testing.T) {
 		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
 	}
 }
+
+func TestProcessConfigSet__dryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{}, nil)
+	// Expect to preview environment variables
+	client.EXPECT().PreviewSetEnvVars("young-eyrie-24091", map[string]string{
+		"RAILS_ENV": "production",
+	}).Return([]*objects.Change{
+		{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateApplicationContainer",
+			ResourceType:      "AWS::ECS::TaskDefinition",
+			Replacement:       "True",
+		},
+		{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateApplicationService",
+			ResourceType:      "AWS::ECS::Service",
+			Replacement:       "False",
+		},
+	}, nil)
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	err := processConfigSet(&configSetContext{
+		name:   "young-eyrie-24091",
+		args:   []string{"RAILS_ENV=production"},
+		dryRun: true,
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	railsEnv := color.New(color.FgGreen).Sprint("RAILS_ENV")
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	modify := color.New(color.FgYellow).Sprint("Modify")
+	expected := fmt.Sprintf("Previewing setting %s on %s...\r", railsEnv, appStr)
+	expected = expected + fmt.Sprintf(`Previewing setting %s on %s... done
+%s HerogateApplicationContainer AWS::ECS::TaskDefinition%s
+%s HerogateApplicationService   AWS::ECS::Service
+`, railsEnv, appStr, modify, color.New(color.FgRed).Sprint(" (replacement)"), modify)
+
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessConfigUnset__dryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{}, nil)
+	// Expect to preview environment variables
+	client.EXPECT().PreviewUnsetEnvVars("young-eyrie-24091", []string{"RAILS_ENV"}).Return([]*objects.Change{}, nil)
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	err := processConfigUnset(&configUnsetContext{
+		name:    "young-eyrie-24091",
+		envList: []string{"RAILS_ENV"},
+		dryRun:  true,
+		app:     app,
+		client:  client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	railsEnv := color.New(color.FgGreen).Sprint("RAILS_ENV")
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf("Previewing unsetting %s on %s...\r", railsEnv, appStr)
+	expected = expected + fmt.Sprintf("Previewing unsetting %s on %s... done\nNo changes\n", railsEnv, appStr)
+
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessConfigSet__busy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to set environment variables, but another operation started
+	client.EXPECT().SetEnvVars("young-eyrie-24091", map[string]string{
+		"RAILS_ENV": "production",
+	}).Return(&api.StackBusyError{AppName: "young-eyrie-24091", Status: "UPDATE_IN_PROGRESS"})
+
+	err := processConfigSet(&configSetContext{
+		name:   "young-eyrie-24091",
+		args:   []string{"RAILS_ENV=production"},
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    %s is busy with a running update (UPDATE_IN_PROGRESS).\n%s    Wait for it to finish, or re-run this command without %s.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgCyan).Sprint("--no-wait"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
+func TestProcessConfigSet__busyAfterWaiting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to set environment variables, but another operation started
+	client.EXPECT().SetEnvVars("young-eyrie-24091", map[string]string{
+		"RAILS_ENV": "production",
+	}).Return(&api.StackBusyError{AppName: "young-eyrie-24091", Status: "UPDATE_IN_PROGRESS"})
+
+	err := processConfigSet(&configSetContext{
+		name:   "young-eyrie-24091",
+		args:   []string{"RAILS_ENV=production"},
+		wait:   10 * time.Minute,
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    %s is busy with a running update (UPDATE_IN_PROGRESS).\n%s    The update started while waiting. Wait for it to finish, or re-run this command with a longer %s.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgCyan).Sprint("--wait-timeout"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
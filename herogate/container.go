@@ -0,0 +1,153 @@
+package herogate
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/hecticjeff/procfile"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/api/objects"
+)
+
+// runDocker runs the docker command. The stdin is passed to the command if it is not empty.
+// It is a variable so that tests can replace it.
+var runDocker = func(w io.Writer, stdin string, args ...string) error {
+	cmd := exec.Command("docker", args...)
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	return cmd.Run()
+}
+
+type containerReleaseContext struct {
+	name     string
+	image    string
+	procfile string
+	push     bool
+	wait     time.Duration
+	verbose  bool
+	app      *cli.App
+	client   iface.ClientInterface
+}
+
+// ContainerRelease deploys the pre-built image to the app without the pipeline.
+// Processes are read from Procfile in the current directory, or the processes currently deployed are used.
+// With `--push`, the local image is pushed to the app's registry before deploying.
+func ContainerRelease(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	image := ctx.Args().First()
+	if image == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require argument, You must specify an image", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	file, err := ioutil.ReadFile("Procfile")
+	if err != nil {
+		logrus.Debug("Failed to load Procfile")
+	}
+
+	return processContainerRelease(&containerReleaseContext{
+		name:     name,
+		image:    image,
+		procfile: string(file),
+		push:     ctx.Bool("push"),
+		wait:     waitTimeout(ctx),
+		verbose:  ctx.Bool("verbose"),
+		app:      ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processContainerRelease(ctx *containerReleaseContext) error {
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	image := ctx.image
+	if ctx.push {
+		if image, err = pushImage(ctx.client, ctx.name, ctx.image, ctx.app.Writer); err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Failed to push the image: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+	}
+
+	processes := map[string][]string{}
+	for name, process := range procfile.Parse(ctx.procfile) {
+		if name == testProcess {
+			continue
+		}
+		processes[name] = append([]string{process.Command}, process.Arguments...)
+	}
+
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	imageStr := color.New(color.FgCyan).Sprint(image)
+	progress := fmt.Sprintf("Releasing %s to %s...\r", imageStr, appStr)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	var release *objects.Release
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		var releaseErr error
+		release, releaseErr = ctx.client.ReleaseImage(ctx.name, image, processes)
+		return releaseErr
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to release the image: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Releasing %s to %s... done, v%d\n", imageStr, appStr, release.Version)
+
+	return nil
+}
+
+// pushImage pushes the local image to the app's registry, and returns the pushed image.
+// The image is tagged with the current time so that the task definition is always changed.
+func pushImage(client iface.ClientInterface, name string, image string, w io.Writer) (string, error) {
+	registry, err := client.GetRegistry(name)
+	if err != nil {
+		return "", err
+	}
+
+	remote := fmt.Sprintf("%s:herogate-%s", registry.URI, time.Now().UTC().Format("20060102150405"))
+	if err = runDocker(w, registry.Password, "login", "--username", registry.Username, "--password-stdin", registry.Endpoint); err != nil {
+		return "", err
+	}
+	if err = runDocker(w, "", "tag", image, remote); err != nil {
+		return "", err
+	}
+	if err = runDocker(w, "", "push", remote); err != nil {
+		return "", err
+	}
+
+	return remote, nil
+}
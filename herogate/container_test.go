@@ -0,0 +1,113 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessContainerRelease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to release the image with processes in Procfile
+	client.EXPECT().ReleaseImage("young-eyrie-24091", "registry.example.com/app:1.0", map[string][]string{
+		"web":    {"bundle", "exec", "rails", "server"},
+		"worker": {"bundle", "exec", "sidekiq"},
+	}).Return(&objects.Release{
+		Version: 2,
+		Image:   "registry.example.com/app:1.0",
+	}, nil)
+
+	err := processContainerRelease(&containerReleaseContext{
+		name:     "young-eyrie-24091",
+		image:    "registry.example.com/app:1.0",
+		procfile: "web: bundle exec rails server\nworker: bundle exec sidekiq\n",
+		app:      app,
+		client:   client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	imageStr := color.New(color.FgCyan).Sprint("registry.example.com/app:1.0")
+	expected := fmt.Sprintf("Releasing %s to %s...\rReleasing %s to %s... done, v2\n", imageStr, appStr, imageStr, appStr)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessContainerRelease__push(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	commands := [][]string{}
+	original := runDocker
+	runDocker = func(w io.Writer, stdin string, args ...string) error {
+		commands = append(commands, append([]string{stdin}, args...))
+		return nil
+	}
+	defer func() { runDocker = original }()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to get registry
+	client.EXPECT().GetRegistry("young-eyrie-24091").Return(&objects.Registry{
+		URI:      "123456789012.dkr.ecr.us-east-1.amazonaws.com/young-eyrie-24091",
+		Endpoint: "https://123456789012.dkr.ecr.us-east-1.amazonaws.com",
+		Username: "AWS",
+		Password: "secret",
+	}, nil)
+	// Expect to release the pushed image with the current processes
+	client.EXPECT().ReleaseImage("young-eyrie-24091", gomock.Any(), map[string][]string{}).Return(&objects.Release{
+		Version: 1,
+	}, nil)
+
+	err := processContainerRelease(&containerReleaseContext{
+		name:   "young-eyrie-24091",
+		image:  "my-app:latest",
+		push:   true,
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	if len(commands) != 3 {
+		t.Fatalf("Expected to run 3 docker commands, but get %d", len(commands))
+	}
+	if commands[0][0] != "secret" || commands[0][1] != "login" {
+		t.Fatalf("Expected to login with the password, but get `%v`", commands[0])
+	}
+	pattern := regexp.MustCompile(`^123456789012\.dkr\.ecr\.us-east-1\.amazonaws\.com/young-eyrie-24091:herogate-\d{14}$`)
+	if commands[1][1] != "tag" || commands[1][2] != "my-app:latest" || !pattern.MatchString(commands[1][3]) {
+		t.Fatalf("Expected to tag the image, but get `%v`", commands[1])
+	}
+	if commands[2][1] != "push" || commands[2][2] != commands[1][3] {
+		t.Fatalf("Expected to push the image, but get `%v`", commands[2])
+	}
+}
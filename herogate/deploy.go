@@ -0,0 +1,228 @@
+package herogate
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/heroku"
+	"github.com/wata727/herogate/manifest"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
+)
+
+// ignoreFile is the file of patterns excluded from the source archive.
+// `.dockerignore` is not used because docker build applies it in the pipeline.
+const ignoreFile = ".gitignore"
+
+// requiredFiles are files in the root directory which the pipeline reads, so they are never excluded.
+var requiredFiles = []string{"Dockerfile", "Procfile", manifest.FileName, heroku.HerokuYMLFileName}
+
+type deployContext struct {
+	name    string
+	dir     string
+	wait    time.Duration
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
+}
+
+// Deploy packages the directory as a zip archive and deploys it via the pipeline.
+// It doesn't require the Git repository. The pipeline source is changed to S3 when deploying first.
+func Deploy(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	dir := ctx.Args().First()
+	if dir == "" {
+		dir = "."
+	}
+
+	return processDeploy(&deployContext{
+		name:    name,
+		dir:     dir,
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processDeploy(ctx *deployContext) error {
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	if api.ComparePlatformVersions(app.PlatformVersion, api.S3SourcePlatformVersion) < 0 {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    %s is on the platform version %s, but deploying without Git requires %s or later.\n%s    Run %s first.",
+				color.New(color.FgRed).Sprint("▸"),
+				appStr,
+				app.PlatformVersion,
+				api.S3SourcePlatformVersion,
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgCyan).Sprint("herogate apps:upgrade"),
+			),
+			1,
+		)
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Packaging %s...\r", ctx.dir)
+	archive, count, err := packageSource(ctx.dir)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to package the source: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+	fmt.Fprintf(ctx.app.Writer, "Packaging %s... done, %d files (%d KB)\n", ctx.dir, count, (archive.Len()+1023)/1024)
+
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	progress := fmt.Sprintf("Preparing the source of %s...\r", appStr)
+	fmt.Fprint(ctx.app.Writer, progress)
+	var changed bool
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		var sourceErr error
+		changed, sourceErr = ctx.client.UseS3Source(ctx.name)
+		return sourceErr
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to change the source: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+	if changed {
+		fmt.Fprintf(ctx.app.Writer, "Preparing the source of %s... done, changed to S3\n", appStr)
+	} else {
+		fmt.Fprintf(ctx.app.Writer, "Preparing the source of %s... done\n", appStr)
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Deploying to %s...\r", appStr)
+	executionID, err := ctx.client.DeploySource(ctx.name, bytes.NewReader(archive.Bytes()))
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to deploy the source: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+	fmt.Fprintf(ctx.app.Writer, "Deploying to %s... started (%s)\n", appStr, executionID)
+	fmt.Fprintf(ctx.app.Writer, "Run %s to see the progress\n", color.New(color.FgCyan).Sprint("herogate logs"))
+
+	return nil
+}
+
+// packageSource returns the zip archive of the directory and the number of files in it.
+// The `.git` directory and files matched by patterns in `.gitignore` are excluded except for required files.
+func packageSource(dir string) (*bytes.Buffer, int, error) {
+	patterns, err := readIgnorePatterns(filepath.Join(dir, ignoreFile))
+	if err != nil {
+		return nil, 0, err
+	}
+	matcher := gitignore.NewMatcher(append([]gitignore.Pattern{gitignore.ParsePattern(".git", nil)}, patterns...))
+	required := map[string]bool{}
+	for _, file := range requiredFiles {
+		required[file] = true
+	}
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	count := 0
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if !required[filepath.ToSlash(rel)] && matcher.Match(strings.Split(filepath.ToSlash(rel), "/"), info.IsDir()) {
+			logrus.Debug("Ignore " + rel)
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		header.Method = zip.Deflate
+		writer, err := w.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if _, err = io.Copy(writer, file); err != nil {
+			return err
+		}
+		count++
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, 0, err
+	}
+
+	return buf, count, nil
+}
+
+func readIgnorePatterns(path string) ([]gitignore.Pattern, error) {
+	patterns := []gitignore.Pattern{}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return patterns, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
+	}
+
+	return patterns, scanner.Err()
+}
@@ -0,0 +1,108 @@
+package herogate
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestPackageSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		".gitignore":           "# dependencies\nnode_modules\n*.log\n*.yml\n",
+		".dockerignore":        "tmp/\n",
+		"herogate.yml":         "web:\n  port: 3000\n",
+		"config/database.yml":  "development: {}\n",
+		".git/HEAD":            "ref: refs/heads/master\n",
+		"Procfile":             "web: npm start\n",
+		"index.js":             "console.log('hello')\n",
+		"lib/app.js":           "module.exports = {}\n",
+		"node_modules/a/a.js":  "module.exports = {}\n",
+		"tmp/cache":            "cache\n",
+		"logs/development.log": "log\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %s", err)
+		}
+		if err = ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %s", err)
+		}
+	}
+
+	archive, count, err := packageSource(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	if err != nil {
+		t.Fatalf("Failed to read the archive: %s", err)
+	}
+	names := []string{}
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	sort.Strings(names)
+
+	// `.dockerignore` is applied by docker build, and required files are kept even if `.gitignore` matches them
+	expected := []string{".dockerignore", ".gitignore", "Procfile", "herogate.yml", "index.js", "lib/app.js", "tmp/cache"}
+	if !cmp.Equal(expected, names) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, names))
+	}
+	if count != len(expected) {
+		t.Fatalf("Expected count is %d, but get %d", len(expected), count)
+	}
+}
+
+func TestProcessDeploy__oldPlatform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:            "young-eyrie-24091",
+		Status:          "CREATE_COMPLETE",
+		PlatformVersion: "1.0",
+	}, nil)
+
+	err := processDeploy(&deployContext{
+		name:   "young-eyrie-24091",
+		dir:    ".",
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    %s is on the platform version 1.0, but deploying without Git requires 1.1 or later.\n%s    Run %s first.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgCyan).Sprint("herogate apps:upgrade"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
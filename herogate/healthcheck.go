@@ -0,0 +1,188 @@
+package herogate
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/api/objects"
+)
+
+type healthCheckContext struct {
+	name   string
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// HealthCheck displays the health check settings of the web process.
+func HealthCheck(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processHealthCheck(&healthCheckContext{
+		name: name,
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processHealthCheck(ctx *healthCheckContext) error {
+	healthCheck, err := ctx.client.GetHealthCheck(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	fmt.Fprintln(ctx.app.Writer, fmt.Sprintf("=== %s Health Check", ctx.name))
+	putsHealthCheck(healthCheck, ctx.app.Writer)
+
+	return nil
+}
+
+func putsHealthCheck(healthCheck *objects.HealthCheck, writer io.Writer) {
+	settings := map[string]string{
+		"path":              healthCheck.Path,
+		"interval":          fmt.Sprintf("%ds", healthCheck.Interval),
+		"healthy-threshold": strconv.Itoa(healthCheck.HealthyThreshold),
+		"command":           "(none)",
+	}
+	if healthCheck.Command != "" {
+		settings["command"] = healthCheck.Command
+	}
+	putsEnvVars(settings, writer)
+}
+
+func healthCheckSummary(healthCheck *objects.HealthCheck) string {
+	return fmt.Sprintf("GET %s every %ds, healthy after %d checks", healthCheck.Path, healthCheck.Interval, healthCheck.HealthyThreshold)
+}
+
+type healthCheckSetContext struct {
+	name             string
+	path             string
+	interval         int
+	healthyThreshold int
+	command          *string
+	dryRun           bool
+	wait             time.Duration
+	verbose          bool
+	app              *cli.App
+	client           iface.ClientInterface
+}
+
+// HealthCheckSet changes the health check settings of the web process.
+// Only the given settings are changed, and the others are kept.
+func HealthCheckSet(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if !ctx.IsSet("path") && !ctx.IsSet("interval") && !ctx.IsSet("healthy-threshold") && !ctx.IsSet("command") {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flags, You must specify at least one of `--path`, `--interval`, `--healthy-threshold` and `--command`", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	var command *string
+	if ctx.IsSet("command") {
+		value := ctx.String("command")
+		command = &value
+	}
+
+	return processHealthCheckSet(&healthCheckSetContext{
+		name:             name,
+		path:             ctx.String("path"),
+		interval:         ctx.Int("interval"),
+		healthyThreshold: ctx.Int("healthy-threshold"),
+		command:          command,
+		dryRun:           ctx.Bool("dry-run"),
+		wait:             waitTimeout(ctx),
+		verbose:          ctx.Bool("verbose"),
+		app:              ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processHealthCheckSet(ctx *healthCheckSetContext) error {
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	healthCheck, err := ctx.client.GetHealthCheck(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if ctx.path != "" {
+		healthCheck.Path = ctx.path
+	}
+	if ctx.interval != 0 {
+		healthCheck.Interval = ctx.interval
+	}
+	if ctx.healthyThreshold != 0 {
+		healthCheck.HealthyThreshold = ctx.healthyThreshold
+	}
+	if ctx.command != nil {
+		healthCheck.Command = *ctx.command
+	}
+	if err = api.ValidateHealthCheck(healthCheck); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	if ctx.dryRun {
+		fmt.Fprintf(ctx.app.Writer, "Previewing setting health check of %s...\r", appStr)
+		changes, err := ctx.client.PreviewSetHealthCheck(ctx.name, healthCheck)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Failed to preview changes: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+		fmt.Fprintf(ctx.app.Writer, "Previewing setting health check of %s... done\n", appStr)
+		putsChanges(changes, ctx.app.Writer)
+		return nil
+	}
+
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	progress := fmt.Sprintf("Setting health check of %s...\r", appStr)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		return ctx.client.SetHealthCheck(ctx.name, healthCheck)
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+		}).Fatal("Failed to set health check: " + err.Error())
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Setting health check of %s... done\n", appStr)
+	putsHealthCheck(healthCheck, ctx.app.Writer)
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessHealthCheckSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to get the current health check
+	client.EXPECT().GetHealthCheck("young-eyrie-24091").Return(&objects.HealthCheck{
+		Path:             "/",
+		Interval:         30,
+		HealthyThreshold: 5,
+	}, nil)
+	// Expect to set health check with the given settings only
+	client.EXPECT().SetHealthCheck("young-eyrie-24091", &objects.HealthCheck{
+		Path:             "/healthz",
+		Interval:         15,
+		HealthyThreshold: 5,
+	}).Return(nil)
+
+	err := processHealthCheckSet(&healthCheckSetContext{
+		name:     "young-eyrie-24091",
+		path:     "/healthz",
+		interval: 15,
+		app:      app,
+		client:   client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf(
+		"Setting health check of %s...\rSetting health check of %s... done\n%s:           (none)\n%s: 5\n%s:          15s\n%s:              /healthz\n",
+		appStr,
+		appStr,
+		color.New(color.FgGreen).Sprint("command"),
+		color.New(color.FgGreen).Sprint("healthy-threshold"),
+		color.New(color.FgGreen).Sprint("interval"),
+		color.New(color.FgGreen).Sprint("path"),
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessHealthCheckSet__dryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to get the current health check
+	client.EXPECT().GetHealthCheck("young-eyrie-24091").Return(&objects.HealthCheck{
+		Path:             "/",
+		Interval:         30,
+		HealthyThreshold: 5,
+	}, nil)
+	// Expect to preview health check with the given settings only
+	client.EXPECT().PreviewSetHealthCheck("young-eyrie-24091", &objects.HealthCheck{
+		Path:             "/healthz",
+		Interval:         30,
+		HealthyThreshold: 5,
+	}).Return([]*objects.Change{
+		{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateLoadBalancerTargetGroup",
+			ResourceType:      "AWS::ElasticLoadBalancingV2::TargetGroup",
+			Replacement:       "False",
+		},
+	}, nil)
+
+	err := processHealthCheckSet(&healthCheckSetContext{
+		name:   "young-eyrie-24091",
+		path:   "/healthz",
+		dryRun: true,
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf(
+		"Previewing setting health check of %s...\rPreviewing setting health check of %s... done\n%s HerogateLoadBalancerTargetGroup AWS::ElasticLoadBalancingV2::TargetGroup\n",
+		appStr,
+		appStr,
+		color.New(color.FgYellow).Sprint("Modify"),
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessHealthCheckSet__invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+	// Expect to get the current health check
+	client.EXPECT().GetHealthCheck("young-eyrie-24091").Return(&objects.HealthCheck{
+		Path:             "/",
+		Interval:         30,
+		HealthyThreshold: 5,
+	}, nil)
+
+	err := processHealthCheckSet(&healthCheckSetContext{
+		name:   "young-eyrie-24091",
+		path:   "healthz",
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+	expected := fmt.Sprintf("%s    Invalid path `healthz`, must start with /", color.New(color.FgRed).Sprint("▸"))
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
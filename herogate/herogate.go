@@ -1,12 +1,52 @@
 package herogate
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/fake"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/api/objects"
 	git "gopkg.in/src-d/go-git.v4"
 )
 
+// newClient returns the API client. When `HEROGATE_BACKEND=fake` is set, it returns the fake client
+// which works without AWS. Its state is saved to `HEROGATE_FAKE_STATE`, and operations take `HEROGATE_FAKE_DELAY`.
+func newClient(option *api.ClientOption) iface.ClientInterface {
+	if os.Getenv("HEROGATE_BACKEND") != "fake" {
+		return api.NewClient(option)
+	}
+
+	statePath := os.Getenv("HEROGATE_FAKE_STATE")
+	if statePath == "" {
+		statePath = filepath.Join(os.TempDir(), "herogate-fake.json")
+	}
+	delay := fake.DefaultDelay
+	if value := os.Getenv("HEROGATE_FAKE_DELAY"); value != "" {
+		var err error
+		if delay, err = time.ParseDuration(value); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"HEROGATE_FAKE_DELAY": value,
+			}).Fatal("Failed to parse the delay: " + err.Error())
+		}
+	}
+	logrus.Debug("Use the fake backend: " + statePath)
+
+	return fake.NewClient(&fake.ClientOption{
+		StatePath: statePath,
+		Delay:     delay,
+	})
+}
+
 func detectAppFromRepo() (string, string) {
 	repo, err := git.PlainOpen(".")
 	if err != nil {
@@ -41,3 +81,218 @@ func detectAppFromRepo() (string, string) {
 	}).Debug("Detected application from local Git repository")
 	return string(matches[1][:]), string(matches[2][:])
 }
+
+// errWriter returns the writer for the error output of the app.
+// cli.App leaves ErrWriter nil unless it is set, and then the package level writer (stderr) is used.
+func errWriter(app *cli.App) io.Writer {
+	if app.ErrWriter != nil {
+		return app.ErrWriter
+	}
+	return cli.ErrWriter
+}
+
+// waitTimeout returns the maximum time to wait for a running operation of the app.
+// Commands wait by default, and `--wait-timeout` changes the time. When `--no-wait` is specified, it returns zero.
+func waitTimeout(ctx *cli.Context) time.Duration {
+	if ctx.Bool("no-wait") {
+		return 0
+	}
+	return ctx.Duration("wait-timeout")
+}
+
+// waitForIdleApp waits until the running operation of the app finishes.
+// For example, when the stack is updating by another `config:set` or the pipeline deployment.
+// If the timeout is zero, it does not wait and returns an error immediately.
+func waitForIdleApp(client iface.ClientInterface, app *objects.App, timeout time.Duration, w io.Writer) error {
+	if !api.StackInProgress(app.Status) {
+		return nil
+	}
+	if timeout <= 0 {
+		return busyAppError(&api.StackBusyError{AppName: app.Name, Status: app.Status}, timeout)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", app.Name)
+	operation := api.StackOperation(app.Status)
+	start := time.Now()
+	for api.StackInProgress(app.Status) {
+		elapsed := time.Since(start)
+		if elapsed > timeout {
+			return cli.NewExitError(
+				fmt.Sprintf(
+					"%s    Timed out waiting for the running %s of %s (%s)",
+					color.New(color.FgRed).Sprint("▸"),
+					operation,
+					appStr,
+					app.Status,
+				),
+				1,
+			)
+		}
+		fmt.Fprintf(w, "Waiting for the running %s of %s... %ds\r", operation, appStr, int(elapsed.Seconds()))
+
+		time.Sleep(progressCheckInterval)
+		var err error
+		app, err = client.GetApp(app.Name)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+		}
+	}
+	fmt.Fprintf(w, "Waiting for the running %s of %s... done\n", operation, appStr)
+
+	return nil
+}
+
+// busyAppError returns the error for the app busy with the running operation.
+// When the command didn't wait because of `--no-wait`, it suggests re-running without it.
+// Otherwise, another operation started after waiting, so it suggests raising the wait timeout.
+func busyAppError(err *api.StackBusyError, wait time.Duration) error {
+	hint := fmt.Sprintf("Wait for it to finish, or re-run this command without %s.", color.New(color.FgCyan).Sprint("--no-wait"))
+	if wait > 0 {
+		hint = fmt.Sprintf(
+			"The %s started while waiting. Wait for it to finish, or re-run this command with a longer %s.",
+			err.Operation(),
+			color.New(color.FgCyan).Sprint("--wait-timeout"),
+		)
+	}
+
+	return cli.NewExitError(
+		fmt.Sprintf(
+			"%s    %s is busy with a running %s (%s).\n%s    %s",
+			color.New(color.FgRed).Sprint("▸"),
+			color.New(color.FgMagenta).Sprintf("⬢ %s", err.AppName),
+			err.Operation(),
+			err.Status,
+			color.New(color.FgRed).Sprint("▸"),
+			hint,
+		),
+		1,
+	)
+}
+
+// stackEventStreamer writes stack events of the app which have not been written yet.
+// It is used for `--verbose` option. A nil streamer writes nothing.
+type stackEventStreamer struct {
+	client iface.ClientInterface
+	name   string
+	seen   map[string]bool
+}
+
+// newStackEventStreamer returns a streamer which ignores events that already happened.
+func newStackEventStreamer(client iface.ClientInterface, name string) *stackEventStreamer {
+	streamer := &stackEventStreamer{
+		client: client,
+		name:   name,
+		seen:   map[string]bool{},
+	}
+
+	events, err := client.DescribeAppEvents(name)
+	if err != nil {
+		// The stack may not be created yet
+		logrus.Debug("Failed to describe app events: " + err.Error())
+		return streamer
+	}
+	for _, event := range events {
+		streamer.seen[event.EventID] = true
+	}
+
+	return streamer
+}
+
+// flush writes new events and returns the number of written events.
+func (s *stackEventStreamer) flush(w io.Writer) int {
+	if s == nil {
+		return 0
+	}
+
+	events, err := s.client.DescribeAppEvents(s.name)
+	if err != nil {
+		// The stack may be deleted
+		logrus.Debug("Failed to describe app events: " + err.Error())
+		return 0
+	}
+
+	var written int
+	for _, event := range events {
+		if s.seen[event.EventID] {
+			continue
+		}
+		s.seen[event.EventID] = true
+		putsStackEvent(event, w)
+		written++
+	}
+
+	return written
+}
+
+func putsStackEvent(event *objects.StackEvent, w io.Writer) {
+	var statusColor *color.Color
+	switch {
+	case strings.HasSuffix(event.ResourceStatus, "_FAILED"):
+		statusColor = color.New(color.FgRed)
+	case strings.HasSuffix(event.ResourceStatus, "_IN_PROGRESS"):
+		statusColor = color.New(color.FgYellow)
+	default:
+		statusColor = color.New(color.FgGreen)
+	}
+
+	line := fmt.Sprintf(
+		"%s %s %s %s",
+		event.Timestamp.Local().Format("15:04:05"),
+		statusColor.Sprint(event.ResourceStatus),
+		event.LogicalResourceID,
+		event.ResourceType,
+	)
+	if event.ResourceStatusReason != "" {
+		line += fmt.Sprintf(" (%s)", event.ResourceStatusReason)
+	}
+	fmt.Fprintln(w, line)
+}
+
+// runWithEvents runs the function while streaming stack events.
+// When events are written, the progress message is written again because events overwrite it.
+// If the streamer is nil, it just runs the function.
+func runWithEvents(events *stackEventStreamer, w io.Writer, progress string, fn func() error) error {
+	if events == nil {
+		return fn()
+	}
+
+	ch := make(chan error, 1)
+	go func() {
+		ch <- fn()
+	}()
+
+	for {
+		select {
+		case err := <-ch:
+			events.flush(w)
+			return err
+		case <-time.After(progressCheckInterval):
+			if events.flush(w) > 0 {
+				fmt.Fprint(w, progress)
+			}
+		}
+	}
+}
+
+// stackFailureError returns the failure report of the app. The notes are appended as next steps.
+func stackFailureError(err *api.StackFailureError, operation string, notes ...string) error {
+	errorColor := color.New(color.FgRed)
+	lines := []string{
+		fmt.Sprintf("Failed to %s %s (%s).", operation, color.New(color.FgMagenta).Sprintf("⬢ %s", err.AppName), err.Status),
+	}
+	for _, failure := range err.Failures {
+		lines = append(lines, fmt.Sprintf("%s (%s) %s", failure.LogicalResourceID, failure.ResourceType, errorColor.Sprint(failure.ResourceStatus)))
+		if failure.Reason != "" {
+			lines = append(lines, "    Reason: "+failure.Reason)
+		}
+		if failure.Hint != "" {
+			lines = append(lines, "    Hint: "+failure.Hint)
+		}
+	}
+	lines = append(lines, notes...)
+
+	for i, line := range lines {
+		lines[i] = fmt.Sprintf("%s    %s", errorColor.Sprint("▸"), line)
+	}
+	return cli.NewExitError(strings.Join(lines, "\n"), 1)
+}
@@ -1,10 +1,23 @@
 package herogate
 
 import (
+	"bytes"
+	"flag"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/fake"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
 	git "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/config"
 )
@@ -145,3 +158,270 @@ func TestDetectAppFromRepo__invalidRemoteURL(t *testing.T) {
 		t.Fatalf("Expected app is empty, but get `%s`", app)
 	}
 }
+
+func TestWaitTimeout(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Args     []string
+		Expected time.Duration
+		Rest     []string
+	}{
+		{
+			Name:     "default",
+			Args:     []string{"FOO=bar"},
+			Expected: 10 * time.Minute,
+			Rest:     []string{"FOO=bar"},
+		},
+		{
+			Name:     "bare --wait",
+			Args:     []string{"--wait", "FOO=bar"},
+			Expected: 10 * time.Minute,
+			Rest:     []string{"FOO=bar"},
+		},
+		{
+			Name:     "--wait-timeout",
+			Args:     []string{"--wait-timeout", "30m", "FOO=bar"},
+			Expected: 30 * time.Minute,
+			Rest:     []string{"FOO=bar"},
+		},
+		{
+			Name:     "--no-wait",
+			Args:     []string{"--no-wait", "FOO=bar"},
+			Expected: 0,
+			Rest:     []string{"FOO=bar"},
+		},
+	}
+
+	for _, tc := range cases {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags := []cli.Flag{
+			cli.BoolFlag{Name: "wait"},
+			cli.DurationFlag{Name: "wait-timeout", Value: 10 * time.Minute},
+			cli.BoolFlag{Name: "no-wait"},
+		}
+		for _, f := range flags {
+			f.Apply(set)
+		}
+		if err := set.Parse(tc.Args); err != nil {
+			t.Fatalf("Unexpected error occurred in %s: %s", tc.Name, err)
+		}
+		ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+		if timeout := waitTimeout(ctx); timeout != tc.Expected {
+			t.Fatalf("Expected timeout is `%s`, but get `%s` in %s", tc.Expected, timeout, tc.Name)
+		}
+		if !cmp.Equal(tc.Rest, []string(ctx.Args())) {
+			t.Fatalf("Expected args are `%v`, but get `%v` in %s", tc.Rest, ctx.Args(), tc.Name)
+		}
+	}
+}
+
+func TestWaitForIdleApp(t *testing.T) {
+	// Wait only 1 second
+	progressCheckInterval = 1 * time.Second
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application after the update
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+
+	writer := new(bytes.Buffer)
+	err := waitForIdleApp(client, &objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_IN_PROGRESS",
+	}, 10*time.Minute, writer)
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	expected := fmt.Sprintf("Waiting for the running update of %s... 0s\rWaiting for the running update of %s... done\n", appStr, appStr)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestWaitForIdleApp__noWait(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+
+	err := waitForIdleApp(client, &objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_ROLLBACK_IN_PROGRESS",
+	}, 0, new(bytes.Buffer))
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    %s is busy with a running update rollback (UPDATE_ROLLBACK_IN_PROGRESS).\n%s    Wait for it to finish, or re-run this command without %s.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgCyan).Sprint("--no-wait"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
+func TestStackEventStreamer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	oldEvent := &objects.StackEvent{
+		EventID:           "1",
+		Timestamp:         time.Date(2017, time.December, 1, 12, 0, 0, 0, time.Local),
+		LogicalResourceID: "young-eyrie-24091",
+		ResourceType:      "AWS::CloudFormation::Stack",
+		ResourceStatus:    "UPDATE_COMPLETE",
+	}
+	newEvent := &objects.StackEvent{
+		EventID:              "2",
+		Timestamp:            time.Date(2017, time.December, 1, 12, 30, 0, 0, time.Local),
+		LogicalResourceID:    "HerogateApplicationService",
+		ResourceType:         "AWS::ECS::Service",
+		ResourceStatus:       "UPDATE_FAILED",
+		ResourceStatusReason: "Resource timed out",
+	}
+	gomock.InOrder(
+		// Expect to ignore events that already happened
+		client.EXPECT().DescribeAppEvents("young-eyrie-24091").Return([]*objects.StackEvent{oldEvent}, nil),
+		client.EXPECT().DescribeAppEvents("young-eyrie-24091").Return([]*objects.StackEvent{oldEvent, newEvent}, nil),
+		client.EXPECT().DescribeAppEvents("young-eyrie-24091").Return([]*objects.StackEvent{oldEvent, newEvent}, nil),
+	)
+
+	writer := new(bytes.Buffer)
+	streamer := newStackEventStreamer(client, "young-eyrie-24091")
+	if written := streamer.flush(writer); written != 1 {
+		t.Fatalf("Expected written events are `1`, but get `%d`", written)
+	}
+	if written := streamer.flush(writer); written != 0 {
+		t.Fatalf("Expected written events are `0`, but get `%d`", written)
+	}
+
+	expected := fmt.Sprintf(
+		"12:30:00 %s HerogateApplicationService AWS::ECS::Service (Resource timed out)\n",
+		color.New(color.FgRed).Sprint("UPDATE_FAILED"),
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestStackEventStreamer__nil(t *testing.T) {
+	var streamer *stackEventStreamer
+	writer := new(bytes.Buffer)
+
+	if written := streamer.flush(writer); written != 0 {
+		t.Fatalf("Expected written events are `0`, but get `%d`", written)
+	}
+	if writer.String() != "" {
+		t.Fatalf("Expected to output is empty, but get `%s`", writer.String())
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	defer os.Unsetenv("HEROGATE_BACKEND")
+
+	os.Setenv("HEROGATE_BACKEND", "fake")
+	if _, ok := newClient(&api.ClientOption{Region: "us-east-1"}).(*fake.Client); !ok {
+		t.Fatal("Expected client is the fake client, but it is not")
+	}
+
+	os.Unsetenv("HEROGATE_BACKEND")
+	if _, ok := newClient(&api.ClientOption{Region: "us-east-1"}).(*api.Client); !ok {
+		t.Fatal("Expected client is the API client, but it is not")
+	}
+}
+
+func TestFakeBackend(t *testing.T) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("Failed to get current directory: " + err.Error())
+	}
+	defer os.Chdir(currentDir)
+	dir, err := ioutil.TempDir("", "fakeBackend")
+	if err != nil {
+		t.Fatal("Failed to create tempdir: " + err.Error())
+	}
+	defer os.RemoveAll(dir)
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal("Failed to change directory: " + err.Error())
+	}
+
+	// The fake backend completes operations immediately
+	progressCheckInterval = 100 * time.Millisecond
+	defer func() { progressCheckInterval = 10 * time.Second }()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+	client := fake.NewClient(&fake.ClientOption{})
+
+	err = processAppsCreate(&appsCreateContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	err = processConfigSet(&configSetContext{
+		name:   "young-eyrie-24091",
+		args:   []string{"RAILS_ENV=production"},
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	writer.Reset()
+	err = processPs(&psContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if !strings.Contains(writer.String(), "web") {
+		t.Fatalf("Expected the web container is listed, but get `%s`", writer.String())
+	}
+
+	writer.Reset()
+	err = processLogs(&logsContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+		num:    100,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if !strings.Contains(writer.String(), "herogate[deployer]: young-eyrie-24091 update completed") {
+		t.Fatalf("Expected the update is logged, but get `%s`", writer.String())
+	}
+
+	err = processAppsDestroy(&appsDestroyContext{
+		name:    "young-eyrie-24091",
+		app:     app,
+		confirm: "young-eyrie-24091",
+		client:  client,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if client.StackExists("young-eyrie-24091") {
+		t.Fatal("Expected the app is destroyed, but it exists")
+	}
+}
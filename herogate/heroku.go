@@ -0,0 +1,36 @@
+package herogate
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/wata727/herogate/heroku"
+)
+
+// putsPlan puts settings translated from Heroku, and settings which are skipped because Herogate doesn't support them.
+func putsPlan(plan *heroku.Plan, source string, writer io.Writer) {
+	fmt.Fprintf(writer, "=== Settings from %s\n", source)
+
+	envVars := []string{}
+	for name := range plan.EnvVars {
+		envVars = append(envVars, name)
+	}
+	sort.Strings(envVars)
+	if len(envVars) == 0 {
+		envVars = append(envVars, "(none)")
+	}
+	fmt.Fprintf(writer, "Config Vars: %s\n", strings.Join(envVars, ", "))
+	if plan.DesiredCount > 0 {
+		fmt.Fprintf(writer, "Scale:       %d\n", plan.DesiredCount)
+	}
+	if plan.Size != nil {
+		fmt.Fprintf(writer, "Size:        %s CPU units, %s MiB\n", plan.Size.CPU, plan.Size.Memory)
+	}
+	for _, skipped := range plan.Skipped {
+		fmt.Fprintf(writer, "%s    Skipped %s\n", color.New(color.FgYellow).Sprint("▸"), skipped)
+	}
+	fmt.Fprint(writer, "\n")
+}
@@ -0,0 +1,111 @@
+package herogate
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	haikunator "github.com/Atrox/haikunatorgo"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/heroku"
+)
+
+type importHerokuContext struct {
+	name          string
+	branch        string
+	verbose       bool
+	keepOnFailure bool
+	configFile    string
+	formationFile string
+	domainsFile   string
+	addonsFile    string
+	app           *cli.App
+	client        iface.ClientInterface
+}
+
+// ImportHeroku creates a new app from the configuration exported from a Heroku app.
+// It takes the output of `heroku config --json`, and optionally the formation, domains and add-ons.
+// Settings which Herogate doesn't support are reported before the creation.
+func ImportHeroku(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		haikunator := haikunator.New()
+		name = haikunator.Haikunate()
+	}
+
+	return processImportHeroku(&importHerokuContext{
+		name:          name,
+		branch:        ctx.String("branch"),
+		verbose:       ctx.Bool("verbose"),
+		keepOnFailure: ctx.Bool("keep-on-failure"),
+		configFile:    ctx.String("config"),
+		formationFile: ctx.String("formation"),
+		domainsFile:   ctx.String("domains"),
+		addonsFile:    ctx.String("addons"),
+		app:           ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processImportHeroku(ctx *importHerokuContext) error {
+	if ctx.configFile == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    --config is required. Export config vars with `heroku config --json`.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	export := &heroku.Export{}
+	var err error
+	if err = readHerokuExport(ctx.configFile, func(data []byte) (err error) {
+		export.ConfigVars, err = heroku.ParseConfigVars(data)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err = readHerokuExport(ctx.formationFile, func(data []byte) (err error) {
+		export.Formation, err = heroku.ParseFormation(data)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err = readHerokuExport(ctx.domainsFile, func(data []byte) (err error) {
+		export.Domains, err = heroku.ParseDomains(data)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err = readHerokuExport(ctx.addonsFile, func(data []byte) (err error) {
+		export.Addons, err = heroku.ParseAddons(data)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return processAppsCreate(&appsCreateContext{
+		name:          ctx.name,
+		branch:        ctx.branch,
+		verbose:       ctx.verbose,
+		keepOnFailure: ctx.keepOnFailure,
+		plan:          export.Plan(),
+		planSource:    "Heroku",
+		app:           ctx.app,
+		client:        ctx.client,
+	})
+}
+
+// readHerokuExport reads the exported file and parses it. If the path is empty, it does nothing.
+func readHerokuExport(path string, parse func([]byte) error) error {
+	if path == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to read %s: %s", color.New(color.FgRed).Sprint("▸"), path, err.Error()), 1)
+	}
+	if err = parse(data); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package herogate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/options"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessImportHeroku(t *testing.T) {
+	// Wait only 1 second
+	progressCheckInterval = 1 * time.Second
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"config.json":    `{"RAILS_ENV": "production"}`,
+		"formation.json": `[{"type": "web", "quantity": 2, "size": "Standard-2X"}]`,
+		"domains.txt":    "www.example.com\n",
+	}
+	for name, content := range files {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(nil, errors.New("Stack not found"))
+	// Expect to check stack
+	client.EXPECT().StackExists("young-eyrie-24091").Return(false)
+	// Expect to create application with the exported configuration
+	client.EXPECT().CreateApp("young-eyrie-24091", &options.CreateApp{
+		Branch:       "master",
+		EnvVars:      map[string]string{"RAILS_ENV": "production"},
+		DesiredCount: 2,
+		CPU:          "512",
+		Memory:       "1024",
+	}).Return(nil, &api.StackFailureError{
+		AppName: "young-eyrie-24091",
+		Status:  "ROLLBACK_COMPLETE",
+	})
+	// Allow to get progress rate
+	client.EXPECT().GetAppCreationProgress("young-eyrie-24091").Return(50).AnyTimes()
+
+	processImportHeroku(&importHerokuContext{
+		name:          "young-eyrie-24091",
+		branch:        "master",
+		configFile:    filepath.Join(dir, "config.json"),
+		formationFile: filepath.Join(dir, "formation.json"),
+		domainsFile:   filepath.Join(dir, "domains.txt"),
+		app:           app,
+		client:        client,
+	})
+
+	expected := fmt.Sprintf(`=== Settings from Heroku
+Config Vars: RAILS_ENV
+Scale:       2
+Size:        512 CPU units, 1024 MiB
+%s    Skipped domain www.example.com: custom domains are not supported, point the DNS record to the Web URL
+
+`, color.New(color.FgYellow).Sprint("▸"))
+	if !strings.HasPrefix(writer.String(), expected) {
+		t.Fatalf("Expected plan outputs are not contained:\nExpected: %s\nActual: %s", expected, writer.String())
+	}
+}
+
+func TestProcessImportHeroku__invalidConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(`["RAILS_ENV"]`), 0644); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	err = processImportHeroku(&importHerokuContext{
+		name:       "young-eyrie-24091",
+		configFile: filepath.Join(dir, "config.json"),
+		app:        cli.NewApp(),
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf("%s    Failed to parse config vars: json: cannot unmarshal array into Go value of type map[string]string", color.New(color.FgRed).Sprint("▸"))
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
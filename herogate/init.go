@@ -0,0 +1,133 @@
+package herogate
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	haikunator "github.com/Atrox/haikunatorgo"
+	"github.com/fatih/color"
+	"github.com/hecticjeff/procfile"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/buildpack"
+	git "gopkg.in/src-d/go-git.v4"
+)
+
+type initContext struct {
+	dir    string
+	name   string
+	create bool
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// Init scaffolds Dockerfile, Procfile and .dockerignore for the project in the current directory.
+// Existing files are not overwritten. After that, it offers to create the app.
+func Init(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		haikunator := haikunator.New()
+		name = haikunator.Haikunate()
+	}
+
+	return processInit(&initContext{
+		dir:    ".",
+		name:   name,
+		create: ctx.Bool("create"),
+		app:    ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processInit(ctx *initContext) error {
+	pack, err := buildpack.Detect(ctx.dir)
+	if err == buildpack.ErrNotDetected {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    Couldn't detect the stack of the project. Supported stacks are %s.",
+				color.New(color.FgRed).Sprint("▸"),
+				strings.Join(buildpackNames(), ", "),
+			),
+			1,
+		)
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to detect the buildpack: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+	fmt.Fprintf(ctx.app.Writer, "=== %s app detected\n", color.New(color.FgGreen).Sprint(pack.Name))
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "Dockerfile", content: pack.Dockerfile(ctx.dir)},
+		{name: "Procfile", content: fmt.Sprintf("web: %s\n", pack.WebCommand(ctx.dir))},
+		{name: ".dockerignore", content: pack.DockerIgnore()},
+	}
+	for _, file := range files {
+		path := filepath.Join(ctx.dir, file.name)
+		if _, err := os.Stat(path); err == nil {
+			fmt.Fprintf(ctx.app.Writer, "Skipping %s, it already exists\n", color.New(color.FgCyan).Sprint(file.name))
+			continue
+		}
+		if err := ioutil.WriteFile(path, []byte(file.content), 0644); err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Failed to write %s: %s", color.New(color.FgRed).Sprint("▸"), file.name, err.Error()), 1)
+		}
+		fmt.Fprintf(ctx.app.Writer, "Writing %s... done\n", color.New(color.FgCyan).Sprint(file.name))
+	}
+
+	// Herogate routes requests to the web process only
+	if content, err := ioutil.ReadFile(filepath.Join(ctx.dir, "Procfile")); err == nil {
+		if _, ok := procfile.Parse(string(content))["web"]; !ok {
+			fmt.Fprintf(
+				ctx.app.Writer,
+				"%s    Procfile has no %s process. Add it to receive requests, e.g. %s\n",
+				color.New(color.FgYellow).Sprint("▸"),
+				color.New(color.FgGreen).Sprint("web"),
+				color.New(color.FgCyan).Sprintf("web: %s", pack.WebCommand(ctx.dir)),
+			)
+		}
+	}
+
+	if !ctx.create {
+		fmt.Fprintf(ctx.app.Writer, "\nCreate %s now? [y/N] ", color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name))
+		scanner := bufio.NewScanner(stdin)
+		scanner.Scan()
+		answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if answer != "y" && answer != "yes" {
+			fmt.Fprintf(ctx.app.Writer, "Run %s when you are ready\n", color.New(color.FgCyan).Sprint("herogate apps:create"))
+			return nil
+		}
+	}
+
+	// The herogate remote is added to the local repository, so initialize it if needed.
+	if _, err := git.PlainOpen(ctx.dir); err == git.ErrRepositoryNotExists {
+		logrus.Debug("Initialize Git repository")
+		if _, err = git.PlainInit(ctx.dir, false); err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Failed to initialize Git repository: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+		fmt.Fprintln(ctx.app.Writer, "Initializing Git repository... done")
+	}
+
+	return processAppsCreate(&appsCreateContext{
+		name:   ctx.name,
+		app:    ctx.app,
+		client: ctx.client,
+	})
+}
+
+func buildpackNames() []string {
+	names := []string{}
+	for _, pack := range buildpack.Buildpacks {
+		names = append(names, pack.Name)
+	}
+	return names
+}
@@ -0,0 +1,76 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+func TestProcessInit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = ioutil.WriteFile(filepath.Join(dir, "package.json"), []byte("{}\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, "Procfile"), []byte("worker: node worker.js\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	// Answer no to the confirmation
+	stdin = strings.NewReader("n\n")
+	defer func() { stdin = os.Stdin }()
+
+	err = processInit(&initContext{
+		dir:  dir,
+		name: "young-eyrie-24091",
+		app:  app,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := fmt.Sprintf(
+		"=== %s app detected\nWriting %s... done\nSkipping %s, it already exists\nWriting %s... done\n%s    Procfile has no %s process. Add it to receive requests, e.g. %s\n\nCreate %s now? [y/N] Run %s when you are ready\n",
+		color.New(color.FgGreen).Sprint("Node.js"),
+		color.New(color.FgCyan).Sprint("Dockerfile"),
+		color.New(color.FgCyan).Sprint("Procfile"),
+		color.New(color.FgCyan).Sprint(".dockerignore"),
+		color.New(color.FgYellow).Sprint("▸"),
+		color.New(color.FgGreen).Sprint("web"),
+		color.New(color.FgCyan).Sprint("web: npm start"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		color.New(color.FgCyan).Sprint("herogate apps:create"),
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+
+	dockerignore, err := ioutil.ReadFile(filepath.Join(dir, ".dockerignore"))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if string(dockerignore) != ".git\n.env\nDockerfile.herogate\nnode_modules\nnpm-debug.log\nyarn-error.log\n" {
+		t.Fatalf("Unexpected .dockerignore: %s", string(dockerignore))
+	}
+	procfile, err := ioutil.ReadFile(filepath.Join(dir, "Procfile"))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if string(procfile) != "worker: node worker.js\n" {
+		t.Fatalf("Expected not to overwrite Procfile, but get: %s", string(procfile))
+	}
+}
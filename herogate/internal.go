@@ -3,27 +3,46 @@ package herogate
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/hecticjeff/procfile"
 	"github.com/olebedev/config"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 	"github.com/wata727/herogate/api"
 	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/buildpack"
 	"github.com/wata727/herogate/container"
+	"github.com/wata727/herogate/heroku"
+	"github.com/wata727/herogate/manifest"
 )
 
+// testProcess is the Procfile process name for the test command.
+const testProcess = "test"
+
+// internalNow returns the time of releases. It is a variable so that tests can replace it.
+var internalNow = time.Now
+
 type internalGenerateTemplateContext struct {
-	name     string
-	image    string
-	procfile string
-	app      *cli.App
-	client   iface.ClientInterface
+	name      string
+	image     string
+	procfile  string
+	herokuYML string
+	manifest  string
+	app       *cli.App
+	client    iface.ClientInterface
 }
 
 // InternalGenerateTemplate generates new stack template from image name.
 // It gets template from the current stack and replace image by specified new image name.
+// If Procfile is not found, `run` entries in `heroku.yml` are used instead.
+// Per-process settings in `herogate.yml` are applied to the container definitions.
 // Finally, it puts generated template to stdout.
 func InternalGenerateTemplate(ctx *cli.Context) error {
 	name := ctx.Args().First()
@@ -39,19 +58,40 @@ func InternalGenerateTemplate(ctx *cli.Context) error {
 	if err != nil {
 		logrus.Debug("Failed to load Procfile")
 	}
+	herokuYMLFile, err := ioutil.ReadFile(heroku.HerokuYMLFileName)
+	if err != nil {
+		logrus.Debug("Failed to load " + heroku.HerokuYMLFileName)
+	}
+	manifestFile, err := ioutil.ReadFile(manifest.FileName)
+	if err != nil {
+		logrus.Debug("Failed to load " + manifest.FileName)
+	}
 
-	processInternalGenerateTemplate(&internalGenerateTemplateContext{
-		name:     name,
-		image:    image,
-		procfile: string(file),
-		app:      ctx.App,
-		client:   api.NewClient(&api.ClientOption{}),
+	return processInternalGenerateTemplate(&internalGenerateTemplateContext{
+		name:      name,
+		image:     image,
+		procfile:  string(file),
+		herokuYML: string(herokuYMLFile),
+		manifest:  string(manifestFile),
+		app:       ctx.App,
+		client:    newClient(&api.ClientOption{}),
 	})
-
-	return nil
 }
 
-func processInternalGenerateTemplate(ctx *internalGenerateTemplateContext) {
+func processInternalGenerateTemplate(ctx *internalGenerateTemplateContext) error {
+	if ctx.procfile == "" && ctx.herokuYML != "" {
+		herokuYML, err := heroku.ParseHerokuYML(ctx.herokuYML)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+		ctx.procfile = herokuYML.Procfile()
+	}
+
+	settings, err := manifest.Parse(ctx.manifest, ctx.procfile)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
 	template := ctx.client.GetTemplate(ctx.name)
 	cfg, err := config.ParseYaml(template)
 	if err != nil {
@@ -68,13 +108,49 @@ func processInternalGenerateTemplate(ctx *internalGenerateTemplateContext) {
 		}).Debug("Failed to get environment list" + err.Error())
 	}
 
+	// The port is kept across builds, and the web process listens on it.
+	// If the manifest declares the port of the web process, the load balancer follows it.
+	// The target group is not changed, so it works on any platform version without replacing resources.
+	port := api.TemplatePort(cfg)
+	if web, ok := settings.Processes["web"]; ok && web.Port != 0 && web.Port != port {
+		port = web.Port
+		api.ApplyPort(cfg, port)
+	}
+
 	definitions := []*container.Definition{}
 	proclist := procfile.Parse(ctx.procfile)
 	for name, process := range proclist {
-		definitions = append(
-			definitions,
-			container.New(name, ctx.image, append([]string{process.Command}, process.Arguments...), environment),
-		)
+		// The test process runs in the pipeline, not as a container.
+		if name == testProcess {
+			continue
+		}
+		command := append([]string{process.Command}, process.Arguments...)
+		p, declared := settings.Processes[name]
+		if declared && p.Command != "" {
+			command = p.CommandArgs()
+		}
+		definition := container.New(name, ctx.image, command, environment, port)
+		if name == "web" {
+			definition.HealthCheck = api.WebHealthCheck(cfg)
+		}
+		if declared {
+			p.Apply(definition)
+		}
+		definitions = append(definitions, definition)
+	}
+	// Sidecars run alongside processes in Procfile, so they are ignored without Procfile.
+	if len(definitions) > 0 {
+		for _, name := range settings.Sidecars(ctx.procfile) {
+			p := settings.Processes[name]
+			image := ctx.image
+			if p.Image != "" {
+				image = p.Image
+			}
+			definition := container.New(name, image, p.CommandArgs(), environment, port)
+			definition.DockerLabels = map[string]string{container.SidecarLabel: "true"}
+			p.Apply(definition)
+			definitions = append(definitions, definition)
+		}
 	}
 	sort.Slice(definitions, func(i, j int) bool {
 		return definitions[i].Name < definitions[j].Name
@@ -88,6 +164,14 @@ func processInternalGenerateTemplate(ctx *internalGenerateTemplateContext) {
 				"config":      cfg,
 			}).Fatal("Failed to set container definitions to template" + err.Error())
 		}
+
+		// The deployment by the pipeline is recorded in the release history of the template.
+		// If the deployment fails, the stack is rolled back and the release is removed with it.
+		api.RecordRelease(cfg, &objects.Release{
+			Description: "Deploy " + ctx.image,
+			Image:       ctx.image,
+			CreatedAt:   internalNow(),
+		})
 	}
 
 	result, err := config.RenderYaml(cfg.Root)
@@ -99,4 +183,129 @@ func processInternalGenerateTemplate(ctx *internalGenerateTemplateContext) {
 	}
 
 	fmt.Fprintln(ctx.app.Writer, result)
+
+	return nil
+}
+
+type internalTestCommandContext struct {
+	procfile string
+	appJSON  string
+	app      *cli.App
+}
+
+// InternalTestCommand puts the test command to stdout. It is used by the tester in the pipeline.
+// The `test` process in Procfile is used first, and then the test script in `app.json`.
+// If no tests are defined, it puts nothing.
+func InternalTestCommand(ctx *cli.Context) error {
+	file, err := ioutil.ReadFile("Procfile")
+	if err != nil {
+		logrus.Debug("Failed to load Procfile")
+	}
+	appJSONFile, err := ioutil.ReadFile(heroku.AppJSONFileName)
+	if err != nil {
+		logrus.Debug("Failed to load app.json")
+	}
+
+	processInternalTestCommand(&internalTestCommandContext{
+		procfile: string(file),
+		appJSON:  string(appJSONFile),
+		app:      ctx.App,
+	})
+
+	return nil
+}
+
+func processInternalTestCommand(ctx *internalTestCommandContext) {
+	if process, ok := procfile.Parse(ctx.procfile)[testProcess]; ok {
+		fmt.Fprintln(ctx.app.Writer, strings.Join(append([]string{process.Command}, process.Arguments...), " "))
+		return
+	}
+
+	if ctx.appJSON == "" {
+		return
+	}
+	appJSON, err := heroku.ParseAppJSON([]byte(ctx.appJSON))
+	if err != nil {
+		logrus.Debug(err.Error())
+		return
+	}
+	if appJSON.Environments.Test.Scripts.Test != "" {
+		fmt.Fprintln(ctx.app.Writer, appJSON.Environments.Test.Scripts.Test)
+	}
+}
+
+// generatedDockerfile is the file name of the Dockerfile generated by the buildpack.
+const generatedDockerfile = "Dockerfile.herogate"
+
+type internalBuildContext struct {
+	dir        string
+	dockerfile string
+	herokuYML  string
+	app        *cli.App
+}
+
+// InternalBuild puts the path of the Dockerfile to build to stdout. It is used by the builder in the pipeline.
+// If the app has no Dockerfile, it uses the Dockerfile of the web process in `heroku.yml`,
+// or detects the buildpack and generates a Dockerfile.
+// Progress messages are put to stderr so that the builder can read the path only.
+func InternalBuild(ctx *cli.Context) error {
+	herokuYMLFile, err := ioutil.ReadFile(heroku.HerokuYMLFileName)
+	if err != nil {
+		logrus.Debug("Failed to load " + heroku.HerokuYMLFileName)
+	}
+
+	return processInternalBuild(&internalBuildContext{
+		dir:        ".",
+		dockerfile: os.Getenv("HEROGATE_DOCKERFILE"),
+		herokuYML:  string(herokuYMLFile),
+		app:        ctx.App,
+	})
+}
+
+func processInternalBuild(ctx *internalBuildContext) error {
+	// The Dockerfile set by `herogate build:set` must exist
+	if ctx.dockerfile != "" {
+		if _, err := os.Stat(filepath.Join(ctx.dir, ctx.dockerfile)); err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    %s is not found. Check the build settings with `herogate build`.", color.New(color.FgRed).Sprint("▸"), ctx.dockerfile), 1)
+		}
+		fmt.Fprintln(ctx.app.Writer, ctx.dockerfile)
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(ctx.dir, "Dockerfile")); err == nil {
+		fmt.Fprintln(ctx.app.Writer, "Dockerfile")
+		return nil
+	}
+	// Herogate builds an image for all processes, so the Dockerfile of the web process is used.
+	if herokuYML, err := heroku.ParseHerokuYML(ctx.herokuYML); err == nil && herokuYML.Dockerfiles["web"] != "" {
+		fmt.Fprintf(errWriter(ctx.app), "-----> Using %s of the web process in %s\n", herokuYML.Dockerfiles["web"], heroku.HerokuYMLFileName)
+		fmt.Fprintln(ctx.app.Writer, herokuYML.Dockerfiles["web"])
+		return nil
+	}
+
+	pack, err := buildpack.Detect(ctx.dir)
+	if err == buildpack.ErrNotDetected {
+		return cli.NewExitError(buildpackNotDetectedMessage(), 1)
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to detect the buildpack: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	fmt.Fprintf(errWriter(ctx.app), "-----> %s app detected, generating %s\n", pack.Name, generatedDockerfile)
+	dockerfile := pack.Dockerfile(ctx.dir)
+	fmt.Fprint(errWriter(ctx.app), dockerfile)
+	if err = ioutil.WriteFile(filepath.Join(ctx.dir, generatedDockerfile), []byte(dockerfile), 0644); err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to write %s: %s", color.New(color.FgRed).Sprint("▸"), generatedDockerfile, err.Error()), 1)
+	}
+	fmt.Fprintln(ctx.app.Writer, generatedDockerfile)
+
+	return nil
+}
+
+func buildpackNotDetectedMessage() string {
+	return fmt.Sprintf(
+		"%s    No Dockerfile is found, and the app is not a supported stack (%s).\n%s    Add a Dockerfile to the root of the repository, or set the path with `herogate build:set dockerfile=PATH`.",
+		color.New(color.FgRed).Sprint("▸"),
+		strings.Join(buildpackNames(), ", "),
+		color.New(color.FgRed).Sprint("▸"),
+	)
 }
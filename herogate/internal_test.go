@@ -2,9 +2,17 @@ package herogate
 
 import (
 	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/golang/mock/gomock"
+	"github.com/olebedev/config"
 	"github.com/urfave/cli"
 	"github.com/wata727/herogate/mock"
 )
@@ -12,6 +20,8 @@ import (
 func TestProcessInternalGenerateTemplate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
+	internalNow = func() time.Time { return time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { internalNow = time.Now }()
 
 	app := cli.NewApp()
 	writer := new(bytes.Buffer)
@@ -39,6 +49,12 @@ Resources:
 	})
 
 	expected := `Description: Herogate Platform Template v1.0
+Metadata:
+  HerogateReleases:
+  - CreatedAt: 2018-05-01T12:00:00Z
+    Description: Deploy myapp:0.1
+    Image: myapp:0.1
+    Version: 1
 Resources:
   HerogateApplicationContainer:
     Properties:
@@ -49,7 +65,9 @@ Resources:
         - bundle
         - exec
         - puma
-        Environment: []
+        Environment:
+        - Name: PORT
+          Value: "80"
         PortMappings:
         - ContainerPort: 80
         LogConfiguration:
@@ -66,7 +84,9 @@ Resources:
         - bundle
         - exec
         - sidekiq
-        Environment: []
+        Environment:
+        - Name: PORT
+          Value: "80"
         PortMappings: []
         LogConfiguration:
           LogDriver: awslogs
@@ -88,6 +108,8 @@ Resources:
 func TestProcessInternalGenerateTemplate__withEnvironment(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
+	internalNow = func() time.Time { return time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { internalNow = time.Now }()
 
 	app := cli.NewApp()
 	writer := new(bytes.Buffer)
@@ -120,6 +142,12 @@ Resources:
 	})
 
 	expected := `Description: Herogate Platform Template v1.0
+Metadata:
+  HerogateReleases:
+  - CreatedAt: 2018-05-01T12:00:00Z
+    Description: Deploy myapp:0.1
+    Image: myapp:0.1
+    Version: 1
 Resources:
   HerogateApplicationContainer:
     Properties:
@@ -135,6 +163,8 @@ Resources:
           Value: production
         - Name: RACK_ENV
           Value: production
+        - Name: PORT
+          Value: "80"
         PortMappings:
         - ContainerPort: 80
         LogConfiguration:
@@ -156,6 +186,8 @@ Resources:
           Value: production
         - Name: RACK_ENV
           Value: production
+        - Name: PORT
+          Value: "80"
         PortMappings: []
         LogConfiguration:
           LogDriver: awslogs
@@ -174,6 +206,336 @@ Resources:
 	}
 }
 
+func TestProcessInternalGenerateTemplate__port(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	internalNow = func() time.Time { return time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { internalNow = time.Now }()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get template
+	client.EXPECT().GetTemplate("bold-art-6993").Return(`
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Image: httpd:2.4
+        Name: web
+        Environment:
+        - Name: PORT
+          Value: "5000"
+  HerogateApplicationService:
+    Properties:
+      LoadBalancers:
+      - ContainerName: web
+        ContainerPort: 5000
+`)
+
+	processInternalGenerateTemplate(&internalGenerateTemplateContext{
+		name:     "bold-art-6993",
+		image:    "myapp:0.1",
+		procfile: "web: npm start\n",
+		app:      app,
+		client:   client,
+	})
+
+	expected := `Metadata:
+  HerogateReleases:
+  - CreatedAt: 2018-05-01T12:00:00Z
+    Description: Deploy myapp:0.1
+    Image: myapp:0.1
+    Version: 1
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Name: web
+        Image: myapp:0.1
+        Command:
+        - npm
+        - start
+        Environment:
+        - Name: PORT
+          Value: "5000"
+        PortMappings:
+        - ContainerPort: 5000
+        LogConfiguration:
+          LogDriver: awslogs
+          Options:
+            awslogs-region:
+              Ref: AWS::Region
+            awslogs-group:
+              Ref: HerogateApplicationContainerLogs
+            awslogs-stream-prefix: web
+  HerogateApplicationService:
+    Properties:
+      LoadBalancers:
+      - ContainerName: web
+        ContainerPort: 5000
+
+`
+
+	if writer.String() != expected {
+		t.Fatalf("Expected template is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessInternalGenerateTemplate__manifest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	internalNow = func() time.Time { return time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { internalNow = time.Now }()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get template
+	client.EXPECT().GetTemplate("bold-art-6993").Return(`
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Image: httpd:2.4
+        Name: web
+`)
+
+	err := processInternalGenerateTemplate(&internalGenerateTemplateContext{
+		name:     "bold-art-6993",
+		image:    "myapp:0.1",
+		procfile: "web: npm start\n",
+		manifest: `processes:
+  web:
+    memory: 1024
+    depends_on:
+      - proxy
+  proxy:
+    image: envoyproxy/envoy:v1.7.0
+    port: 9901
+    essential: false
+`,
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := `Metadata:
+  HerogateReleases:
+  - CreatedAt: 2018-05-01T12:00:00Z
+    Description: Deploy myapp:0.1
+    Image: myapp:0.1
+    Version: 1
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Name: proxy
+        Image: envoyproxy/envoy:v1.7.0
+        Command: []
+        Environment:
+        - Name: PORT
+          Value: "9901"
+        PortMappings:
+        - ContainerPort: 9901
+        LogConfiguration:
+          LogDriver: awslogs
+          Options:
+            awslogs-region:
+              Ref: AWS::Region
+            awslogs-group:
+              Ref: HerogateApplicationContainerLogs
+            awslogs-stream-prefix: proxy
+        Essential: false
+        DockerLabels:
+          herogate.sidecar: "true"
+      - Name: web
+        Image: myapp:0.1
+        Command:
+        - npm
+        - start
+        Environment:
+        - Name: PORT
+          Value: "80"
+        PortMappings:
+        - ContainerPort: 80
+        LogConfiguration:
+          LogDriver: awslogs
+          Options:
+            awslogs-region:
+              Ref: AWS::Region
+            awslogs-group:
+              Ref: HerogateApplicationContainerLogs
+            awslogs-stream-prefix: web
+        Memory: 1024
+        DependsOn:
+        - ContainerName: proxy
+          Condition: START
+
+`
+
+	if writer.String() != expected {
+		t.Fatalf("Expected template is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessInternalGenerateTemplate__manifestPortOnOldPlatform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get the template of the platform version 1.4, whose target group has the fixed name
+	client.EXPECT().GetTemplate("bold-art-6993").Return(`
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Image: httpd:2.4
+        Name: web
+        PortMappings:
+        - ContainerPort: 80
+  HerogateApplicationService:
+    Properties:
+      LoadBalancers:
+      - ContainerName: web
+        ContainerPort: 80
+  HerogateLoadBalancerTargetGroup:
+    Properties:
+      Name:
+        Ref: AWS::StackName
+      Port: 80
+`)
+
+	err := processInternalGenerateTemplate(&internalGenerateTemplateContext{
+		name:     "bold-art-6993",
+		image:    "myapp:0.1",
+		procfile: "web: npm start\n",
+		manifest: "processes:\n  web:\n    port: 5000\n",
+		app:      app,
+		client:   client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	cfg, err := config.ParseYaml(writer.String())
+	if err != nil {
+		t.Fatalf("Failed to parse the generated template: %s", err)
+	}
+	// The target group is kept so that CloudFormation doesn't replace it
+	expected := map[string]string{
+		"Resources.HerogateLoadBalancerTargetGroup.Properties.Name.Ref":                                         "AWS::StackName",
+		"Resources.HerogateLoadBalancerTargetGroup.Properties.Port":                                             "80",
+		"Resources.HerogateApplicationService.Properties.LoadBalancers.0.ContainerPort":                         "5000",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.PortMappings.0.ContainerPort": "5000",
+		"Resources.HerogateApplicationContainer.Properties.ContainerDefinitions.0.Environment.0.Value":          "5000",
+	}
+	for path, value := range expected {
+		actual, err := cfg.String(path)
+		if err != nil {
+			t.Fatalf("Failed to get `%s`: %s", path, err)
+		}
+		if actual != value {
+			t.Fatalf("Expected `%s` is `%s`, but get `%s`", path, value, actual)
+		}
+	}
+}
+
+func TestProcessInternalGenerateTemplate__invalidManifest(t *testing.T) {
+	err := processInternalGenerateTemplate(&internalGenerateTemplateContext{
+		name:     "bold-art-6993",
+		image:    "myapp:0.1",
+		procfile: "web: npm start\n",
+		manifest: "processes:\n  web:\n    port: http\n",
+		app:      cli.NewApp(),
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+	expected := fmt.Sprintf("%s    herogate.yml is invalid:\n  processes.web.port: must be an integer, but got \"http\"", color.New(color.FgRed).Sprint("▸"))
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
+func TestProcessInternalGenerateTemplate__herokuYML(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	internalNow = func() time.Time { return time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { internalNow = time.Now }()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get template
+	client.EXPECT().GetTemplate("bold-art-6993").Return(`
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Image: httpd:2.4
+        Name: web
+`)
+
+	err := processInternalGenerateTemplate(&internalGenerateTemplateContext{
+		name:      "bold-art-6993",
+		image:     "myapp:0.1",
+		herokuYML: "run:\n  web: npm start\n",
+		app:       app,
+		client:    client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := `Metadata:
+  HerogateReleases:
+  - CreatedAt: 2018-05-01T12:00:00Z
+    Description: Deploy myapp:0.1
+    Image: myapp:0.1
+    Version: 1
+Resources:
+  HerogateApplicationContainer:
+    Properties:
+      ContainerDefinitions:
+      - Name: web
+        Image: myapp:0.1
+        Command:
+        - npm
+        - start
+        Environment:
+        - Name: PORT
+          Value: "80"
+        PortMappings:
+        - ContainerPort: 80
+        LogConfiguration:
+          LogDriver: awslogs
+          Options:
+            awslogs-region:
+              Ref: AWS::Region
+            awslogs-group:
+              Ref: HerogateApplicationContainerLogs
+            awslogs-stream-prefix: web
+
+`
+
+	if writer.String() != expected {
+		t.Fatalf("Expected template is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
 func TestProcessInternalGenerateTemplate__noProcfile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -219,3 +581,173 @@ Resources:
 		t.Fatalf("Expected template is `%s`, but get `%s`", template, writer.String())
 	}
 }
+
+func TestProcessInternalTestCommand(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Procfile string
+		AppJSON  string
+		Expected string
+	}{
+		{
+			Name:     "Procfile",
+			Procfile: "web: bundle exec puma\ntest: bundle exec rspec\n",
+			AppJSON:  `{"environments":{"test":{"scripts":{"test":"bundle exec rake test"}}}}`,
+			Expected: "bundle exec rspec\n",
+		},
+		{
+			Name:     "app.json",
+			Procfile: "web: bundle exec puma\n",
+			AppJSON:  `{"environments":{"test":{"scripts":{"test":"bundle exec rake test"}}}}`,
+			Expected: "bundle exec rake test\n",
+		},
+		{
+			Name:     "no tests",
+			Procfile: "web: bundle exec puma\n",
+			AppJSON:  `{"name":"my-app"}`,
+			Expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		app := cli.NewApp()
+		writer := new(bytes.Buffer)
+		app.Writer = writer
+
+		processInternalTestCommand(&internalTestCommandContext{
+			procfile: tc.Procfile,
+			appJSON:  tc.AppJSON,
+			app:      app,
+		})
+
+		if writer.String() != tc.Expected {
+			t.Fatalf("Expected to output is `%s`, but get `%s` in %s", tc.Expected, writer.String(), tc.Name)
+		}
+	}
+}
+
+func TestProcessInternalBuild(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = ioutil.WriteFile(filepath.Join(dir, "package.json"), []byte("{}\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+	app.ErrWriter = new(bytes.Buffer)
+
+	err = processInternalBuild(&internalBuildContext{
+		dir: dir,
+		app: app,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	if writer.String() != "Dockerfile.herogate\n" {
+		t.Fatalf("Expected to output is `Dockerfile.herogate`, but get `%s`", writer.String())
+	}
+	if _, err = os.Stat(filepath.Join(dir, "Dockerfile.herogate")); err != nil {
+		t.Fatalf("Expected to generate Dockerfile.herogate, but get `%s`", err.Error())
+	}
+}
+
+func TestProcessInternalBuild__defaultErrWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = ioutil.WriteFile(filepath.Join(dir, "package.json"), []byte("{}\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	// The app doesn't set ErrWriter like NewApp, so progress messages go to the package level writer
+	stderr := new(bytes.Buffer)
+	defaultErrWriter := cli.ErrWriter
+	cli.ErrWriter = stderr
+	defer func() { cli.ErrWriter = defaultErrWriter }()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	err = processInternalBuild(&internalBuildContext{
+		dir: dir,
+		app: app,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	if writer.String() != "Dockerfile.herogate\n" {
+		t.Fatalf("Expected to output is `Dockerfile.herogate`, but get `%s`", writer.String())
+	}
+	if !strings.HasPrefix(stderr.String(), "-----> Node.js app detected, generating Dockerfile.herogate\n") {
+		t.Fatalf("Expected to output progress to stderr, but get `%s`", stderr.String())
+	}
+}
+
+func TestProcessInternalBuild__dockerfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = os.Mkdir(filepath.Join(dir, "api"), 0755); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, "api", "Dockerfile"), []byte("FROM alpine\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	err = processInternalBuild(&internalBuildContext{
+		dir:        dir,
+		dockerfile: "api/Dockerfile",
+		app:        app,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	if writer.String() != "api/Dockerfile\n" {
+		t.Fatalf("Expected to output is `api/Dockerfile`, but get `%s`", writer.String())
+	}
+}
+
+func TestProcessInternalBuild__herokuYML(t *testing.T) {
+	// The app doesn't set ErrWriter like NewApp, so progress messages go to the package level writer
+	stderr := new(bytes.Buffer)
+	defaultErrWriter := cli.ErrWriter
+	cli.ErrWriter = stderr
+	defer func() { cli.ErrWriter = defaultErrWriter }()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	err := processInternalBuild(&internalBuildContext{
+		dir:       ".",
+		herokuYML: "build:\n  docker:\n    web: Dockerfile.web\n",
+		app:       app,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	if writer.String() != "Dockerfile.web\n" {
+		t.Fatalf("Expected to output is `Dockerfile.web`, but get `%s`", writer.String())
+	}
+	if stderr.String() != "-----> Using Dockerfile.web of the web process in heroku.yml\n" {
+		t.Fatalf("Expected to output progress to stderr, but get `%s`", stderr.String())
+	}
+}
@@ -0,0 +1,321 @@
+package herogate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/hecticjeff/procfile"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/compose"
+	"github.com/wata727/herogate/log"
+)
+
+// localImage is the tag of the image built by `herogate local --docker`.
+const localImage = "herogate-local"
+
+// localColors are colors of process prefixes. Green is used by Herogate itself.
+var localColors = []color.Attribute{color.FgCyan, color.FgYellow, color.FgMagenta, color.FgBlue, color.FgHiCyan, color.FgHiYellow}
+
+// localStopTimeout is the duration to wait for processes to stop before killing them.
+var localStopTimeout = 10 * time.Second
+
+// localNow returns the timestamp of logs. It is a variable so that tests can replace it.
+var localNow = time.Now
+
+type localContext struct {
+	name         string
+	dir          string
+	procfile     string
+	processes    []string
+	envFile      string
+	remoteConfig bool
+	docker       bool
+	image        string
+	port         int
+	signals      chan os.Signal
+	app          *cli.App
+	client       iface.ClientInterface
+}
+
+// Local runs processes in Procfile on the local machine like `heroku local`.
+// Config vars are read from `.env`, or from the app with `--remote-config`.
+// With `--docker`, processes run in the image built from the Dockerfile.
+// All processes are stopped when one of them exits, or when it receives SIGINT or SIGTERM.
+func Local(ctx *cli.Context) error {
+	name := ""
+	if ctx.Bool("remote-config") {
+		_, name = detectAppFromRepo()
+		if ctx.String("app") != "" {
+			logrus.Debug("Override application name: " + ctx.String("app"))
+			name = ctx.String("app")
+		}
+		if name == "" {
+			return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+		}
+	}
+
+	file, err := ioutil.ReadFile(ctx.String("procfile"))
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    %s is not found.", color.New(color.FgRed).Sprint("▸"), ctx.String("procfile")), 1)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	return processLocal(&localContext{
+		name:         name,
+		dir:          ".",
+		procfile:     string(file),
+		processes:    ctx.Args(),
+		envFile:      ctx.String("env"),
+		remoteConfig: ctx.Bool("remote-config"),
+		docker:       ctx.Bool("docker"),
+		image:        ctx.String("image"),
+		port:         ctx.Int("port"),
+		signals:      signals,
+		app:          ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+type localProcess struct {
+	name string
+	cmd  *exec.Cmd
+	out  *io.PipeWriter
+	done chan struct{}
+}
+
+type localExit struct {
+	name string
+	err  error
+}
+
+func processLocal(ctx *localContext) error {
+	names, err := localProcessNames(ctx)
+	if err != nil {
+		return err
+	}
+	env, err := localEnvVars(ctx)
+	if err != nil {
+		return err
+	}
+
+	image := ctx.image
+	if ctx.docker && image == "" {
+		image = localImage
+		fmt.Fprintf(ctx.app.Writer, "-----> Building %s\n", color.New(color.FgCyan).Sprint(image))
+		if err = runDocker(ctx.app.Writer, "", "build", "--tag", image, ctx.dir); err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Failed to build the image: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+	}
+
+	var mutex sync.Mutex
+	puts := func(l *log.Log, c *color.Color) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		fmt.Fprintln(ctx.app.Writer, l.FormatWithColor(c))
+	}
+	putsHerogate := func(message string) {
+		puts(&log.Log{Timestamp: localNow(), Source: log.HerogateSource, Process: "local", Message: message}, color.New(color.FgGreen))
+	}
+
+	procs := procfile.Parse(ctx.procfile)
+	exits := make(chan localExit, len(names))
+	running := []*localProcess{}
+	for i, name := range names {
+		command := append([]string{procs[name].Command}, procs[name].Arguments...)
+		process := &localProcess{name: name, cmd: localCommand(ctx, name, command, env, image), done: make(chan struct{})}
+		r, w := io.Pipe()
+		process.out = w
+		process.cmd.Stdout = w
+		process.cmd.Stderr = w
+
+		processColor := color.New(localColors[i%len(localColors)])
+		go func(process *localProcess) {
+			defer close(process.done)
+			readLocalOutput(r, func(line string) {
+				puts(&log.Log{Timestamp: localNow(), Source: "app", Process: process.name, Message: line}, processColor)
+			})
+		}(process)
+
+		putsHerogate(fmt.Sprintf("Starting %s with `%s`", name, strings.Join(command, " ")))
+		if err = process.cmd.Start(); err != nil {
+			w.Close()
+			<-process.done
+			putsHerogate(fmt.Sprintf("Failed to start %s: %s", name, err.Error()))
+			stopLocalProcesses(running, exits)
+			return cli.NewExitError("", 1)
+		}
+		running = append(running, process)
+		// The exit is notified after all outputs of the process are put
+		go func(process *localProcess) {
+			err := process.cmd.Wait()
+			process.out.Close()
+			<-process.done
+			exits <- localExit{name: process.name, err: err}
+		}(process)
+	}
+
+	var result error
+	select {
+	case exit := <-exits:
+		running = removeLocalProcess(running, exit.name)
+		if exit.err != nil {
+			putsHerogate(fmt.Sprintf("%s exited with %s, stopping all processes", exit.name, exit.err.Error()))
+			result = cli.NewExitError("", 1)
+		} else {
+			putsHerogate(fmt.Sprintf("%s exited, stopping all processes", exit.name))
+		}
+	case sig := <-ctx.signals:
+		putsHerogate(fmt.Sprintf("%s received, stopping all processes", sig.String()))
+	}
+
+	stopLocalProcesses(running, exits)
+
+	return result
+}
+
+// readLocalOutput calls the function with each line of the output of a process.
+// Unlike bufio.Scanner, the length of lines is not limited, so long lines such as JSON logs are not dropped.
+// If reading fails, the rest of the output is discarded so that the process is not blocked on writing to the pipe.
+func readLocalOutput(r io.Reader, fn func(line string)) {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			fn(strings.TrimRight(line, "\r\n"))
+		}
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			logrus.Debug("Failed to read the output: " + err.Error())
+			io.Copy(ioutil.Discard, r)
+			return
+		}
+	}
+}
+
+// localProcessNames returns processes to run. The test process runs in the pipeline, so it is excluded unless specified.
+func localProcessNames(ctx *localContext) ([]string, error) {
+	procs := procfile.Parse(ctx.procfile)
+	if len(ctx.processes) > 0 {
+		for _, name := range ctx.processes {
+			if _, ok := procs[name]; !ok {
+				return nil, cli.NewExitError(fmt.Sprintf("%s    %s is not found in Procfile.", color.New(color.FgRed).Sprint("▸"), name), 1)
+			}
+		}
+		return ctx.processes, nil
+	}
+
+	names := []string{}
+	for name := range procs {
+		if name != testProcess {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, cli.NewExitError(fmt.Sprintf("%s    No processes are found in Procfile.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// localEnvVars returns config vars of processes. PORT is always set like the app on Herogate.
+func localEnvVars(ctx *localContext) (map[string]string, error) {
+	env := map[string]string{}
+	if ctx.remoteConfig {
+		envVars, err := ctx.client.DescribeEnvVars(ctx.name)
+		if err != nil {
+			return nil, cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+		}
+		for key, value := range envVars {
+			env[key] = value
+		}
+	} else if file, err := ioutil.ReadFile(filepath.Join(ctx.dir, ctx.envFile)); err == nil {
+		env = compose.ParseEnvFile(string(file))
+	} else {
+		logrus.Debug("Failed to load " + ctx.envFile)
+	}
+	env[portEnvName] = strconv.Itoa(ctx.port)
+	return env, nil
+}
+
+// localCommand returns the command of the process. Without Docker, the command runs in the shell so that `$PORT` is expanded.
+// With Docker, config vars are passed by name so that values don't appear in the arguments.
+func localCommand(ctx *localContext, name string, command []string, env map[string]string, image string) *exec.Cmd {
+	environ := os.Environ()
+	for _, key := range sortedEnvVarNames(env) {
+		environ = append(environ, key+"="+env[key])
+	}
+
+	var cmd *exec.Cmd
+	if image == "" {
+		cmd = exec.Command("sh", "-c", strings.Join(command, " "))
+	} else {
+		args := []string{"run", "--rm", "--init"}
+		for _, key := range sortedEnvVarNames(env) {
+			args = append(args, "--env", key)
+		}
+		if name == "web" {
+			args = append(args, "--publish", fmt.Sprintf("%d:%d", ctx.port, ctx.port))
+		}
+		args = append(args, image)
+		cmd = exec.Command("docker", append(args, command...)...)
+	}
+	cmd.Dir = ctx.dir
+	cmd.Env = environ
+	setLocalProcessGroup(cmd)
+	return cmd
+}
+
+// stopLocalProcesses sends SIGTERM to running processes, and kills them if they don't stop in time.
+func stopLocalProcesses(processes []*localProcess, exits chan localExit) {
+	for _, process := range processes {
+		if err := signalLocalProcess(process.cmd, syscall.SIGTERM); err != nil {
+			process.cmd.Process.Kill()
+		}
+	}
+
+	timeout := time.After(localStopTimeout)
+	for len(processes) > 0 {
+		select {
+		case exit := <-exits:
+			processes = removeLocalProcess(processes, exit.name)
+		case <-timeout:
+			for _, process := range processes {
+				signalLocalProcess(process.cmd, syscall.SIGKILL)
+			}
+			timeout = nil
+		}
+	}
+}
+
+func removeLocalProcess(processes []*localProcess, name string) []*localProcess {
+	result := []*localProcess{}
+	for _, process := range processes {
+		if process.name != name {
+			result = append(result, process)
+		}
+	}
+	return result
+}
@@ -0,0 +1,236 @@
+package herogate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/log"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessLocal(t *testing.T) {
+	timestamp := time.Date(2018, time.February, 2, 11, 0, 9, 0, time.FixedZone("UTC", 0))
+	localNow = func() time.Time { return timestamp }
+	defer func() { localNow = time.Now }()
+
+	dir, err := ioutil.TempDir("", "herogate")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = ioutil.WriteFile(filepath.Join(dir, ".env"), []byte("GREETING=hello\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	err = processLocal(&localContext{
+		dir:      dir,
+		procfile: "web: echo $GREETING $PORT\ntest: exit 1\n",
+		envFile:  ".env",
+		port:     5000,
+		signals:  make(chan os.Signal),
+		app:      app,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	herogateColor := color.New(color.FgGreen)
+	expected := strings.Join([]string{
+		(&log.Log{Timestamp: timestamp, Source: "herogate", Process: "local", Message: "Starting web with `echo $GREETING $PORT`"}).FormatWithColor(herogateColor),
+		(&log.Log{Timestamp: timestamp, Source: "app", Process: "web", Message: "hello 5000"}).FormatWithColor(color.New(color.FgCyan)),
+		(&log.Log{Timestamp: timestamp, Source: "herogate", Process: "local", Message: "web exited, stopping all processes"}).FormatWithColor(herogateColor),
+	}, "\n") + "\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected outputs are `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessLocal__failure(t *testing.T) {
+	timestamp := time.Date(2018, time.February, 2, 11, 0, 9, 0, time.FixedZone("UTC", 0))
+	localNow = func() time.Time { return timestamp }
+	defer func() { localNow = time.Now }()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	err := processLocal(&localContext{
+		dir:      ".",
+		procfile: "web: sleep 10\nworker: exit 3\n",
+		envFile:  ".env",
+		port:     5000,
+		signals:  make(chan os.Signal),
+		app:      app,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := (&log.Log{Timestamp: timestamp, Source: "herogate", Process: "local", Message: "worker exited with exit status 3, stopping all processes"}).FormatWithColor(color.New(color.FgGreen))
+	if !strings.HasSuffix(writer.String(), expected+"\n") {
+		t.Fatalf("Expected to stop with `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessLocal__signal(t *testing.T) {
+	timestamp := time.Date(2018, time.February, 2, 11, 0, 9, 0, time.FixedZone("UTC", 0))
+	localNow = func() time.Time { return timestamp }
+	defer func() { localNow = time.Now }()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	signals := make(chan os.Signal, 1)
+	signals <- os.Interrupt
+	start := time.Now()
+	err := processLocal(&localContext{
+		dir:      ".",
+		procfile: "web: sleep 10\n",
+		envFile:  ".env",
+		port:     5000,
+		signals:  signals,
+		app:      app,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+	if time.Since(start) >= 10*time.Second {
+		t.Fatal("Expected processes are stopped, but they are not")
+	}
+
+	expected := (&log.Log{Timestamp: timestamp, Source: "herogate", Process: "local", Message: "interrupt received, stopping all processes"}).FormatWithColor(color.New(color.FgGreen))
+	if !strings.HasSuffix(writer.String(), expected+"\n") {
+		t.Fatalf("Expected to stop with `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessLocal__remoteConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get config vars of the app
+	client.EXPECT().DescribeEnvVars("young-eyrie-24091").Return(nil, errors.New("Stack not found"))
+
+	err := processLocal(&localContext{
+		name:         "young-eyrie-24091",
+		dir:          ".",
+		procfile:     "web: sleep 10\n",
+		remoteConfig: true,
+		port:         5000,
+		app:          cli.NewApp(),
+		client:       client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸"))
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
+func TestProcessLocal__processNotFound(t *testing.T) {
+	err := processLocal(&localContext{
+		dir:       ".",
+		procfile:  "web: sleep 10\n",
+		processes: []string{"worker"},
+		app:       cli.NewApp(),
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf("%s    worker is not found in Procfile.", color.New(color.FgRed).Sprint("▸"))
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
+func TestReadLocalOutput(t *testing.T) {
+	long := strings.Repeat("a", 100*1024)
+
+	cases := []struct {
+		Name     string
+		Output   string
+		Expected []string
+	}{
+		{
+			Name:     "lines",
+			Output:   "hello\r\nworld\n",
+			Expected: []string{"hello", "world"},
+		},
+		{
+			Name:     "long line",
+			Output:   long + "\nnext\n",
+			Expected: []string{long, "next"},
+		},
+		{
+			Name:     "without trailing newline",
+			Output:   "hello\nworld",
+			Expected: []string{"hello", "world"},
+		},
+	}
+
+	for _, tc := range cases {
+		lines := []string{}
+		readLocalOutput(strings.NewReader(tc.Output), func(line string) {
+			lines = append(lines, line)
+		})
+		if !cmp.Equal(lines, tc.Expected) {
+			t.Fatalf("Unexpected lines: Diff=%s in %s", cmp.Diff(lines, tc.Expected), tc.Name)
+		}
+	}
+}
+
+// failingReader fails at the first read, and then reads from the underlying reader.
+type failingReader struct {
+	r      io.Reader
+	failed bool
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if !f.failed {
+		f.failed = true
+		return 0, errors.New("read error")
+	}
+	return f.r.Read(p)
+}
+
+func TestReadLocalOutput__drain(t *testing.T) {
+	r, w := io.Pipe()
+	go readLocalOutput(&failingReader{r: r}, func(line string) {})
+
+	// The process must not be blocked on writing even after reading fails
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Write([]byte("hello\n"))
+		w.Write([]byte("world\n"))
+		w.Close()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the output is drained, but writing is blocked")
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+// +build !windows
+
+package herogate
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setLocalProcessGroup runs the process in a new process group, so that children spawned by the shell are stopped together.
+func setLocalProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// signalLocalProcess sends the signal to the process group of the process.
+func signalLocalProcess(cmd *exec.Cmd, sig syscall.Signal) error {
+	return syscall.Kill(-cmd.Process.Pid, sig)
+}
@@ -0,0 +1,14 @@
+package herogate
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setLocalProcessGroup does nothing on Windows.
+func setLocalProcessGroup(cmd *exec.Cmd) {}
+
+// signalLocalProcess kills the process because Windows doesn't support signals.
+func signalLocalProcess(cmd *exec.Cmd, sig syscall.Signal) error {
+	return cmd.Process.Kill()
+}
@@ -37,7 +37,7 @@ func Logs(ctx *cli.Context) error {
 	return processLogs(&logsContext{
 		name: name,
 		app:  ctx.App,
-		client: api.NewClient(&api.ClientOption{
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 		num:    ctx.Int("num"),
@@ -0,0 +1,51 @@
+package herogate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/manifest"
+)
+
+type manifestValidateContext struct {
+	dir string
+	app *cli.App
+}
+
+// ManifestValidate validates `herogate.yml` in the directory against the schema and Procfile.
+// It runs locally, so you can run it in pre-commit hooks.
+func ManifestValidate(ctx *cli.Context) error {
+	dir := ctx.Args().First()
+	if dir == "" {
+		dir = "."
+	}
+
+	return processManifestValidate(&manifestValidateContext{
+		dir: dir,
+		app: ctx.App,
+	})
+}
+
+func processManifestValidate(ctx *manifestValidateContext) error {
+	file, err := ioutil.ReadFile(filepath.Join(ctx.dir, manifest.FileName))
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    %s is not found.", color.New(color.FgRed).Sprint("▸"), manifest.FileName), 1)
+	}
+	procfile, err := ioutil.ReadFile(filepath.Join(ctx.dir, "Procfile"))
+	if err != nil {
+		logrus.Debug("Failed to load Procfile")
+	}
+
+	settings, err := manifest.Parse(string(file), string(procfile))
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "%s is valid, %d processes are declared.\n", manifest.FileName, len(settings.Processes))
+
+	return nil
+}
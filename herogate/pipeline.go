@@ -0,0 +1,369 @@
+package herogate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+	"github.com/wata727/herogate/api/objects"
+)
+
+type pipelineBranchContext struct {
+	name    string
+	branch  string
+	wait    time.Duration
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
+}
+
+// PipelineBranch displays or changes the branch deployed by the application pipeline.
+func PipelineBranch(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processPipelineBranch(&pipelineBranchContext{
+		name:    name,
+		branch:  ctx.Args().First(),
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processPipelineBranch(ctx *pipelineBranchContext) error {
+	if ctx.branch == "" {
+		branch, err := ctx.client.GetBranch(ctx.name)
+		if err == api.ErrS3Source {
+			return s3SourceBranchError(ctx.name)
+		}
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+		}
+		fmt.Fprintln(ctx.app.Writer, branch)
+		return nil
+	}
+
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	branchStr := color.New(color.FgGreen).Sprint(ctx.branch)
+	progress := fmt.Sprintf("Setting deploy branch of %s to %s...\r", appStr, branchStr)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		return ctx.client.SetBranch(ctx.name, ctx.branch)
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err == api.ErrS3Source {
+		fmt.Fprintf(ctx.app.Writer, "Setting deploy branch of %s to %s... failed\n", appStr, branchStr)
+		return s3SourceBranchError(ctx.name)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+			"branch":  ctx.branch,
+		}).Fatal("Failed to set branch: " + err.Error())
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Setting deploy branch of %s to %s... done\n", appStr, branchStr)
+	fmt.Fprintf(ctx.app.Writer, "Push to %s to deploy %s\n", branchStr, appStr)
+
+	return nil
+}
+
+func s3SourceBranchError(name string) error {
+	return cli.NewExitError(
+		fmt.Sprintf(
+			"%s    %s deploys the source uploaded by %s, so it has no deploy branch.",
+			color.New(color.FgRed).Sprint("▸"),
+			color.New(color.FgMagenta).Sprintf("⬢ %s", name),
+			color.New(color.FgCyan).Sprint("herogate deploy"),
+		),
+		1,
+	)
+}
+
+type pipelinesAddContext struct {
+	name     string
+	pipeline string
+	stage    string
+	wait     time.Duration
+	verbose  bool
+	app      *cli.App
+	client   iface.ClientInterface
+}
+
+// PipelinesAdd adds the application to the pipeline as the stage.
+// Applications in the same pipeline can promote the image each other.
+func PipelinesAdd(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	pipeline := ctx.Args().First()
+	if pipeline == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require argument, You must specify a pipeline name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if ctx.String("stage") == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `--stage`, You must specify a stage name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processPipelinesAdd(&pipelinesAddContext{
+		name:     name,
+		pipeline: pipeline,
+		stage:    ctx.String("stage"),
+		wait:     waitTimeout(ctx),
+		verbose:  ctx.Bool("verbose"),
+		app:      ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processPipelinesAdd(ctx *pipelinesAddContext) error {
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	pipelineStr := color.New(color.FgCyan).Sprint(ctx.pipeline)
+	progress := fmt.Sprintf("Adding %s to %s pipeline as %s...\r", appStr, pipelineStr, ctx.stage)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		return ctx.client.SetPipeline(ctx.name, ctx.pipeline, ctx.stage)
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName":  ctx.name,
+			"pipeline": ctx.pipeline,
+			"stage":    ctx.stage,
+		}).Fatal("Failed to set pipeline: " + err.Error())
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Adding %s to %s pipeline as %s... done\n", appStr, pipelineStr, ctx.stage)
+
+	return nil
+}
+
+type pipelinesPromoteContext struct {
+	source  string
+	target  string
+	wait    time.Duration
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
+}
+
+// PipelinesPromote deploys the image running in the source application to the target application.
+// Unlike pushing to the repository, it doesn't rebuild the image.
+func PipelinesPromote(ctx *cli.Context) error {
+	source := ctx.Args().Get(0)
+	target := ctx.Args().Get(1)
+	if source == "" || target == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require arguments, You must specify source and target application names", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processPipelinesPromote(&pipelinesPromoteContext{
+		source:  source,
+		target:  target,
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processPipelinesPromote(ctx *pipelinesPromoteContext) error {
+	source, err := ctx.client.GetApp(ctx.source)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find %s.", color.New(color.FgRed).Sprint("▸"), color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.source)), 1)
+	}
+	target, err := ctx.client.GetApp(ctx.target)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find %s.", color.New(color.FgRed).Sprint("▸"), color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.target)), 1)
+	}
+
+	sourceStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.source)
+	targetStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.target)
+	if source.Pipeline == "" || source.Pipeline != target.Pipeline {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    %s and %s are not in the same pipeline.\n%s    Add them to a pipeline with %s first.",
+				color.New(color.FgRed).Sprint("▸"),
+				sourceStr,
+				targetStr,
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgCyan).Sprint("herogate pipelines:add"),
+			),
+			1,
+		)
+	}
+
+	if err = waitForIdleApp(ctx.client, target, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.target)
+	}
+
+	progress := fmt.Sprintf("Promoting %s to %s (%s)...\r", sourceStr, targetStr, target.Stage)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	var release *objects.Release
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		var promoteErr error
+		release, promoteErr = ctx.client.PromoteApp(ctx.source, ctx.target)
+		return promoteErr
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Failed to promote the app: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Promoting %s to %s (%s)... done, v%d\n", sourceStr, targetStr, target.Stage, release.Version)
+
+	return nil
+}
+
+type pipelineApprovalContext struct {
+	name    string
+	state   string
+	wait    time.Duration
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
+}
+
+// PipelineApproval displays or changes whether or not the pipeline requires manual approval before deploying.
+func PipelineApproval(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processPipelineApproval(&pipelineApprovalContext{
+		name:    name,
+		state:   ctx.Args().First(),
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processPipelineApproval(ctx *pipelineApprovalContext) error {
+	if ctx.state == "" {
+		enabled, err := ctx.client.GetApproval(ctx.name)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+		}
+		if enabled {
+			fmt.Fprintln(ctx.app.Writer, "on")
+		} else {
+			fmt.Fprintln(ctx.app.Writer, "off")
+		}
+		return nil
+	}
+	if ctx.state != "on" && ctx.state != "off" {
+		return cli.NewExitError(fmt.Sprintf("%s    Invalid argument `%s`, You must specify `on` or `off`", color.New(color.FgRed).Sprint("▸"), ctx.state), 1)
+	}
+	enabled := ctx.state == "on"
+
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	action := "Disabling"
+	if enabled {
+		action = "Enabling"
+	}
+	progress := fmt.Sprintf("%s manual approval of %s...\r", action, appStr)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		return ctx.client.SetApproval(ctx.name, enabled)
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+			"enabled": enabled,
+		}).Fatal("Failed to set approval: " + err.Error())
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "%s manual approval of %s... done\n", action, appStr)
+	if enabled {
+		fmt.Fprintf(ctx.app.Writer, "Run %s to approve builds before deploying\n", color.New(color.FgCyan).Sprint("herogate approve"))
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessPipelineBranch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "CREATE_COMPLETE",
+	}, nil)
+	// Expect to set branch
+	client.EXPECT().SetBranch("young-eyrie-24091", "main").Return(nil)
+
+	err := processPipelineBranch(&pipelineBranchContext{
+		name:   "young-eyrie-24091",
+		branch: "main",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	branchStr := color.New(color.FgGreen).Sprint("main")
+	expected := fmt.Sprintf(
+		"Setting deploy branch of %s to %s...\rSetting deploy branch of %s to %s... done\nPush to %s to deploy %s\n",
+		appStr, branchStr, appStr, branchStr, branchStr, appStr,
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessPipelineBranch__display(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get branch
+	client.EXPECT().GetBranch("young-eyrie-24091").Return("release", nil)
+
+	err := processPipelineBranch(&pipelineBranchContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	if writer.String() != "release\n" {
+		t.Fatalf("Expected to output is `release`, but get `%s`", writer.String())
+	}
+}
+
+func TestProcessPipelineBranch__s3Source(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get branch
+	client.EXPECT().GetBranch("young-eyrie-24091").Return("", api.ErrS3Source)
+
+	err := processPipelineBranch(&pipelineBranchContext{
+		name:   "young-eyrie-24091",
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    %s deploys the source uploaded by %s, so it has no deploy branch.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		color.New(color.FgCyan).Sprint("herogate deploy"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
+func TestProcessPipelinesPromote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get applications
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:     "young-eyrie-24091",
+		Status:   "UPDATE_COMPLETE",
+		Pipeline: "young-eyrie",
+		Stage:    "staging",
+	}, nil)
+	client.EXPECT().GetApp("proud-lab-1661").Return(&objects.App{
+		Name:     "proud-lab-1661",
+		Status:   "UPDATE_COMPLETE",
+		Pipeline: "young-eyrie",
+		Stage:    "production",
+	}, nil)
+	// Expect to promote
+	client.EXPECT().PromoteApp("young-eyrie-24091", "proud-lab-1661").Return(&objects.Release{
+		Version:     3,
+		Description: "Promote from young-eyrie-24091",
+		Image:       "young-eyrie-24091:latest",
+	}, nil)
+
+	err := processPipelinesPromote(&pipelinesPromoteContext{
+		source: "young-eyrie-24091",
+		target: "proud-lab-1661",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	sourceStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	targetStr := color.New(color.FgMagenta).Sprint("⬢ proud-lab-1661")
+	expected := fmt.Sprintf(
+		"Promoting %s to %s (production)...\rPromoting %s to %s (production)... done, v3\n",
+		sourceStr, targetStr, sourceStr, targetStr,
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessPipelinesPromote__differentPipeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get applications
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:     "young-eyrie-24091",
+		Status:   "UPDATE_COMPLETE",
+		Pipeline: "young-eyrie",
+		Stage:    "staging",
+	}, nil)
+	client.EXPECT().GetApp("proud-lab-1661").Return(&objects.App{
+		Name:   "proud-lab-1661",
+		Status: "UPDATE_COMPLETE",
+	}, nil)
+
+	err := processPipelinesPromote(&pipelinesPromoteContext{
+		source: "young-eyrie-24091",
+		target: "proud-lab-1661",
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    %s and %s are not in the same pipeline.\n%s    Add them to a pipeline with %s first.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+		color.New(color.FgMagenta).Sprint("⬢ proud-lab-1661"),
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgCyan).Sprint("herogate pipelines:add"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
@@ -0,0 +1,158 @@
+package herogate
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+)
+
+type portContext struct {
+	name    string
+	port    string
+	dryRun  bool
+	wait    time.Duration
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
+}
+
+// Port displays the port which the web process of the application listens on.
+// The port is passed to processes as `PORT` environment variable.
+func Port(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processPort(&portContext{
+		name: name,
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processPort(ctx *portContext) error {
+	port, err := ctx.client.GetPort(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	fmt.Fprintln(ctx.app.Writer, port)
+
+	return nil
+}
+
+// PortSet changes the port which the web process of the application listens on.
+// The port mapping, the load balancer and PORT follow it, and containers are restarted.
+func PortSet(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if !ctx.Args().Present() {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require argument, You must specify a port", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processPortSet(&portContext{
+		name:    name,
+		port:    ctx.Args().First(),
+		dryRun:  ctx.Bool("dry-run"),
+		wait:    waitTimeout(ctx),
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processPortSet(ctx *portContext) error {
+	port, err := strconv.Atoi(ctx.port)
+	if err != nil || port < 1 || port > 65535 {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    %s is invalid. Must be a number between 1 and 65535.",
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgCyan).Sprint(ctx.port),
+			),
+			1,
+		)
+	}
+
+	app, err := ctx.client.GetApp(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	portStr := color.New(color.FgGreen).Sprint(port)
+	if ctx.dryRun {
+		fmt.Fprintf(ctx.app.Writer, "Previewing setting port of %s to %s...\r", appStr, portStr)
+		changes, err := ctx.client.PreviewSetPort(ctx.name, port)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("%s    Failed to preview changes: %s", color.New(color.FgRed).Sprint("▸"), err.Error()), 1)
+		}
+		fmt.Fprintf(ctx.app.Writer, "Previewing setting port of %s to %s... done\n", appStr, portStr)
+		putsChanges(changes, ctx.app.Writer)
+		return nil
+	}
+
+	if err = waitForIdleApp(ctx.client, app, ctx.wait, ctx.app.Writer); err != nil {
+		return err
+	}
+
+	var events *stackEventStreamer
+	if ctx.verbose {
+		events = newStackEventStreamer(ctx.client, ctx.name)
+	}
+
+	progress := fmt.Sprintf("Setting port of %s to %s and restarting...\r", appStr, portStr)
+	fmt.Fprint(ctx.app.Writer, progress)
+
+	err = runWithEvents(events, ctx.app.Writer, progress, func() error {
+		return ctx.client.SetPort(ctx.name, port)
+	})
+	if busyErr, ok := err.(*api.StackBusyError); ok {
+		return busyAppError(busyErr, ctx.wait)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"appName": ctx.name,
+			"port":    port,
+		}).Fatal("Failed to set port: " + err.Error())
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "Setting port of %s to %s and restarting... done\n", appStr, portStr)
+
+	return nil
+}
+
+// portEnvName is the environment variable of the port. It is managed by `herogate port:set`, not config vars.
+const portEnvName = "PORT"
+
+func portConfigVarError() error {
+	return cli.NewExitError(
+		fmt.Sprintf(
+			"%s    %s is set by Herogate. Run %s to change the port.",
+			color.New(color.FgRed).Sprint("▸"),
+			color.New(color.FgGreen).Sprint(portEnvName),
+			color.New(color.FgCyan).Sprint("herogate port:set PORT"),
+		),
+		1,
+	)
+}
@@ -0,0 +1,181 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessPort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get port
+	client.EXPECT().GetPort("young-eyrie-24091").Return(5000, nil)
+
+	err := processPort(&portContext{
+		name:   "young-eyrie-24091",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	if writer.String() != "5000\n" {
+		t.Fatalf("Expected to output is `5000`, but get `%s`", writer.String())
+	}
+}
+
+func TestProcessPortSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:            "young-eyrie-24091",
+		Status:          "UPDATE_COMPLETE",
+		PlatformVersion: "1.5",
+	}, nil)
+	// Expect to set port
+	client.EXPECT().SetPort("young-eyrie-24091", 5000).Return(nil)
+
+	err := processPortSet(&portContext{
+		name:   "young-eyrie-24091",
+		port:   "5000",
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	portStr := color.New(color.FgGreen).Sprint(5000)
+	expected := fmt.Sprintf(
+		"Setting port of %s to %s and restarting...\rSetting port of %s to %s and restarting... done\n",
+		appStr, portStr, appStr, portStr,
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessPortSet__dryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application, even if it is busy
+	client.EXPECT().GetApp("young-eyrie-24091").Return(&objects.App{
+		Name:   "young-eyrie-24091",
+		Status: "UPDATE_IN_PROGRESS",
+	}, nil)
+	// Expect to preview port
+	client.EXPECT().PreviewSetPort("young-eyrie-24091", 5000).Return([]*objects.Change{
+		{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateApplicationContainer",
+			ResourceType:      "AWS::ECS::TaskDefinition",
+			Replacement:       "True",
+		},
+		{
+			Action:            "Modify",
+			LogicalResourceID: "HerogateApplicationService",
+			ResourceType:      "AWS::ECS::Service",
+			Replacement:       "False",
+		},
+	}, nil)
+
+	err := processPortSet(&portContext{
+		name:   "young-eyrie-24091",
+		port:   "5000",
+		dryRun: true,
+		app:    app,
+		client: client,
+	})
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	appStr := color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091")
+	portStr := color.New(color.FgGreen).Sprint(5000)
+	modify := color.New(color.FgYellow).Sprint("Modify")
+	expected := fmt.Sprintf("Previewing setting port of %s to %s...\r", appStr, portStr)
+	expected = expected + fmt.Sprintf(`Previewing setting port of %s to %s... done
+%s HerogateApplicationContainer AWS::ECS::TaskDefinition%s
+%s HerogateApplicationService   AWS::ECS::Service
+`, appStr, portStr, modify, color.New(color.FgRed).Sprint(" (replacement)"), modify)
+
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessPortSet__errors(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Port     string
+		Expected string
+	}{
+		{
+			Name: "invalid port",
+			Port: "http",
+			Expected: fmt.Sprintf(
+				"%s    %s is invalid. Must be a number between 1 and 65535.",
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgCyan).Sprint("http"),
+			),
+		},
+		{
+			Name: "out of range",
+			Port: "70000",
+			Expected: fmt.Sprintf(
+				"%s    %s is invalid. Must be a number between 1 and 65535.",
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgCyan).Sprint("70000"),
+			),
+		},
+	}
+
+	for _, tc := range cases {
+		ctrl := gomock.NewController(t)
+
+		client := mock.NewMockClientInterface(ctrl)
+
+		err := processPortSet(&portContext{
+			name:   "young-eyrie-24091",
+			port:   tc.Port,
+			app:    cli.NewApp(),
+			client: client,
+		})
+		if err == nil {
+			t.Fatalf("Expected error is not nil, but get nil in %s", tc.Name)
+		}
+		if err.Error() != tc.Expected {
+			t.Fatalf("Expected error is `%s`, but get `%s` in %s", tc.Expected, err.Error(), tc.Name)
+		}
+
+		ctrl.Finish()
+	}
+}
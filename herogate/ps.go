@@ -31,7 +31,7 @@ func Ps(ctx *cli.Context) error {
 	return processPs(&psContext{
 		name: name,
 		app:  ctx.App,
-		client: api.NewClient(&api.ClientOption{
+		client: newClient(&api.ClientOption{
 			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
 		}),
 	})
@@ -0,0 +1,64 @@
+package herogate
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+)
+
+type releasesContext struct {
+	name   string
+	app    *cli.App
+	client iface.ClientInterface
+}
+
+// Releases displays the release history of the app.
+func Releases(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processReleases(&releasesContext{
+		name: name,
+		app:  ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processReleases(ctx *releasesContext) error {
+	releases, err := ctx.client.DescribeReleases(ctx.name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	appStr := color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.name)
+	if len(releases) == 0 {
+		fmt.Fprintf(ctx.app.Writer, "%s has no releases.\n", appStr)
+		return nil
+	}
+
+	fmt.Fprintf(ctx.app.Writer, "=== %s Releases\n", appStr)
+	for _, release := range releases {
+		fmt.Fprintf(
+			ctx.app.Writer,
+			"%s  %s  %s  %s\n",
+			color.New(color.FgGreen).Sprintf("v%d", release.Version),
+			release.Description,
+			release.Image,
+			release.CreatedAt.Local().Format("2006/01/02 15:04:05"),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package herogate
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api"
+	"github.com/wata727/herogate/api/iface"
+)
+
+type reviewCreateContext struct {
+	parent  string
+	branch  string
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
+}
+
+// ReviewCreate creates a review app which deploys the branch of the parent app.
+// The review app is cloned from the parent app's config.
+func ReviewCreate(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	branch := ctx.Args().First()
+	if branch == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require argument, You must specify a branch name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processReviewCreate(&reviewCreateContext{
+		parent:  name,
+		branch:  branch,
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processReviewCreate(ctx *reviewCreateContext) error {
+	parent, err := ctx.client.GetApp(ctx.parent)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find that app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	if parent.Parent != "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Cannot create a review app from the review app.", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	name := api.ReviewAppName(ctx.parent, ctx.branch)
+	if len(name) > api.MaxAppNameLength {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    The name of the review app %s is longer than %d characters. Use a shorter name for the parent app.",
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgMagenta).Sprintf("⬢ %s", name),
+				api.MaxAppNameLength,
+			),
+			1,
+		)
+	}
+
+	return processAppsCreate(&appsCreateContext{
+		name:    name,
+		branch:  ctx.branch,
+		parent:  ctx.parent,
+		verbose: ctx.verbose,
+		app:     ctx.app,
+		client:  ctx.client,
+	})
+}
+
+type reviewDestroyContext struct {
+	parent  string
+	branch  string
+	verbose bool
+	app     *cli.App
+	client  iface.ClientInterface
+}
+
+// ReviewDestroy destroys the review app of the branch.
+// Unlike `apps:destroy`, it doesn't require confirmation because review apps are temporary.
+func ReviewDestroy(ctx *cli.Context) error {
+	_, name := detectAppFromRepo()
+	if ctx.String("app") != "" {
+		logrus.Debug("Override application name: " + ctx.String("app"))
+		name = ctx.String("app")
+	}
+	if name == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require flag `-a`, You must specify an application name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+	branch := ctx.Args().First()
+	if branch == "" {
+		return cli.NewExitError(fmt.Sprintf("%s    Missing require argument, You must specify a branch name", color.New(color.FgRed).Sprint("▸")), 1)
+	}
+
+	return processReviewDestroy(&reviewDestroyContext{
+		parent:  name,
+		branch:  branch,
+		verbose: ctx.Bool("verbose"),
+		app:     ctx.App,
+		client: newClient(&api.ClientOption{
+			Region: "us-east-1", // NOTE: Currently, Fargate supported region is only `us-east-1`
+		}),
+	})
+}
+
+func processReviewDestroy(ctx *reviewDestroyContext) error {
+	name := api.ReviewAppName(ctx.parent, ctx.branch)
+	app, err := ctx.client.GetApp(name)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("%s    Couldn't find the review app of %s.", color.New(color.FgRed).Sprint("▸"), color.New(color.FgGreen).Sprint(ctx.branch)), 1)
+	}
+	if app.Parent != ctx.parent {
+		return cli.NewExitError(
+			fmt.Sprintf(
+				"%s    %s is not a review app of %s.",
+				color.New(color.FgRed).Sprint("▸"),
+				color.New(color.FgMagenta).Sprintf("⬢ %s", name),
+				color.New(color.FgMagenta).Sprintf("⬢ %s", ctx.parent),
+			),
+			1,
+		)
+	}
+
+	return processAppsDestroy(&appsDestroyContext{
+		name:    name,
+		parent:  ctx.parent,
+		app:     ctx.app,
+		confirm: name,
+		verbose: ctx.verbose,
+		client:  ctx.client,
+	})
+}
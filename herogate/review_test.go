@@ -0,0 +1,146 @@
+package herogate
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/golang/mock/gomock"
+	"github.com/urfave/cli"
+	"github.com/wata727/herogate/api/objects"
+	"github.com/wata727/herogate/mock"
+)
+
+func TestProcessAppsWithReviewApps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to list applications
+	client.EXPECT().ListApps().Return([]*objects.App{
+		{
+			Name:   "young-eyrie-24091-pr-feature",
+			Status: "CREATE_COMPLETE",
+			Parent: "young-eyrie-24091",
+		},
+		{
+			Name:   "young-eyrie-24091",
+			Status: "CREATE_COMPLETE",
+		},
+		{
+			Name:   "proud-lab-1661",
+			Status: "CREATE_COMPLETE",
+		},
+	})
+
+	app := cli.NewApp()
+	writer := new(bytes.Buffer)
+	app.Writer = writer
+
+	processApps(&appsContext{
+		app:    app,
+		client: client,
+	})
+
+	expected := fmt.Sprintf(
+		"=== Apps\nyoung-eyrie-24091\n  young-eyrie-24091-pr-feature %s\nproud-lab-1661\n\n",
+		color.New(color.FgCyan).Sprint("(review)"),
+	)
+	if writer.String() != expected {
+		t.Fatalf("Expected to output is `%s`, but get `%s`", expected, writer.String())
+	}
+}
+
+func TestProcessReviewCreate__fromReviewApp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091-pr-feature").Return(&objects.App{
+		Name:   "young-eyrie-24091-pr-feature",
+		Status: "CREATE_COMPLETE",
+		Parent: "young-eyrie-24091",
+	}, nil)
+
+	err := processReviewCreate(&reviewCreateContext{
+		parent: "young-eyrie-24091-pr-feature",
+		branch: "login",
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf("%s    Cannot create a review app from the review app.", color.New(color.FgRed).Sprint("▸"))
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
+func TestProcessReviewCreate__longParentName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("a-very-long-application-name").Return(&objects.App{
+		Name:   "a-very-long-application-name",
+		Status: "CREATE_COMPLETE",
+	}, nil)
+	// Expect not to create the app
+	client.EXPECT().CreateApp(gomock.Any(), gomock.Any()).Times(0)
+
+	err := processReviewCreate(&reviewCreateContext{
+		parent: "a-very-long-application-name",
+		branch: "login",
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    The name of the review app %s is longer than 31 characters. Use a shorter name for the parent app.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ a-very-long-application-name-pr-2736fab"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
+
+func TestProcessReviewDestroy__notReviewApp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewMockClientInterface(ctrl)
+	// Expect to get application
+	client.EXPECT().GetApp("young-eyrie-24091-pr-feature").Return(&objects.App{
+		Name:   "young-eyrie-24091-pr-feature",
+		Status: "CREATE_COMPLETE",
+	}, nil)
+
+	err := processReviewDestroy(&reviewDestroyContext{
+		parent: "young-eyrie-24091",
+		branch: "feature",
+		app:    cli.NewApp(),
+		client: client,
+	})
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+
+	expected := fmt.Sprintf(
+		"%s    %s is not a review app of %s.",
+		color.New(color.FgRed).Sprint("▸"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091-pr-feature"),
+		color.New(color.FgMagenta).Sprint("⬢ young-eyrie-24091"),
+	)
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
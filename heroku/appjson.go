@@ -0,0 +1,211 @@
+package heroku
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AppJSONFileName is the file name of the app manifest of Heroku.
+const AppJSONFileName = "app.json"
+
+// AppJSON is a subset of `app.json` which Herogate understands.
+// See https://devcenter.heroku.com/articles/app-json-schema
+type AppJSON struct {
+	Name         string                `json:"name"`
+	Env          map[string]*EnvVar    `json:"env"`
+	Formation    map[string]*Formation `json:"formation"`
+	Addons       []*Addon              `json:"addons"`
+	Scripts      map[string]string     `json:"scripts"`
+	Environments struct {
+		Test struct {
+			Scripts struct {
+				Test string `json:"test"`
+			} `json:"scripts"`
+		} `json:"test"`
+	} `json:"environments"`
+}
+
+// EnvVar is a config var in `app.json`. It is declared with a string value or an object.
+type EnvVar struct {
+	Value       string `json:"value"`
+	Description string `json:"description"`
+	Required    *bool  `json:"required"`
+	Generator   string `json:"generator"`
+}
+
+// UnmarshalJSON accepts both `"value"` and `{"value": "value"}`.
+func (e *EnvVar) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		e.Value = value
+		return nil
+	}
+	type envVar EnvVar
+	return json.Unmarshal(data, (*envVar)(e))
+}
+
+// IsRequired returns whether the config var must have a value. Heroku treats config vars as required by default.
+func (e *EnvVar) IsRequired() bool {
+	return e.Required == nil || *e.Required
+}
+
+// Formation is the number and size of dynos of a process.
+type Formation struct {
+	Quantity int    `json:"quantity"`
+	Size     string `json:"size"`
+}
+
+// Addon is an add-on in `app.json`. It is declared with a plan name or an object.
+type Addon struct {
+	Plan string `json:"plan"`
+}
+
+// UnmarshalJSON accepts both `"plan"` and `{"plan": "plan"}`.
+func (a *Addon) UnmarshalJSON(data []byte) error {
+	var plan string
+	if err := json.Unmarshal(data, &plan); err == nil {
+		a.Plan = plan
+		return nil
+	}
+	type addon Addon
+	return json.Unmarshal(data, (*addon)(a))
+}
+
+// ParseAppJSON parses `app.json`.
+func ParseAppJSON(data []byte) (*AppJSON, error) {
+	appJSON := &AppJSON{}
+	if err := json.Unmarshal(data, appJSON); err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %s", AppJSONFileName, err.Error())
+	}
+	return appJSON, nil
+}
+
+// TaskSize is CPU units and memory in MiB of Fargate task.
+type TaskSize struct {
+	CPU    string
+	Memory string
+}
+
+// dynoSizes map dyno sizes to the closest Fargate task sizes in ascending order.
+var dynoSizes = []struct {
+	names []string
+	size  *TaskSize
+}{
+	{names: []string{"free", "eco", "hobby", "basic", "standard-1x"}, size: &TaskSize{CPU: "256", Memory: "512"}},
+	{names: []string{"standard-2x"}, size: &TaskSize{CPU: "512", Memory: "1024"}},
+	{names: []string{"performance-m"}, size: &TaskSize{CPU: "2048", Memory: "4096"}},
+	{names: []string{"performance-l"}, size: &TaskSize{CPU: "4096", Memory: "16384"}},
+}
+
+// Plan is the translation of Heroku settings to Herogate.
+// Skipped are messages about settings which Herogate doesn't support.
+type Plan struct {
+	EnvVars      map[string]string
+	DesiredCount int64
+	Size         *TaskSize
+	Skipped      []string
+}
+
+// Plan translates config vars, formation and add-ons in `app.json` to Herogate.
+// Config vars with the `secret` generator get a random value, and required config vars without value are skipped.
+// All processes run in the same task, so the largest quantity and size of the formation are used.
+func (a *AppJSON) Plan() *Plan {
+	plan := &Plan{EnvVars: map[string]string{}, Skipped: []string{}}
+
+	for _, name := range envVarNames(a.Env) {
+		env := a.Env[name]
+		switch {
+		case env.Generator == "secret":
+			plan.EnvVars[name] = secret()
+		case env.Generator != "":
+			plan.Skipped = append(plan.Skipped, fmt.Sprintf("config var %s: unknown generator `%s`", name, env.Generator))
+		case env.Value != "" || !env.IsRequired():
+			plan.EnvVars[name] = env.Value
+		default:
+			plan.Skipped = append(plan.Skipped, fmt.Sprintf("config var %s: required, but has no value. Run `herogate config:set %s=...`", name, name))
+		}
+	}
+
+	plan.applyFormation(a.Formation)
+
+	for _, addon := range a.Addons {
+		plan.Skipped = append(plan.Skipped, fmt.Sprintf("add-on %s: add-ons are not supported, attach the service with config vars", addon.Plan))
+	}
+	scripts := []string{}
+	for name := range a.Scripts {
+		if name != "test" {
+			scripts = append(scripts, name)
+		}
+	}
+	sort.Strings(scripts)
+	for _, name := range scripts {
+		plan.Skipped = append(plan.Skipped, fmt.Sprintf("script %s: only the test script is supported", name))
+	}
+
+	return plan
+}
+
+// applyFormation sets the largest quantity and size of the formation to the plan.
+func (p *Plan) applyFormation(formation map[string]*Formation) {
+	processes := []string{}
+	for name := range formation {
+		processes = append(processes, name)
+	}
+	sort.Strings(processes)
+	sizeIndex := -1
+	for _, name := range processes {
+		process := formation[name]
+		if int64(process.Quantity) > p.DesiredCount {
+			p.DesiredCount = int64(process.Quantity)
+		}
+		if process.Size == "" {
+			continue
+		}
+		index := dynoSizeIndex(process.Size)
+		if index < 0 {
+			p.Skipped = append(p.Skipped, fmt.Sprintf("formation %s: unknown size `%s`", name, process.Size))
+			continue
+		}
+		if index > sizeIndex {
+			sizeIndex = index
+		}
+	}
+	if sizeIndex >= 0 {
+		p.Size = dynoSizes[sizeIndex].size
+	}
+}
+
+func dynoSizeIndex(size string) int {
+	for i, dynoSize := range dynoSizes {
+		for _, name := range dynoSize.names {
+			if strings.ToLower(size) == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// secret generates a 64 characters hex string like the `secret` generator of Heroku.
+func secret() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		logrus.Fatal("Failed to generate a secret: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
+
+func envVarNames(env map[string]*EnvVar) []string {
+	names := []string{}
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,67 @@
+package heroku
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAppJSONPlan(t *testing.T) {
+	appJSON, err := ParseAppJSON([]byte(`{
+  "name": "my-app",
+  "env": {
+    "RAILS_ENV": "production",
+    "SECRET_KEY_BASE": {"generator": "secret"},
+    "WEB_CONCURRENCY": {"description": "Number of processes", "value": "2"},
+    "SENTRY_DSN": {"description": "Sentry DSN", "required": false},
+    "STRIPE_KEY": {"description": "Stripe API key"}
+  },
+  "formation": {
+    "web": {"quantity": 2, "size": "standard-2X"},
+    "worker": {"quantity": 1, "size": "standard-1X"},
+    "clock": {"quantity": 1, "size": "private-s"}
+  },
+  "addons": ["heroku-postgresql", {"plan": "heroku-redis:mini"}],
+  "scripts": {"postdeploy": "bundle exec rake db:seed", "test": "bundle exec rspec"}
+}`))
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	plan := appJSON.Plan()
+	if len(plan.EnvVars["SECRET_KEY_BASE"]) != 64 {
+		t.Fatalf("Expected secret is 64 characters, but get `%s`", plan.EnvVars["SECRET_KEY_BASE"])
+	}
+	delete(plan.EnvVars, "SECRET_KEY_BASE")
+
+	expected := &Plan{
+		EnvVars: map[string]string{
+			"RAILS_ENV":       "production",
+			"WEB_CONCURRENCY": "2",
+			"SENTRY_DSN":      "",
+		},
+		DesiredCount: 2,
+		Size:         &TaskSize{CPU: "512", Memory: "1024"},
+		Skipped: []string{
+			"config var STRIPE_KEY: required, but has no value. Run `herogate config:set STRIPE_KEY=...`",
+			"formation clock: unknown size `private-s`",
+			"add-on heroku-postgresql: add-ons are not supported, attach the service with config vars",
+			"add-on heroku-redis:mini: add-ons are not supported, attach the service with config vars",
+			"script postdeploy: only the test script is supported",
+		},
+	}
+	if !cmp.Equal(expected, plan) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, plan))
+	}
+}
+
+func TestParseAppJSON__invalid(t *testing.T) {
+	_, err := ParseAppJSON([]byte(`{"env": [`))
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+	expected := "Failed to parse app.json: unexpected end of JSON input"
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
@@ -0,0 +1,142 @@
+package heroku
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Export is the configuration exported from a Heroku app with the Heroku CLI.
+type Export struct {
+	ConfigVars map[string]string
+	Formation  map[string]*Formation
+	Domains    []string
+	Addons     []*ExportedAddon
+}
+
+// ExportedAddon is an add-on attached to a Heroku app. ConfigVars are config vars set by the add-on.
+type ExportedAddon struct {
+	Name       string   `json:"name"`
+	Plan       string   `json:"-"`
+	ConfigVars []string `json:"config_vars"`
+}
+
+// UnmarshalJSON reads the plan name from `{"plan": {"name": "plan"}}`.
+func (a *ExportedAddon) UnmarshalJSON(data []byte) error {
+	type exportedAddon ExportedAddon
+	addon := struct {
+		*exportedAddon
+		Plan struct {
+			Name string `json:"name"`
+		} `json:"plan"`
+	}{exportedAddon: (*exportedAddon)(a)}
+	if err := json.Unmarshal(data, &addon); err != nil {
+		return err
+	}
+	a.Plan = addon.Plan.Name
+	return nil
+}
+
+// ParseConfigVars parses the output of `heroku config --json`.
+func ParseConfigVars(data []byte) (map[string]string, error) {
+	configVars := map[string]string{}
+	if err := json.Unmarshal(data, &configVars); err != nil {
+		return nil, fmt.Errorf("Failed to parse config vars: %s", err.Error())
+	}
+	return configVars, nil
+}
+
+// ParseFormation parses the formation list of the Heroku Platform API, like `[{"type": "web", "quantity": 1, "size": "Standard-1X"}]`.
+// The formation map of `app.json` is also accepted.
+func ParseFormation(data []byte) (map[string]*Formation, error) {
+	formation := map[string]*Formation{}
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		if err := json.Unmarshal(data, &formation); err != nil {
+			return nil, fmt.Errorf("Failed to parse formation: %s", err.Error())
+		}
+		return formation, nil
+	}
+
+	processes := []struct {
+		Type     string `json:"type"`
+		Quantity int    `json:"quantity"`
+		Size     string `json:"size"`
+	}{}
+	if err := json.Unmarshal(data, &processes); err != nil {
+		return nil, fmt.Errorf("Failed to parse formation: %s", err.Error())
+	}
+	for _, process := range processes {
+		if process.Type == "" {
+			return nil, fmt.Errorf("Failed to parse formation: type is required")
+		}
+		formation[process.Type] = &Formation{Quantity: process.Quantity, Size: process.Size}
+	}
+	return formation, nil
+}
+
+// ParseDomains parses the output of `heroku domains --json`, or a list of hostnames separated by newlines.
+// Default domains of Heroku are excluded.
+func ParseDomains(data []byte) ([]string, error) {
+	hostnames := []string{}
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		domains := []struct {
+			Hostname string `json:"hostname"`
+			Kind     string `json:"kind"`
+		}{}
+		if err := json.Unmarshal(data, &domains); err != nil {
+			return nil, fmt.Errorf("Failed to parse domains: %s", err.Error())
+		}
+		for _, domain := range domains {
+			if domain.Kind != "heroku" {
+				hostnames = append(hostnames, domain.Hostname)
+			}
+		}
+	} else {
+		for _, line := range strings.Split(string(data), "\n") {
+			hostnames = append(hostnames, strings.TrimSpace(line))
+		}
+	}
+
+	domains := []string{}
+	for _, hostname := range hostnames {
+		if hostname == "" || strings.HasSuffix(hostname, ".herokuapp.com") {
+			continue
+		}
+		domains = append(domains, hostname)
+	}
+	return domains, nil
+}
+
+// ParseAddons parses the output of `heroku addons --json`.
+func ParseAddons(data []byte) ([]*ExportedAddon, error) {
+	addons := []*ExportedAddon{}
+	if err := json.Unmarshal(data, &addons); err != nil {
+		return nil, fmt.Errorf("Failed to parse add-ons: %s", err.Error())
+	}
+	return addons, nil
+}
+
+// Plan translates the exported configuration to Herogate.
+// Config vars are copied as they are, so config vars of add-ons still point to the services on Heroku.
+// Herogate doesn't manage domains and add-ons, so they are reported as skipped.
+func (e *Export) Plan() *Plan {
+	plan := &Plan{EnvVars: map[string]string{}, Skipped: []string{}}
+
+	for name, value := range e.ConfigVars {
+		plan.EnvVars[name] = value
+	}
+	plan.applyFormation(e.Formation)
+
+	for _, domain := range e.Domains {
+		plan.Skipped = append(plan.Skipped, fmt.Sprintf("domain %s: custom domains are not supported, point the DNS record to the Web URL", domain))
+	}
+	for _, addon := range e.Addons {
+		message := fmt.Sprintf("add-on %s (%s): add-ons are not supported", addon.Name, addon.Plan)
+		if len(addon.ConfigVars) > 0 {
+			message += fmt.Sprintf(", config vars %s still point to the add-on on Heroku", strings.Join(addon.ConfigVars, ", "))
+		}
+		plan.Skipped = append(plan.Skipped, message)
+	}
+
+	return plan
+}
@@ -0,0 +1,119 @@
+package heroku
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExportPlan(t *testing.T) {
+	configVars, err := ParseConfigVars([]byte(`{"DATABASE_URL": "postgres://example.com/db", "RAILS_ENV": "production"}`))
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+	formation, err := ParseFormation([]byte(`[
+  {"type": "web", "quantity": 2, "size": "Standard-1X", "command": "bundle exec puma"},
+  {"type": "worker", "quantity": 1, "size": "Performance-M", "command": "bundle exec sidekiq"}
+]`))
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+	domains, err := ParseDomains([]byte(`[
+  {"hostname": "my-app.herokuapp.com", "kind": "heroku"},
+  {"hostname": "www.example.com", "kind": "custom"}
+]`))
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+	addons, err := ParseAddons([]byte(`[
+  {"name": "postgresql-cubic-12345", "plan": {"name": "heroku-postgresql:mini"}, "config_vars": ["DATABASE_URL"]}
+]`))
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	plan := (&Export{
+		ConfigVars: configVars,
+		Formation:  formation,
+		Domains:    domains,
+		Addons:     addons,
+	}).Plan()
+
+	expected := &Plan{
+		EnvVars: map[string]string{
+			"DATABASE_URL": "postgres://example.com/db",
+			"RAILS_ENV":    "production",
+		},
+		DesiredCount: 2,
+		Size:         &TaskSize{CPU: "2048", Memory: "4096"},
+		Skipped: []string{
+			"domain www.example.com: custom domains are not supported, point the DNS record to the Web URL",
+			"add-on postgresql-cubic-12345 (heroku-postgresql:mini): add-ons are not supported, config vars DATABASE_URL still point to the add-on on Heroku",
+		},
+	}
+	if !cmp.Equal(expected, plan) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, plan))
+	}
+}
+
+func TestParseFormation(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Data     string
+		Expected map[string]*Formation
+		Error    string
+	}{
+		{
+			Name: "formation list",
+			Data: `[{"type": "web", "quantity": 1}]`,
+			Expected: map[string]*Formation{
+				"web": {Quantity: 1},
+			},
+		},
+		{
+			Name: "formation map",
+			Data: `{"web": {"quantity": 3, "size": "standard-2x"}}`,
+			Expected: map[string]*Formation{
+				"web": {Quantity: 3, Size: "standard-2x"},
+			},
+		},
+		{
+			Name:  "missing type",
+			Data:  `[{"quantity": 1}]`,
+			Error: "Failed to parse formation: type is required",
+		},
+		{
+			Name:  "invalid JSON",
+			Data:  `[`,
+			Error: "Failed to parse formation: unexpected end of JSON input",
+		},
+	}
+
+	for _, tc := range cases {
+		formation, err := ParseFormation([]byte(tc.Data))
+		if tc.Error != "" {
+			if err == nil || err.Error() != tc.Error {
+				t.Fatalf("Expected error is `%s`, but get `%v` in %s", tc.Error, err, tc.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected error is nil, but get `%s` in %s", err.Error(), tc.Name)
+		}
+		if !cmp.Equal(tc.Expected, formation) {
+			t.Fatalf("\nDiff: %s\n in %s", cmp.Diff(tc.Expected, formation), tc.Name)
+		}
+	}
+}
+
+func TestParseDomains__list(t *testing.T) {
+	domains, err := ParseDomains([]byte("my-app.herokuapp.com\nwww.example.com\n\napi.example.com\n"))
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := []string{"www.example.com", "api.example.com"}
+	if !cmp.Equal(expected, domains) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, domains))
+	}
+}
@@ -0,0 +1,87 @@
+package heroku
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olebedev/config"
+)
+
+// HerokuYMLFileName is the file name of the build manifest of Heroku.
+const HerokuYMLFileName = "heroku.yml"
+
+// HerokuYML is a subset of `heroku.yml` which Herogate understands.
+// Dockerfiles are keyed by the process name, and Run is the command of each process.
+// See https://devcenter.heroku.com/articles/build-docker-images-heroku-yml
+type HerokuYML struct {
+	Dockerfiles map[string]string
+	Run         map[string]string
+}
+
+// ParseHerokuYML parses `heroku.yml`. A run entry is declared with a command or an object with `command`.
+func ParseHerokuYML(data string) (*HerokuYML, error) {
+	herokuYML := &HerokuYML{
+		Dockerfiles: map[string]string{},
+		Run:         map[string]string{},
+	}
+	if strings.TrimSpace(data) == "" {
+		return herokuYML, nil
+	}
+
+	cfg, err := config.ParseYaml(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %s", HerokuYMLFileName, err.Error())
+	}
+
+	if dockerfiles, err := cfg.Map("build.docker"); err == nil {
+		for name, dockerfile := range dockerfiles {
+			if path, ok := dockerfile.(string); ok {
+				herokuYML.Dockerfiles[name] = path
+			}
+		}
+	}
+
+	run, err := cfg.Map("run")
+	if err != nil {
+		return herokuYML, nil
+	}
+	for name, entry := range run {
+		switch entry := entry.(type) {
+		case string:
+			herokuYML.Run[name] = entry
+		case map[string]interface{}:
+			switch command := entry["command"].(type) {
+			case string:
+				herokuYML.Run[name] = command
+			case []interface{}:
+				parts := []string{}
+				for _, part := range command {
+					parts = append(parts, fmt.Sprint(part))
+				}
+				herokuYML.Run[name] = strings.Join(parts, " ")
+			default:
+				return nil, fmt.Errorf("Failed to parse %s: run.%s.command must be a string or a list", HerokuYMLFileName, name)
+			}
+		default:
+			return nil, fmt.Errorf("Failed to parse %s: run.%s must be a command or a mapping with command", HerokuYMLFileName, name)
+		}
+	}
+
+	return herokuYML, nil
+}
+
+// Procfile returns the run entries in the format of Procfile.
+func (h *HerokuYML) Procfile() string {
+	names := []string{}
+	for name := range h.Run {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	procfile := ""
+	for _, name := range names {
+		procfile += fmt.Sprintf("%s: %s\n", name, h.Run[name])
+	}
+	return procfile
+}
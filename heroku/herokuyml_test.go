@@ -0,0 +1,57 @@
+package heroku
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseHerokuYML(t *testing.T) {
+	herokuYML, err := ParseHerokuYML(`build:
+  docker:
+    web: Dockerfile.web
+    worker: worker/Dockerfile
+run:
+  web: bundle exec puma -C config/puma.rb
+  worker:
+    command:
+      - python myworker.py
+    image: worker
+  clock:
+    command: bundle exec clockwork clock.rb
+`)
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := &HerokuYML{
+		Dockerfiles: map[string]string{
+			"web":    "Dockerfile.web",
+			"worker": "worker/Dockerfile",
+		},
+		Run: map[string]string{
+			"web":    "bundle exec puma -C config/puma.rb",
+			"worker": "python myworker.py",
+			"clock":  "bundle exec clockwork clock.rb",
+		},
+	}
+	if !cmp.Equal(expected, herokuYML) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, herokuYML))
+	}
+
+	procfile := "clock: bundle exec clockwork clock.rb\nweb: bundle exec puma -C config/puma.rb\nworker: python myworker.py\n"
+	if herokuYML.Procfile() != procfile {
+		t.Fatalf("Expected Procfile is `%s`, but get `%s`", procfile, herokuYML.Procfile())
+	}
+}
+
+func TestParseHerokuYML__invalid(t *testing.T) {
+	_, err := ParseHerokuYML("run:\n  web:\n    image: web\n")
+	if err == nil {
+		t.Fatal("Expected error is not nil, but get nil")
+	}
+	expected := "Failed to parse heroku.yml: run.web.command must be a string or a list"
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
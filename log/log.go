@@ -24,15 +24,21 @@ const (
 const (
 	// BuilderProcess is a kind of process type. This type occurs from builder events.
 	BuilderProcess = "builder"
+	// TesterProcess is a kind of process type. This type occurs from tester events.
+	TesterProcess = "tester"
 	// DeployerProcess is a kind of process type. This type occurs from deployer events.
 	DeployerProcess = "deployer"
 )
 
 // Format returns formatted text. This text including source, process, and timestamp (RFC3339).
 func (l *Log) Format() string {
-	herogateColor := color.New(color.FgGreen)
-	timestamp := herogateColor.Sprint(l.Timestamp.Format(time.RFC3339))
-	meta := herogateColor.Sprintf("%s[%s]:", l.Source, l.Process)
+	return l.FormatWithColor(color.New(color.FgGreen))
+}
+
+// FormatWithColor returns formatted text like Format, but the timestamp and the prefix are colored by the specified color.
+func (l *Log) FormatWithColor(c *color.Color) string {
+	timestamp := c.Sprint(l.Timestamp.Format(time.RFC3339))
+	meta := c.Sprintf("%s[%s]:", l.Source, l.Process)
 
 	return fmt.Sprintf("%s %s %s", timestamp, meta, l.Message)
 }
@@ -26,3 +26,21 @@ func TestFormat(t *testing.T) {
 		t.Fatalf("\nExpected: %s\nActual: %s", expected, testLog.Format())
 	}
 }
+
+func TestFormatWithColor(t *testing.T) {
+	testLog := Log{
+		ID:        "foo",
+		Timestamp: time.Date(2018, time.February, 2, 11, 0, 9, 0, time.FixedZone("UTC", 0)),
+		Source:    "app",
+		Process:   "web",
+		Message:   "foo message",
+	}
+
+	processColor := color.New(color.FgCyan)
+	logTimestamps := processColor.Sprint("2018-02-02T11:00:09Z")
+	logMeta := processColor.Sprint("app[web]:")
+	expected := fmt.Sprintf("%s %s foo message", logTimestamps, logMeta)
+	if testLog.FormatWithColor(processColor) != expected {
+		t.Fatalf("\nExpected: %s\nActual: %s", expected, testLog.FormatWithColor(processColor))
+	}
+}
@@ -0,0 +1,368 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hecticjeff/procfile"
+	"github.com/olebedev/config"
+	"github.com/wata727/herogate/container"
+)
+
+// FileName is the file name of the manifest in the root of the repository.
+const FileName = "herogate.yml"
+
+// Manifest is per-process settings of the application. Processes are keyed by the process name in Procfile.
+type Manifest struct {
+	Processes map[string]*Process
+}
+
+// Process is settings of a process. Zero values mean that the setting is not declared.
+// Command and Image are used to declare sidecars which are not in Procfile.
+type Process struct {
+	Command     string
+	Image       string
+	Port        int
+	CPU         int
+	Memory      int
+	HealthCheck *HealthCheck
+	Essential   *bool
+	StopTimeout int
+	DependsOn   []*Dependency
+}
+
+// HealthCheck is the health check run in the container of the process. Durations are in seconds.
+type HealthCheck struct {
+	Command     string
+	Interval    int
+	Timeout     int
+	Retries     int
+	StartPeriod int
+}
+
+// Dependency is a process which must reach the condition before the process starts.
+type Dependency struct {
+	Name      string
+	Condition string
+}
+
+// ValidationError is returned when the manifest doesn't match the schema.
+// Each error is prefixed with the path of the invalid value, like `processes.web.port`.
+type ValidationError struct {
+	Errors []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s is invalid:\n  %s", FileName, strings.Join(e.Errors, "\n  "))
+}
+
+// dependencyConditions are conditions supported by ECS.
+var dependencyConditions = []string{"START", "COMPLETE", "SUCCESS", "HEALTHY"}
+
+// Parse parses the manifest and validates it against the schema and Procfile.
+// If the manifest is empty, it returns an empty manifest. If it is invalid, it returns ValidationError with all errors.
+func Parse(manifest string, procfileContent string) (*Manifest, error) {
+	result := &Manifest{Processes: map[string]*Process{}}
+	if strings.TrimSpace(manifest) == "" {
+		return result, nil
+	}
+
+	cfg, err := config.ParseYaml(manifest)
+	if err != nil {
+		return nil, &ValidationError{Errors: []string{fmt.Sprintf("failed to parse YAML: %s", err.Error())}}
+	}
+
+	v := &validator{}
+	root, ok := cfg.Root.(map[string]interface{})
+	if !ok {
+		v.errorf("", "must be a mapping")
+		return nil, v.err()
+	}
+	for key, value := range root {
+		if key != "processes" {
+			v.errorf(key, "unknown key, must be processes")
+			continue
+		}
+		processes, ok := value.(map[string]interface{})
+		if !ok {
+			v.errorf(key, "must be a mapping of process names")
+			continue
+		}
+		for name, settings := range processes {
+			result.Processes[name] = v.process("processes."+name, settings)
+		}
+	}
+
+	// Processes share the network of the task, so they cannot listen on the same port.
+	ports := map[int]string{}
+	procs := procfile.Parse(procfileContent)
+	for _, name := range sortedNames(result.Processes) {
+		process := result.Processes[name]
+		path := "processes." + name
+		if other, ok := ports[process.Port]; ok && process.Port != 0 {
+			v.errorf(path+".port", "%d is already used by `%s`", process.Port, other)
+		}
+		ports[process.Port] = name
+		if _, ok := procs[name]; !ok && process.Command == "" && process.Image == "" {
+			v.errorf(path, "is not in Procfile, so command or image is required")
+		}
+		for i, dependency := range process.DependsOn {
+			dependencyPath := fmt.Sprintf("%s.depends_on.%d", path, i)
+			target, declared := result.Processes[dependency.Name]
+			if _, ok := procs[dependency.Name]; !ok && !declared {
+				v.errorf(dependencyPath+".name", "process `%s` is not found", dependency.Name)
+				continue
+			}
+			if dependency.Name == name {
+				v.errorf(dependencyPath+".name", "process cannot depend on itself")
+			}
+			if dependency.Condition == "HEALTHY" && (!declared || target.HealthCheck == nil) {
+				v.errorf(dependencyPath+".condition", "HEALTHY requires healthcheck of `%s`", dependency.Name)
+			}
+		}
+	}
+
+	if len(v.errors) > 0 {
+		return nil, v.err()
+	}
+	return result, nil
+}
+
+// CommandArgs returns the command of the process declared in the manifest. If it is not declared, returns nil.
+func (p *Process) CommandArgs() []string {
+	if p.Command == "" {
+		return nil
+	}
+	process := procfile.Parse("process: " + p.Command)["process"]
+	return append([]string{process.Command}, process.Arguments...)
+}
+
+// Apply maps the settings of the process onto the container definition.
+// The port of the web process is mapped by `container.New`, so only other processes get a port mapping here.
+func (p *Process) Apply(definition *container.Definition) {
+	if p.Port != 0 {
+		definition.Environment = container.WithPort(definition.Environment, p.Port)
+		if definition.Name != "web" {
+			definition.PortMappings = append(definition.PortMappings, &container.PortMapping{ContainerPort: p.Port})
+		}
+	}
+	definition.CPU = p.CPU
+	definition.Memory = p.Memory
+	if p.HealthCheck != nil {
+		definition.HealthCheck = container.NewHealthCheck(p.HealthCheck.Command, p.HealthCheck.Interval)
+		definition.HealthCheck.Timeout = p.HealthCheck.Timeout
+		definition.HealthCheck.Retries = p.HealthCheck.Retries
+		definition.HealthCheck.StartPeriod = p.HealthCheck.StartPeriod
+	}
+	definition.Essential = p.Essential
+	definition.StopTimeout = p.StopTimeout
+	for _, dependency := range p.DependsOn {
+		definition.DependsOn = append(definition.DependsOn, &container.Dependency{
+			ContainerName: dependency.Name,
+			Condition:     dependency.Condition,
+		})
+	}
+}
+
+// Sidecars returns names of processes which are declared only in the manifest.
+func (m *Manifest) Sidecars(procfileContent string) []string {
+	procs := procfile.Parse(procfileContent)
+	names := []string{}
+	for _, name := range sortedNames(m.Processes) {
+		if _, ok := procs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func sortedNames(processes map[string]*Process) []string {
+	names := []string{}
+	for name := range processes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validator collects errors while walking the manifest.
+type validator struct {
+	errors []string
+}
+
+func (v *validator) errorf(path string, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if path != "" {
+		message = path + ": " + message
+	}
+	v.errors = append(v.errors, message)
+}
+
+func (v *validator) err() error {
+	sort.Strings(v.errors)
+	return &ValidationError{Errors: v.errors}
+}
+
+func (v *validator) process(path string, value interface{}) *Process {
+	process := &Process{}
+	settings, ok := value.(map[string]interface{})
+	if !ok {
+		if value != nil {
+			v.errorf(path, "must be a mapping of settings")
+		}
+		return process
+	}
+
+	for key, value := range settings {
+		keyPath := path + "." + key
+		switch key {
+		case "command":
+			process.Command = v.str(keyPath, value)
+		case "image":
+			process.Image = v.str(keyPath, value)
+		case "port":
+			process.Port = v.integer(keyPath, value, 1, 65535)
+		case "cpu":
+			process.CPU = v.integer(keyPath, value, 1, 4096)
+		case "memory":
+			process.Memory = v.integer(keyPath, value, 4, 30720)
+		case "healthcheck":
+			process.HealthCheck = v.healthCheck(keyPath, value)
+		case "essential":
+			essential, ok := value.(bool)
+			if !ok {
+				v.errorf(keyPath, "must be true or false, but got %s", describe(value))
+				continue
+			}
+			process.Essential = &essential
+		case "stop_timeout":
+			process.StopTimeout = v.integer(keyPath, value, 1, 120)
+		case "depends_on":
+			process.DependsOn = v.dependencies(keyPath, value)
+		default:
+			v.errorf(keyPath, "unknown key, must be one of command, image, port, cpu, memory, healthcheck, essential, stop_timeout and depends_on")
+		}
+	}
+	return process
+}
+
+func (v *validator) healthCheck(path string, value interface{}) *HealthCheck {
+	settings, ok := value.(map[string]interface{})
+	if !ok {
+		v.errorf(path, "must be a mapping of settings")
+		return nil
+	}
+
+	healthCheck := &HealthCheck{}
+	for key, value := range settings {
+		keyPath := path + "." + key
+		switch key {
+		case "command":
+			healthCheck.Command = v.str(keyPath, value)
+		case "interval":
+			healthCheck.Interval = v.integer(keyPath, value, 5, 300)
+		case "timeout":
+			healthCheck.Timeout = v.integer(keyPath, value, 2, 60)
+		case "retries":
+			healthCheck.Retries = v.integer(keyPath, value, 1, 10)
+		case "start_period":
+			healthCheck.StartPeriod = v.integer(keyPath, value, 0, 300)
+		default:
+			v.errorf(keyPath, "unknown key, must be one of command, interval, timeout, retries and start_period")
+		}
+	}
+	if _, ok := settings["command"]; !ok {
+		v.errorf(path+".command", "is required")
+	}
+	return healthCheck
+}
+
+func (v *validator) dependencies(path string, value interface{}) []*Dependency {
+	list, ok := value.([]interface{})
+	if !ok {
+		v.errorf(path, "must be a list of processes")
+		return nil
+	}
+
+	dependencies := []*Dependency{}
+	for i, item := range list {
+		itemPath := fmt.Sprintf("%s.%d", path, i)
+		// A process name is a shorthand of `{name: NAME, condition: START}`
+		if name, ok := item.(string); ok {
+			dependencies = append(dependencies, &Dependency{Name: name, Condition: "START"})
+			continue
+		}
+		settings, ok := item.(map[string]interface{})
+		if !ok {
+			v.errorf(itemPath, "must be a process name or a mapping of name and condition")
+			continue
+		}
+
+		dependency := &Dependency{Condition: "START"}
+		for key, value := range settings {
+			keyPath := itemPath + "." + key
+			switch key {
+			case "name":
+				dependency.Name = v.str(keyPath, value)
+			case "condition":
+				dependency.Condition = v.str(keyPath, value)
+				if !contains(dependencyConditions, dependency.Condition) {
+					v.errorf(keyPath, "must be one of %s, but got %s", strings.Join(dependencyConditions, ", "), describe(value))
+				}
+			default:
+				v.errorf(keyPath, "unknown key, must be name or condition")
+			}
+		}
+		if dependency.Name == "" {
+			v.errorf(itemPath+".name", "is required")
+			continue
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies
+}
+
+func (v *validator) str(path string, value interface{}) string {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		v.errorf(path, "must be a non-empty string, but got %s", describe(value))
+	}
+	return s
+}
+
+func (v *validator) integer(path string, value interface{}, min int, max int) int {
+	i, ok := value.(int)
+	if !ok {
+		v.errorf(path, "must be an integer, but got %s", describe(value))
+		return 0
+	}
+	if i < min || i > max {
+		v.errorf(path, "must be between %d and %d, but got %d", min, max, i)
+		return 0
+	}
+	return i
+}
+
+func describe(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("%q", value)
+	case map[string]interface{}:
+		return "a mapping"
+	case []interface{}:
+		return "a list"
+	}
+	return fmt.Sprint(value)
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wata727/herogate/container"
+)
+
+func TestParse(t *testing.T) {
+	essential := false
+	manifest := `processes:
+  web:
+    port: 5000
+    cpu: 512
+    memory: 1024
+    healthcheck:
+      command: curl -f http://localhost:5000/
+      interval: 10
+    depends_on:
+      - name: proxy
+        condition: HEALTHY
+  worker:
+    essential: false
+    stop_timeout: 60
+    depends_on:
+      - web
+  proxy:
+    image: envoyproxy/envoy:v1.7.0
+    port: 9901
+    healthcheck:
+      command: curl -f http://localhost:9901/ready
+`
+	result, err := Parse(manifest, "web: bundle exec puma\nworker: bundle exec sidekiq\n")
+	if err != nil {
+		t.Fatalf("Expected error is nil, but get `%s`", err.Error())
+	}
+
+	expected := &Manifest{
+		Processes: map[string]*Process{
+			"web": {
+				Port:        5000,
+				CPU:         512,
+				Memory:      1024,
+				HealthCheck: &HealthCheck{Command: "curl -f http://localhost:5000/", Interval: 10},
+				DependsOn:   []*Dependency{{Name: "proxy", Condition: "HEALTHY"}},
+			},
+			"worker": {
+				Essential:   &essential,
+				StopTimeout: 60,
+				DependsOn:   []*Dependency{{Name: "web", Condition: "START"}},
+			},
+			"proxy": {
+				Image:       "envoyproxy/envoy:v1.7.0",
+				Port:        9901,
+				HealthCheck: &HealthCheck{Command: "curl -f http://localhost:9901/ready"},
+			},
+		},
+	}
+	if !cmp.Equal(expected, result) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, result))
+	}
+	if !cmp.Equal([]string{"proxy"}, result.Sidecars("web: bundle exec puma\nworker: bundle exec sidekiq\n")) {
+		t.Fatalf("Expected sidecars are `[proxy]`, but get `%v`", result.Sidecars("web: bundle exec puma\n"))
+	}
+}
+
+func TestParse__invalid(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Manifest string
+		Expected string
+	}{
+		{
+			Name:     "unknown top level key",
+			Manifest: "process:\n  web:\n    port: 5000\n",
+			Expected: "herogate.yml is invalid:\n  process: unknown key, must be processes",
+		},
+		{
+			Name:     "invalid types and ranges",
+			Manifest: "processes:\n  web:\n    port: http\n    cpu: 0\n    essential: yes please\n    prot: 5000\n",
+			Expected: `herogate.yml is invalid:
+  processes.web.cpu: must be between 1 and 4096, but got 0
+  processes.web.essential: must be true or false, but got "yes please"
+  processes.web.port: must be an integer, but got "http"
+  processes.web.prot: unknown key, must be one of command, image, port, cpu, memory, healthcheck, essential, stop_timeout and depends_on`,
+		},
+		{
+			Name:     "health check without command",
+			Manifest: "processes:\n  web:\n    healthcheck:\n      interval: 10\n",
+			Expected: "herogate.yml is invalid:\n  processes.web.healthcheck.command: is required",
+		},
+		{
+			Name:     "unknown process",
+			Manifest: "processes:\n  proxy:\n    port: 9901\n",
+			Expected: "herogate.yml is invalid:\n  processes.proxy: is not in Procfile, so command or image is required",
+		},
+		{
+			Name:     "invalid dependencies",
+			Manifest: "processes:\n  web:\n    depends_on:\n      - db\n      - name: worker\n        condition: READY\n      - name: worker\n        condition: HEALTHY\n",
+			Expected: `herogate.yml is invalid:
+  processes.web.depends_on.0.name: process ` + "`db`" + ` is not found
+  processes.web.depends_on.1.condition: must be one of START, COMPLETE, SUCCESS, HEALTHY, but got "READY"
+  processes.web.depends_on.2.condition: HEALTHY requires healthcheck of ` + "`worker`",
+		},
+		{
+			Name:     "duplicate ports",
+			Manifest: "processes:\n  web:\n    port: 5000\n  worker:\n    port: 5000\n",
+			Expected: "herogate.yml is invalid:\n  processes.worker.port: 5000 is already used by `web`",
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := Parse(tc.Manifest, "web: bundle exec puma\nworker: bundle exec sidekiq\n")
+		if err == nil {
+			t.Fatalf("Expected error is not nil, but get nil in %s", tc.Name)
+		}
+		if err.Error() != tc.Expected {
+			t.Fatalf("Expected error is `%s`, but get `%s` in %s", tc.Expected, err.Error(), tc.Name)
+		}
+	}
+}
+
+func TestProcessApply(t *testing.T) {
+	essential := false
+	process := &Process{
+		Port:        9901,
+		CPU:         256,
+		Memory:      512,
+		HealthCheck: &HealthCheck{Command: "curl -f http://localhost:9901/ready", Retries: 3},
+		Essential:   &essential,
+		StopTimeout: 30,
+		DependsOn:   []*Dependency{{Name: "web", Condition: "START"}},
+	}
+	definition := container.New("proxy", "envoyproxy/envoy:v1.7.0", nil, []interface{}{}, 80)
+	process.Apply(definition)
+
+	expected := container.New("proxy", "envoyproxy/envoy:v1.7.0", nil, []interface{}{}, 9901)
+	expected.PortMappings = []*container.PortMapping{{ContainerPort: 9901}}
+	expected.CPU = 256
+	expected.Memory = 512
+	expected.HealthCheck = &container.HealthCheck{
+		Command: []string{"CMD-SHELL", "curl -f http://localhost:9901/ready"},
+		Retries: 3,
+	}
+	expected.Essential = &essential
+	expected.StopTimeout = 30
+	expected.DependsOn = []*container.Dependency{{ContainerName: "web", Condition: "START"}}
+	if !cmp.Equal(expected, definition) {
+		t.Fatalf("\nDiff: %s\n", cmp.Diff(expected, definition))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	objects "github.com/wata727/herogate/api/objects"
 	options "github.com/wata727/herogate/api/options"
 	log "github.com/wata727/herogate/log"
+	io "io"
 	reflect "reflect"
 )
 
@@ -48,15 +49,16 @@ func (mr *MockClientInterfaceMockRecorder) ListApps() *gomock.Call {
 }
 
 // CreateApp mocks base method
-func (m *MockClientInterface) CreateApp(appName string) *objects.App {
-	ret := m.ctrl.Call(m, "CreateApp", appName)
+func (m *MockClientInterface) CreateApp(appName string, options *options.CreateApp) (*objects.App, error) {
+	ret := m.ctrl.Call(m, "CreateApp", appName, options)
 	ret0, _ := ret[0].(*objects.App)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CreateApp indicates an expected call of CreateApp
-func (mr *MockClientInterfaceMockRecorder) CreateApp(appName interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApp", reflect.TypeOf((*MockClientInterface)(nil).CreateApp), appName)
+func (mr *MockClientInterfaceMockRecorder) CreateApp(appName, options interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApp", reflect.TypeOf((*MockClientInterface)(nil).CreateApp), appName, options)
 }
 
 // GetAppCreationProgress mocks base method
@@ -121,6 +123,389 @@ func (mr *MockClientInterfaceMockRecorder) DestroyApp(appName interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyApp", reflect.TypeOf((*MockClientInterface)(nil).DestroyApp), appName)
 }
 
+// RepairApp mocks base method
+func (m *MockClientInterface) RepairApp(appName string) (*objects.Repair, error) {
+	ret := m.ctrl.Call(m, "RepairApp", appName)
+	ret0, _ := ret[0].(*objects.Repair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairApp indicates an expected call of RepairApp
+func (mr *MockClientInterfaceMockRecorder) RepairApp(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairApp", reflect.TypeOf((*MockClientInterface)(nil).RepairApp), appName)
+}
+
+// UpgradeApp mocks base method
+func (m *MockClientInterface) UpgradeApp(appName string) error {
+	ret := m.ctrl.Call(m, "UpgradeApp", appName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeApp indicates an expected call of UpgradeApp
+func (mr *MockClientInterfaceMockRecorder) UpgradeApp(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeApp", reflect.TypeOf((*MockClientInterface)(nil).UpgradeApp), appName)
+}
+
+// GetBranch mocks base method
+func (m *MockClientInterface) GetBranch(appName string) (string, error) {
+	ret := m.ctrl.Call(m, "GetBranch", appName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranch indicates an expected call of GetBranch
+func (mr *MockClientInterfaceMockRecorder) GetBranch(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranch", reflect.TypeOf((*MockClientInterface)(nil).GetBranch), appName)
+}
+
+// SetBranch mocks base method
+func (m *MockClientInterface) SetBranch(appName, branch string) error {
+	ret := m.ctrl.Call(m, "SetBranch", appName, branch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBranch indicates an expected call of SetBranch
+func (mr *MockClientInterfaceMockRecorder) SetBranch(appName, branch interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBranch", reflect.TypeOf((*MockClientInterface)(nil).SetBranch), appName, branch)
+}
+
+// SetPipeline mocks base method
+func (m *MockClientInterface) SetPipeline(appName, pipeline, stage string) error {
+	ret := m.ctrl.Call(m, "SetPipeline", appName, pipeline, stage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPipeline indicates an expected call of SetPipeline
+func (mr *MockClientInterfaceMockRecorder) SetPipeline(appName, pipeline, stage interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPipeline", reflect.TypeOf((*MockClientInterface)(nil).SetPipeline), appName, pipeline, stage)
+}
+
+// PromoteApp mocks base method
+func (m *MockClientInterface) PromoteApp(sourceName, targetName string) (*objects.Release, error) {
+	ret := m.ctrl.Call(m, "PromoteApp", sourceName, targetName)
+	ret0, _ := ret[0].(*objects.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteApp indicates an expected call of PromoteApp
+func (mr *MockClientInterfaceMockRecorder) PromoteApp(sourceName, targetName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteApp", reflect.TypeOf((*MockClientInterface)(nil).PromoteApp), sourceName, targetName)
+}
+
+// DescribeReleases mocks base method
+func (m *MockClientInterface) DescribeReleases(appName string) ([]*objects.Release, error) {
+	ret := m.ctrl.Call(m, "DescribeReleases", appName)
+	ret0, _ := ret[0].([]*objects.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeReleases indicates an expected call of DescribeReleases
+func (mr *MockClientInterfaceMockRecorder) DescribeReleases(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeReleases", reflect.TypeOf((*MockClientInterface)(nil).DescribeReleases), appName)
+}
+
+// ReleaseImage mocks base method
+func (m *MockClientInterface) ReleaseImage(appName, image string, processes map[string][]string) (*objects.Release, error) {
+	ret := m.ctrl.Call(m, "ReleaseImage", appName, image, processes)
+	ret0, _ := ret[0].(*objects.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseImage indicates an expected call of ReleaseImage
+func (mr *MockClientInterfaceMockRecorder) ReleaseImage(appName, image, processes interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseImage", reflect.TypeOf((*MockClientInterface)(nil).ReleaseImage), appName, image, processes)
+}
+
+// GetRegistry mocks base method
+func (m *MockClientInterface) GetRegistry(appName string) (*objects.Registry, error) {
+	ret := m.ctrl.Call(m, "GetRegistry", appName)
+	ret0, _ := ret[0].(*objects.Registry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegistry indicates an expected call of GetRegistry
+func (mr *MockClientInterfaceMockRecorder) GetRegistry(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegistry", reflect.TypeOf((*MockClientInterface)(nil).GetRegistry), appName)
+}
+
+// UseS3Source mocks base method
+func (m *MockClientInterface) UseS3Source(appName string) (bool, error) {
+	ret := m.ctrl.Call(m, "UseS3Source", appName)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseS3Source indicates an expected call of UseS3Source
+func (mr *MockClientInterfaceMockRecorder) UseS3Source(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseS3Source", reflect.TypeOf((*MockClientInterface)(nil).UseS3Source), appName)
+}
+
+// DeploySource mocks base method
+func (m *MockClientInterface) DeploySource(appName string, archive io.ReadSeeker) (string, error) {
+	ret := m.ctrl.Call(m, "DeploySource", appName, archive)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeploySource indicates an expected call of DeploySource
+func (mr *MockClientInterfaceMockRecorder) DeploySource(appName, archive interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeploySource", reflect.TypeOf((*MockClientInterface)(nil).DeploySource), appName, archive)
+}
+
+// DescribeTestRuns mocks base method
+func (m *MockClientInterface) DescribeTestRuns(appName string) ([]*objects.TestRun, error) {
+	ret := m.ctrl.Call(m, "DescribeTestRuns", appName)
+	ret0, _ := ret[0].([]*objects.TestRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTestRuns indicates an expected call of DescribeTestRuns
+func (mr *MockClientInterfaceMockRecorder) DescribeTestRuns(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTestRuns", reflect.TypeOf((*MockClientInterface)(nil).DescribeTestRuns), appName)
+}
+
+// GetApproval mocks base method
+func (m *MockClientInterface) GetApproval(appName string) (bool, error) {
+	ret := m.ctrl.Call(m, "GetApproval", appName)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApproval indicates an expected call of GetApproval
+func (mr *MockClientInterfaceMockRecorder) GetApproval(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApproval", reflect.TypeOf((*MockClientInterface)(nil).GetApproval), appName)
+}
+
+// SetApproval mocks base method
+func (m *MockClientInterface) SetApproval(appName string, enabled bool) error {
+	ret := m.ctrl.Call(m, "SetApproval", appName, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetApproval indicates an expected call of SetApproval
+func (mr *MockClientInterfaceMockRecorder) SetApproval(appName, enabled interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetApproval", reflect.TypeOf((*MockClientInterface)(nil).SetApproval), appName, enabled)
+}
+
+// DescribeApprovals mocks base method
+func (m *MockClientInterface) DescribeApprovals(appName string) ([]*objects.Approval, error) {
+	ret := m.ctrl.Call(m, "DescribeApprovals", appName)
+	ret0, _ := ret[0].([]*objects.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeApprovals indicates an expected call of DescribeApprovals
+func (mr *MockClientInterfaceMockRecorder) DescribeApprovals(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeApprovals", reflect.TypeOf((*MockClientInterface)(nil).DescribeApprovals), appName)
+}
+
+// ResolveApproval mocks base method
+func (m *MockClientInterface) ResolveApproval(appName string, approved bool, summary string) (*objects.Approval, error) {
+	ret := m.ctrl.Call(m, "ResolveApproval", appName, approved, summary)
+	ret0, _ := ret[0].(*objects.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveApproval indicates an expected call of ResolveApproval
+func (mr *MockClientInterfaceMockRecorder) ResolveApproval(appName, approved, summary interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveApproval", reflect.TypeOf((*MockClientInterface)(nil).ResolveApproval), appName, approved, summary)
+}
+
+// GetBuildConfig mocks base method
+func (m *MockClientInterface) GetBuildConfig(appName string) (*objects.BuildConfig, error) {
+	ret := m.ctrl.Call(m, "GetBuildConfig", appName)
+	ret0, _ := ret[0].(*objects.BuildConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBuildConfig indicates an expected call of GetBuildConfig
+func (mr *MockClientInterfaceMockRecorder) GetBuildConfig(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBuildConfig", reflect.TypeOf((*MockClientInterface)(nil).GetBuildConfig), appName)
+}
+
+// SetBuildConfig mocks base method
+func (m *MockClientInterface) SetBuildConfig(appName string, build *objects.BuildConfig) error {
+	ret := m.ctrl.Call(m, "SetBuildConfig", appName, build)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBuildConfig indicates an expected call of SetBuildConfig
+func (mr *MockClientInterfaceMockRecorder) SetBuildConfig(appName, build interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBuildConfig", reflect.TypeOf((*MockClientInterface)(nil).SetBuildConfig), appName, build)
+}
+
+// PreviewSetBuildConfig mocks base method
+func (m *MockClientInterface) PreviewSetBuildConfig(appName string, build *objects.BuildConfig) ([]*objects.Change, error) {
+	ret := m.ctrl.Call(m, "PreviewSetBuildConfig", appName, build)
+	ret0, _ := ret[0].([]*objects.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewSetBuildConfig indicates an expected call of PreviewSetBuildConfig
+func (mr *MockClientInterfaceMockRecorder) PreviewSetBuildConfig(appName, build interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewSetBuildConfig", reflect.TypeOf((*MockClientInterface)(nil).PreviewSetBuildConfig), appName, build)
+}
+
+// DescribeBuilds mocks base method
+func (m *MockClientInterface) DescribeBuilds(appName string) ([]*objects.Build, error) {
+	ret := m.ctrl.Call(m, "DescribeBuilds", appName)
+	ret0, _ := ret[0].([]*objects.Build)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeBuilds indicates an expected call of DescribeBuilds
+func (mr *MockClientInterfaceMockRecorder) DescribeBuilds(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeBuilds", reflect.TypeOf((*MockClientInterface)(nil).DescribeBuilds), appName)
+}
+
+// GetBuild mocks base method
+func (m *MockClientInterface) GetBuild(appName, id string) (*objects.Build, error) {
+	ret := m.ctrl.Call(m, "GetBuild", appName, id)
+	ret0, _ := ret[0].(*objects.Build)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBuild indicates an expected call of GetBuild
+func (mr *MockClientInterfaceMockRecorder) GetBuild(appName, id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBuild", reflect.TypeOf((*MockClientInterface)(nil).GetBuild), appName, id)
+}
+
+// DescribeBuildOutput mocks base method
+func (m *MockClientInterface) DescribeBuildOutput(appName, id string) ([]*log.Log, error) {
+	ret := m.ctrl.Call(m, "DescribeBuildOutput", appName, id)
+	ret0, _ := ret[0].([]*log.Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeBuildOutput indicates an expected call of DescribeBuildOutput
+func (mr *MockClientInterfaceMockRecorder) DescribeBuildOutput(appName, id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeBuildOutput", reflect.TypeOf((*MockClientInterface)(nil).DescribeBuildOutput), appName, id)
+}
+
+// CancelBuild mocks base method
+func (m *MockClientInterface) CancelBuild(appName, id string) (*objects.Build, error) {
+	ret := m.ctrl.Call(m, "CancelBuild", appName, id)
+	ret0, _ := ret[0].(*objects.Build)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelBuild indicates an expected call of CancelBuild
+func (mr *MockClientInterfaceMockRecorder) CancelBuild(appName, id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBuild", reflect.TypeOf((*MockClientInterface)(nil).CancelBuild), appName, id)
+}
+
+// GetPort mocks base method
+func (m *MockClientInterface) GetPort(appName string) (int, error) {
+	ret := m.ctrl.Call(m, "GetPort", appName)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPort indicates an expected call of GetPort
+func (mr *MockClientInterfaceMockRecorder) GetPort(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPort", reflect.TypeOf((*MockClientInterface)(nil).GetPort), appName)
+}
+
+// SetPort mocks base method
+func (m *MockClientInterface) SetPort(appName string, port int) error {
+	ret := m.ctrl.Call(m, "SetPort", appName, port)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPort indicates an expected call of SetPort
+func (mr *MockClientInterfaceMockRecorder) SetPort(appName, port interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPort", reflect.TypeOf((*MockClientInterface)(nil).SetPort), appName, port)
+}
+
+// PreviewSetPort mocks base method
+func (m *MockClientInterface) PreviewSetPort(appName string, port int) ([]*objects.Change, error) {
+	ret := m.ctrl.Call(m, "PreviewSetPort", appName, port)
+	ret0, _ := ret[0].([]*objects.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewSetPort indicates an expected call of PreviewSetPort
+func (mr *MockClientInterfaceMockRecorder) PreviewSetPort(appName, port interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewSetPort", reflect.TypeOf((*MockClientInterface)(nil).PreviewSetPort), appName, port)
+}
+
+// GetHealthCheck mocks base method
+func (m *MockClientInterface) GetHealthCheck(appName string) (*objects.HealthCheck, error) {
+	ret := m.ctrl.Call(m, "GetHealthCheck", appName)
+	ret0, _ := ret[0].(*objects.HealthCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheck indicates an expected call of GetHealthCheck
+func (mr *MockClientInterfaceMockRecorder) GetHealthCheck(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheck", reflect.TypeOf((*MockClientInterface)(nil).GetHealthCheck), appName)
+}
+
+// SetHealthCheck mocks base method
+func (m *MockClientInterface) SetHealthCheck(appName string, healthCheck *objects.HealthCheck) error {
+	ret := m.ctrl.Call(m, "SetHealthCheck", appName, healthCheck)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHealthCheck indicates an expected call of SetHealthCheck
+func (mr *MockClientInterfaceMockRecorder) SetHealthCheck(appName, healthCheck interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHealthCheck", reflect.TypeOf((*MockClientInterface)(nil).SetHealthCheck), appName, healthCheck)
+}
+
+// PreviewSetHealthCheck mocks base method
+func (m *MockClientInterface) PreviewSetHealthCheck(appName string, healthCheck *objects.HealthCheck) ([]*objects.Change, error) {
+	ret := m.ctrl.Call(m, "PreviewSetHealthCheck", appName, healthCheck)
+	ret0, _ := ret[0].([]*objects.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewSetHealthCheck indicates an expected call of PreviewSetHealthCheck
+func (mr *MockClientInterfaceMockRecorder) PreviewSetHealthCheck(appName, healthCheck interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewSetHealthCheck", reflect.TypeOf((*MockClientInterface)(nil).PreviewSetHealthCheck), appName, healthCheck)
+}
+
+// PreviewUpgradeApp mocks base method
+func (m *MockClientInterface) PreviewUpgradeApp(appName string) ([]*objects.Change, error) {
+	ret := m.ctrl.Call(m, "PreviewUpgradeApp", appName)
+	ret0, _ := ret[0].([]*objects.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewUpgradeApp indicates an expected call of PreviewUpgradeApp
+func (mr *MockClientInterfaceMockRecorder) PreviewUpgradeApp(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewUpgradeApp", reflect.TypeOf((*MockClientInterface)(nil).PreviewUpgradeApp), appName)
+}
+
 // GetAppDeletionProgress mocks base method
 func (m *MockClientInterface) GetAppDeletionProgress(appName string) int {
 	ret := m.ctrl.Call(m, "GetAppDeletionProgress", appName)
@@ -194,3 +579,42 @@ func (m *MockClientInterface) UnsetEnvVars(appName string, envList []string) err
 func (mr *MockClientInterfaceMockRecorder) UnsetEnvVars(appName, envList interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsetEnvVars", reflect.TypeOf((*MockClientInterface)(nil).UnsetEnvVars), appName, envList)
 }
+
+// PreviewSetEnvVars mocks base method
+func (m *MockClientInterface) PreviewSetEnvVars(appName string, envVars map[string]string) ([]*objects.Change, error) {
+	ret := m.ctrl.Call(m, "PreviewSetEnvVars", appName, envVars)
+	ret0, _ := ret[0].([]*objects.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewSetEnvVars indicates an expected call of PreviewSetEnvVars
+func (mr *MockClientInterfaceMockRecorder) PreviewSetEnvVars(appName, envVars interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewSetEnvVars", reflect.TypeOf((*MockClientInterface)(nil).PreviewSetEnvVars), appName, envVars)
+}
+
+// PreviewUnsetEnvVars mocks base method
+func (m *MockClientInterface) PreviewUnsetEnvVars(appName string, envList []string) ([]*objects.Change, error) {
+	ret := m.ctrl.Call(m, "PreviewUnsetEnvVars", appName, envList)
+	ret0, _ := ret[0].([]*objects.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewUnsetEnvVars indicates an expected call of PreviewUnsetEnvVars
+func (mr *MockClientInterfaceMockRecorder) PreviewUnsetEnvVars(appName, envList interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewUnsetEnvVars", reflect.TypeOf((*MockClientInterface)(nil).PreviewUnsetEnvVars), appName, envList)
+}
+
+// DescribeAppEvents mocks base method
+func (m *MockClientInterface) DescribeAppEvents(appName string) ([]*objects.StackEvent, error) {
+	ret := m.ctrl.Call(m, "DescribeAppEvents", appName)
+	ret0, _ := ret[0].([]*objects.StackEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAppEvents indicates an expected call of DescribeAppEvents
+func (mr *MockClientInterfaceMockRecorder) DescribeAppEvents(appName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAppEvents", reflect.TypeOf((*MockClientInterface)(nil).DescribeAppEvents), appName)
+}